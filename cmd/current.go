@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/samuelenocsson/devops-tui/internal/api"
+	"github.com/samuelenocsson/devops-tui/internal/config"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/pkg/browser"
+	"github.com/samuelenocsson/devops-tui/pkg/git"
+)
+
+// repo is the repository subcommands work in, the one in the current
+// directory
+var repo git.Repo
+
+// runCurrent prints or opens the work item of the checked out git branch
+func runCurrent(args []string) error {
+	flags := flag.NewFlagSet("current", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: devops-tui current [flags]")
+		fmt.Fprintln(flags.Output(), "")
+		fmt.Fprintln(flags.Output(), "Prints the work item started on the current git branch with -set, or else")
+		fmt.Fprintln(flags.Output(), "the one whose ID is in the branch name.")
+		fmt.Fprintln(flags.Output(), "")
+		flags.PrintDefaults()
+	}
+	open := flags.Bool("open", false, "open the work item in the browser")
+	idOnly := flags.Bool("id", false, "print only the work item ID")
+	set := flags.Int("set", 0, "start working on a work item in the current branch")
+	clear := flags.Bool("clear", false, "stop working on the started work item")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	if !repo.IsGitRepo() {
+		return fmt.Errorf("not a git repository")
+	}
+	if *set > 0 || *clear {
+		branch, err := repo.GetCurrentBranch()
+		if err != nil {
+			return err
+		}
+		if *set > 0 {
+			return repo.SetStartedWorkItem(branch, *set)
+		}
+		return repo.ClearStartedWorkItem(branch)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	matcher, err := cfg.BranchMatcher()
+	if err != nil {
+		return err
+	}
+
+	id, err := currentWorkItemID(matcher)
+	if err != nil {
+		return err
+	}
+
+	if *idOnly {
+		fmt.Println(id)
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	if *open {
+		return browser.Open(client.WorkItemWebURL(id))
+	}
+
+	item, err := client.GetWorkItem(id)
+	if err != nil {
+		return fmt.Errorf("loading work item #%d: %w", id, err)
+	}
+	fmt.Printf("#%d %s [%s]\n", item.ID, item.Title, item.State)
+	fmt.Println(client.WorkItemWebURL(item.ID))
+	return nil
+}
+
+// currentWorkItemID returns the work item started on the current branch,
+// or else the one named by it
+func currentWorkItemID(matcher *models.BranchMatcher) (int, error) {
+	branch, err := repo.GetCurrentBranch()
+	if err != nil {
+		return 0, err
+	}
+	if branch == "" {
+		return 0, fmt.Errorf("not on a branch")
+	}
+	if id := repo.StartedWorkItem(branch); id > 0 {
+		return id, nil
+	}
+	id := matcher.WorkItemID(branch)
+	if id == 0 {
+		return 0, fmt.Errorf("no work item ID in branch %s", branch)
+	}
+	return id, nil
+}
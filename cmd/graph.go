@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samuelenocsson/devops-tui/internal/api"
+	"github.com/samuelenocsson/devops-tui/internal/config"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// runGraph prints the dependency graph of a work item or a sprint
+func runGraph(args []string) error {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: devops-tui graph [flags]")
+		fmt.Fprintln(flags.Output(), "")
+		fmt.Fprintln(flags.Output(), "Prints the parent/child and predecessor/successor graph of a work item")
+		fmt.Fprintln(flags.Output(), "or of a sprint. Without -item, the current sprint is used.")
+		fmt.Fprintln(flags.Output(), "")
+		flags.PrintDefaults()
+	}
+	itemID := flags.Int("item", 0, "work item ID to start from")
+	sprint := flags.String("sprint", "current", `sprint name or path, or "current"`)
+	depth := flags.Int("depth", 2, "how many links to follow from the start item(s)")
+	format := flags.String("format", "text", "output format: text, dot or mermaid")
+	output := flags.String("o", "", "write to a file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	render, err := graphRenderer(*format)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	client := api.NewClient(cfg)
+
+	var rootIDs []int
+	if *itemID > 0 {
+		rootIDs = []int{*itemID}
+	} else {
+		rootIDs, err = sprintItemIDs(client, *sprint)
+		if err != nil {
+			return err
+		}
+		// Only the sprint's items, plus what they depend on
+		*depth = 0
+	}
+
+	items, err := client.CollectLinkedItems(rootIDs, *depth)
+	if err != nil {
+		return err
+	}
+
+	statesByType, err := client.GetAllWorkItemTypeStates()
+	if err != nil {
+		// Non-fatal - common closed states are recognized without metadata
+		statesByType = map[string][]models.WorkItemStateInfo{}
+	}
+
+	out := render(models.BuildDependencyGraph(items, statesByType))
+	if *output == "" {
+		fmt.Print(out)
+		return nil
+	}
+	return os.WriteFile(*output, []byte(out), 0644)
+}
+
+// graphRenderer returns the renderer of an output format
+func graphRenderer(format string) (func(*models.DependencyGraph) string, error) {
+	switch strings.ToLower(format) {
+	case "text":
+		return (*models.DependencyGraph).Text, nil
+	case "dot":
+		return (*models.DependencyGraph).DOT, nil
+	case "mermaid":
+		return (*models.DependencyGraph).Mermaid, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (use text, dot or mermaid)", format)
+}
+
+// sprintItemIDs returns the IDs of the work items in a sprint, given by
+// name, path or "current"
+func sprintItemIDs(client *api.Client, sprint string) ([]int, error) {
+	iterations, err := client.GetIterations()
+	if err != nil {
+		return nil, err
+	}
+
+	path := ""
+	for _, it := range iterations {
+		if (sprint == "current" && it.IsCurrent()) ||
+			strings.EqualFold(it.Name, sprint) || strings.EqualFold(it.Path, sprint) {
+			path = it.Path
+			break
+		}
+	}
+	if path == "" {
+		return nil, fmt.Errorf("sprint %q not found", sprint)
+	}
+
+	return client.QueryWorkItemIDs(models.WorkItemQuery{Sprint: path})
+}
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/samuelenocsson/devops-tui/internal/config"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// Hooks devops-tui installs: prepare-commit-msg adds the reference, so it
+// can be seen and edited in the editor, and commit-msg checks the message
+// as written
+const (
+	prepareHook = "prepare-commit-msg"
+	checkHook   = "commit-msg"
+)
+
+// installedHooks are the hooks install writes and uninstall removes
+var installedHooks = []string{prepareHook, checkHook}
+
+// hookMarker identifies hook scripts written by devops-tui, so they can be
+// replaced and removed without touching other hooks
+const hookMarker = "# Installed by devops-tui"
+
+// defaultReference is how commits reference their work item; Azure DevOps
+// links commits mentioning AB#<id> to the item
+const defaultReference = "AB#{id}"
+
+// referencePattern matches work item mentions in a commit message: the
+// configured reference with any ID, or AB#<id>
+func referencePattern(reference string) *regexp.Regexp {
+	parts := strings.Split(reference, "{id}")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile(`AB#\d+|` + strings.Join(parts, `\d+`))
+}
+
+// runHook installs, removes or runs the commit message hook
+func runHook(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: devops-tui hook install|uninstall")
+	}
+
+	switch args[0] {
+	case "install":
+		return installHook(args[1:])
+	case "uninstall":
+		return uninstallHook()
+	case prepareHook:
+		return prepareCommitMsg(args[1:])
+	case checkHook:
+		return commitMsg(args[1:])
+	default:
+		return fmt.Errorf("unknown hook command %q (install, uninstall)", args[0])
+	}
+}
+
+// installHook writes the hooks calling back into devops-tui
+func installHook(args []string) error {
+	flags := flag.NewFlagSet("hook install", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: devops-tui hook install [flags]")
+		fmt.Fprintln(flags.Output(), "")
+		fmt.Fprintln(flags.Output(), "Installs a prepare-commit-msg hook in the current repository that adds")
+		fmt.Fprintln(flags.Output(), "a reference to the started work item, or the one in the branch name,")
+		fmt.Fprintln(flags.Output(), "to commit messages, and a commit-msg hook checking the final message.")
+		fmt.Fprintln(flags.Output(), "")
+		flags.PrintDefaults()
+	}
+	reference := flags.String("reference", defaultReference, `line added to commit messages, {id} is replaced with the work item ID (e.g. "Work-Item: {id}")`)
+	require := flags.Bool("require", false, "refuse commits without a work item")
+	force := flags.Bool("force", false, "replace existing prepare-commit-msg and commit-msg hooks")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if !strings.Contains(*reference, "{id}") {
+		return fmt.Errorf("reference %q doesn't contain {id}", *reference)
+	}
+
+	if !repo.IsGitRepo() {
+		return fmt.Errorf("not a git repository")
+	}
+	// Check every hook first, so none is written when one is in the way
+	paths := make([]string, len(installedHooks))
+	for i, name := range installedHooks {
+		path, err := repo.HookPath(name)
+		if err != nil {
+			return err
+		}
+		if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), hookMarker) && !*force {
+			return fmt.Errorf("%s already exists, use -force to replace it", path)
+		}
+		paths[i] = path
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("finding devops-tui binary: %w", err)
+	}
+
+	hookArgs := "-reference " + shellQuote(*reference)
+	if *require {
+		hookArgs += " -require"
+	}
+	for i, name := range installedHooks {
+		script := fmt.Sprintf("#!/bin/sh\n%s\nexec %s hook %s %s \"$@\"\n",
+			hookMarker, shellQuote(filepath.ToSlash(exe)), name, hookArgs)
+
+		if err := os.MkdirAll(filepath.Dir(paths[i]), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(paths[i], []byte(script), 0755); err != nil {
+			return err
+		}
+		fmt.Println("Installed", paths[i])
+	}
+	return nil
+}
+
+// uninstallHook removes the hooks devops-tui installed
+func uninstallHook() error {
+	if !repo.IsGitRepo() {
+		return fmt.Errorf("not a git repository")
+	}
+	for _, name := range installedHooks {
+		path, err := repo.HookPath(name)
+		if err != nil {
+			return err
+		}
+		existing, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(existing), hookMarker) {
+			return fmt.Errorf("%s wasn't installed by devops-tui, leaving it", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		fmt.Println("Removed", path)
+	}
+	return nil
+}
+
+// hookFlags parses the flags the hooks are run with, followed by git's
+// arguments
+func hookFlags(name string, args []string) (reference string, require bool, gitArgs []string, err error) {
+	flags := flag.NewFlagSet("hook "+name, flag.ContinueOnError)
+	flags.StringVar(&reference, "reference", defaultReference, "line added to commit messages")
+	flags.BoolVar(&require, "require", false, "refuse commits without a work item")
+	if err := flags.Parse(args); err != nil {
+		return "", false, nil, err
+	}
+	if flags.NArg() < 1 {
+		return "", false, nil, fmt.Errorf("missing commit message file")
+	}
+	return reference, require, flags.Args(), nil
+}
+
+// prepareCommitMsg is run by the prepare-commit-msg hook: it adds the work
+// item reference to the message file git passes, before the editor opens.
+// Merges, squashes and amended or reused messages are left alone.
+func prepareCommitMsg(args []string) error {
+	reference, _, gitArgs, err := hookFlags(prepareHook, args)
+	if err != nil {
+		return err
+	}
+	file, source := gitArgs[0], ""
+	if len(gitArgs) > 1 {
+		source = gitArgs[1]
+	}
+	switch source {
+	case "merge", "squash", "commit":
+		return nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	msg := string(content)
+
+	// Messages given with -m or -F keep their comment lines unless edited,
+	// so only what follows a scissors line is git's
+	format := messageFormat{comment: commentPrefix(repo.CommentChar(), msg), strip: source != "message"}
+
+	id, err := currentWorkItemID(hookBranchMatcher())
+	if err != nil {
+		// The commit-msg hook refuses it with -require
+		return nil
+	}
+	line := strings.ReplaceAll(reference, "{id}", strconv.Itoa(id))
+	if strings.Contains(messageBody(msg, format), line) {
+		return nil
+	}
+	return os.WriteFile(file, []byte(appendReference(msg, line, format)), 0644)
+}
+
+// commitMsg is run by the commit-msg hook, once the message is written. A
+// message that is only the added reference aborts the commit, as an empty
+// one would, and with -require messages must reference a work item.
+func commitMsg(args []string) error {
+	reference, require, gitArgs, err := hookFlags(checkHook, args)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(gitArgs[0])
+	if err != nil {
+		return err
+	}
+	msg := string(content)
+	comment := commentPrefix(repo.CommentChar(), msg)
+
+	id, idErr := currentWorkItemID(hookBranchMatcher())
+	if idErr == nil {
+		line := strings.ReplaceAll(reference, "{id}", strconv.Itoa(id))
+		body := messageBody(msg, messageFormat{comment: comment, strip: true})
+		if strings.Contains(body, line) && strings.TrimSpace(strings.ReplaceAll(body, line, "")) == "" {
+			return fmt.Errorf("aborting commit due to empty commit message")
+		}
+		return nil
+	}
+
+	// Messages naming an item themselves are fine; so are merges
+	text := messageBody(msg, messageFormat{comment: comment})
+	if require && !repo.Merging() && !referencePattern(reference).MatchString(text) {
+		return fmt.Errorf("commit refused: %v; start a work item with \"devops-tui current -set <id>\"", idErr)
+	}
+	return nil
+}
+
+// hookBranchMatcher returns the configured branch matcher. Commits
+// shouldn't fail over config problems, so the default patterns are used
+// when the config can't be loaded.
+func hookBranchMatcher() *models.BranchMatcher {
+	if cfg, err := config.Load(); err == nil {
+		if matcher, err := cfg.BranchMatcher(); err == nil {
+			return matcher
+		}
+	}
+	matcher, _ := models.NewBranchMatcher(nil)
+	return matcher
+}
+
+// scissors follows the comment prefix on the line below which git drops
+// everything, e.g. the diff of commit -v
+const scissors = " ------------------------ >8 ------------------------"
+
+// autoCommentChars are the comment characters git picks from with
+// core.commentChar=auto, in order
+const autoCommentChars = "#;@!$%^&|:"
+
+// messageFormat says which lines of a commit message file git drops
+type messageFormat struct {
+	comment string // Comment prefix, see commentPrefix
+	strip   bool   // Comment lines are dropped, not only the scissors line and below
+}
+
+// commentPrefix returns the comment prefix of a message file from the
+// core.commentChar setting. With "auto" git picks one per message; it is
+// the one of its scissors line, or else of the comment lines that end the
+// message.
+func commentPrefix(setting, msg string) string {
+	if setting != "auto" {
+		return setting
+	}
+	lines := strings.Split(msg, "\n")
+	for _, line := range lines {
+		if c, ok := strings.CutSuffix(line, scissors); ok && len(c) == 1 && strings.Contains(autoCommentChars, c) {
+			return c
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			if strings.Contains(autoCommentChars, line[:1]) {
+				return line[:1]
+			}
+			break
+		}
+	}
+	return "#"
+}
+
+// messageBody returns a commit message without the lines git drops
+func messageBody(msg string, format messageFormat) string {
+	lines := strings.Split(msg, "\n")
+	return strings.Join(lines[:commentStart(lines, format)], "\n")
+}
+
+// commentStart returns the index where the lines git drops start: its
+// scissors line, or when comments are stripped the block of comment lines
+// ending the message. Comment lines between lines of text are kept in the
+// body.
+func commentStart(lines []string, format messageFormat) int {
+	end := len(lines)
+	for i, line := range lines {
+		if line == format.comment+scissors {
+			end = i
+			break
+		}
+	}
+	if !format.strip {
+		return end
+	}
+
+	start := end
+	for i := end - 1; i >= 0; i-- {
+		line := lines[i]
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, format.comment) {
+			break
+		}
+		start = i
+	}
+	// Trailing blank lines alone aren't git's
+	if !hasComment(lines[start:end], format.comment) {
+		return end
+	}
+	return start
+}
+
+// hasComment reports whether any of the lines is a comment
+func hasComment(lines []string, comment string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(line, comment) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendReference adds a line to the end of a commit message, separated
+// from the text by a blank line and kept above the lines git drops. An
+// empty message keeps its first line free for the subject.
+func appendReference(msg, line string, format messageFormat) string {
+	lines := strings.Split(msg, "\n")
+	start := commentStart(lines, format)
+	body := strings.TrimRight(strings.Join(lines[:start], "\n"), " \n")
+
+	var b strings.Builder
+	if body == "" {
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(body + "\n\n")
+	}
+	b.WriteString(line + "\n")
+	if start < len(lines) {
+		b.WriteString("\n" + strings.Join(lines[start:], "\n"))
+	}
+	return b.String()
+}
+
+// shellQuote quotes a string for sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import "testing"
+
+const verboseMessage = `fix login
+
+# Please enter the commit message for your changes.
+#
+# ------------------------ >8 ------------------------
+# Do not modify or remove the line above.
+diff --git a/login.go b/login.go
+#include <stdio.h>
+`
+
+func TestMessageBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		format messageFormat
+		want   string
+	}{
+		{
+			name:   "-m message starting with the comment char",
+			msg:    "#42 fix login\n",
+			format: messageFormat{comment: "#"},
+			want:   "#42 fix login\n",
+		},
+		{
+			name:   "edited message with template",
+			msg:    "fix login\n\n# Please enter the commit message for your changes.\n#\n",
+			format: messageFormat{comment: "#", strip: true},
+			want:   "fix login",
+		},
+		{
+			name:   "comment lines between text are kept",
+			msg:    "fix login\n# not trailing\nmore\n",
+			format: messageFormat{comment: "#", strip: true},
+			want:   "fix login\n# not trailing\nmore\n",
+		},
+		{
+			name:   "commit -v",
+			msg:    verboseMessage,
+			format: messageFormat{comment: "#", strip: true},
+			want:   "fix login",
+		},
+		{
+			name:   "commit -v with -m",
+			msg:    "#42 fix login\n" + "# ------------------------ >8 ------------------------\ndiff\n",
+			format: messageFormat{comment: "#"},
+			want:   "#42 fix login",
+		},
+		{
+			name:   "custom comment char",
+			msg:    "#42 fix login\n\n; Please enter the commit message for your changes.\n",
+			format: messageFormat{comment: ";", strip: true},
+			want:   "#42 fix login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageBody(tt.msg, tt.format); got != tt.want {
+				t.Errorf("messageBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendReference(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		format messageFormat
+		want   string
+	}{
+		{
+			name:   "-m message starting with the comment char",
+			msg:    "#42 fix login\n",
+			format: messageFormat{comment: "#"},
+			want:   "#42 fix login\n\nAB#7\n",
+		},
+		{
+			name:   "empty editor template",
+			msg:    "\n# Please enter the commit message for your changes.\n",
+			format: messageFormat{comment: "#", strip: true},
+			want:   "\n\nAB#7\n\n\n# Please enter the commit message for your changes.\n",
+		},
+		{
+			name:   "commit -v",
+			msg:    verboseMessage,
+			format: messageFormat{comment: "#", strip: true},
+			want: "fix login\n\nAB#7\n\n\n# Please enter the commit message for your changes.\n#\n" +
+				"# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\n" +
+				"diff --git a/login.go b/login.go\n#include <stdio.h>\n",
+		},
+		{
+			name:   "custom comment char",
+			msg:    "#42 fix login\n\n; Please enter the commit message for your changes.\n",
+			format: messageFormat{comment: ";", strip: true},
+			want:   "#42 fix login\n\nAB#7\n\n\n; Please enter the commit message for your changes.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendReference(tt.msg, "AB#7", tt.format); got != tt.want {
+				t.Errorf("appendReference() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting string
+		msg     string
+		want    string
+	}{
+		{name: "configured", setting: ";", msg: "fix\n# x\n", want: ";"},
+		{name: "auto from scissors", setting: "auto", msg: "fix\n; ------------------------ >8 ------------------------\n", want: ";"},
+		{name: "auto from trailing comments", setting: "auto", msg: "#42 fix\n\n@ Please enter\n", want: "@"},
+		{name: "auto without comments", setting: "auto", msg: "fix\n", want: "#"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commentPrefix(tt.setting, tt.msg); got != tt.want {
+				t.Errorf("commentPrefix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
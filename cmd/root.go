@@ -10,8 +10,19 @@ import (
 	"github.com/samuelenocsson/devops-tui/internal/ui"
 )
 
-// Execute runs the application
+// Execute runs the application, or a subcommand when one is given
 func Execute() error {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "graph":
+			return runGraph(os.Args[2:])
+		case "current":
+			return runCurrent(os.Args[2:])
+		case "hook":
+			return runHook(os.Args[2:])
+		}
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -28,12 +39,14 @@ func Execute() error {
 	client := api.NewClient(cfg)
 
 	// Create and run the TUI
-	app := ui.NewApp(client)
+	out := ui.NewTerminal(os.Stdout)
+	app := ui.NewApp(client, cfg, out)
 
 	p := tea.NewProgram(
 		app,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithOutput(out),
 	)
 
 	if _, err := p.Run(); err != nil {
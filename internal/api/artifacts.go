@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// ResolveArtifacts resolves artifact links (pull requests, commits, branches
+// and builds) through the Git and Build APIs. Links that fail to resolve are
+// returned with Err set, so one deleted branch doesn't hide the others.
+func (c *Client) ResolveArtifacts(urls []string) map[string]models.Artifact {
+	artifacts := make(map[string]models.Artifact, len(urls))
+	repos := make(map[string]repositoryAPIItem)
+
+	for _, u := range urls {
+		ref, ok := models.ParseArtifactURL(u)
+		if !ok {
+			continue
+		}
+		artifact := models.Artifact{Ref: ref}
+		if err := c.resolveArtifact(&artifact, repos); err != nil {
+			artifact.Err = err
+		}
+		artifacts[u] = artifact
+	}
+
+	return artifacts
+}
+
+// resolveArtifact fills in the details of an artifact. repos caches
+// repositories by ID across the artifacts of one item.
+func (c *Client) resolveArtifact(a *models.Artifact, repos map[string]repositoryAPIItem) error {
+	ref := a.Ref
+
+	repo := func() (repositoryAPIItem, error) {
+		if r, ok := repos[ref.RepositoryID]; ok {
+			return r, nil
+		}
+		r, err := c.getRepository(ref.ProjectID, ref.RepositoryID)
+		if err != nil {
+			return repositoryAPIItem{}, err
+		}
+		repos[ref.RepositoryID] = r
+		return r, nil
+	}
+
+	switch ref.Kind {
+	case models.ArtifactPullRequest:
+		id, err := strconv.Atoi(ref.ID)
+		if err != nil {
+			return fmt.Errorf("invalid pull request ID %q", ref.ID)
+		}
+		pr, err := c.GetPullRequest(ref.ProjectID, ref.RepositoryID, id)
+		if err != nil {
+			return fmt.Errorf("fetching pull request !%d: %w", id, err)
+		}
+		a.PullRequest = &pr
+		a.Repository = pr.Repository
+		a.WebURL = pr.WebURL
+		a.Build, err = c.GetLatestBuild(ref.ProjectID, ref.RepositoryID, fmt.Sprintf("refs/pull/%d/merge", id))
+		if err != nil {
+			return fmt.Errorf("fetching builds of pull request !%d: %w", id, err)
+		}
+
+	case models.ArtifactCommit:
+		commit, err := c.GetCommit(ref.ProjectID, ref.RepositoryID, ref.ID)
+		if err != nil {
+			return fmt.Errorf("fetching commit %s: %w", models.ShortSHA(ref.ID), err)
+		}
+		a.Commit = &commit
+		a.WebURL = commit.WebURL
+		if r, err := repo(); err == nil {
+			a.Repository = r.Name
+		}
+
+	case models.ArtifactBranch:
+		r, err := repo()
+		if err != nil {
+			return fmt.Errorf("fetching repository of branch %s: %w", ref.ID, err)
+		}
+		a.Repository = r.Name
+		a.WebURL = fmt.Sprintf("%s?version=GB%s", r.WebURL, url.QueryEscape(ref.ID))
+		a.Build, err = c.GetLatestBuild(ref.ProjectID, ref.RepositoryID, "refs/heads/"+ref.ID)
+		if err != nil {
+			return fmt.Errorf("fetching builds of branch %s: %w", ref.ID, err)
+		}
+
+	case models.ArtifactBuild:
+		id, err := strconv.Atoi(ref.ID)
+		if err != nil {
+			return fmt.Errorf("invalid build ID %q", ref.ID)
+		}
+		// Build links don't name their project, assume the configured one
+		build, err := c.GetBuild("", id)
+		if err != nil {
+			return fmt.Errorf("fetching build %d: %w", id, err)
+		}
+		a.Build = &build
+		a.WebURL = build.WebURL
+
+	default:
+		return fmt.Errorf("unsupported artifact link %s", ref.URL)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// buildAPIItem represents a build from the API
+type buildAPIItem struct {
+	ID          int    `json:"id"`
+	BuildNumber string `json:"buildNumber"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	Definition  struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"definition"`
+	SourceBranch string             `json:"sourceBranch"`
+	RequestedFor identityRefAPIItem `json:"requestedFor"`
+	QueueTime    time.Time          `json:"queueTime"`
+	StartTime    time.Time          `json:"startTime"`
+	FinishTime   time.Time          `json:"finishTime"`
+	Links        struct {
+		Web struct {
+			Href string `json:"href"`
+		} `json:"web"`
+	} `json:"_links"`
+}
+
+// buildsResponse represents the response from the builds API
+type buildsResponse struct {
+	Count int            `json:"count"`
+	Value []buildAPIItem `json:"value"`
+}
+
+// convertBuild converts an API build to the model
+func convertBuild(item buildAPIItem) models.Build {
+	return models.Build{
+		ID:           item.ID,
+		Number:       item.BuildNumber,
+		DefinitionID: item.Definition.ID,
+		Definition:   item.Definition.Name,
+		Status:       item.Status,
+		Result:       item.Result,
+		SourceBranch: item.SourceBranch,
+		RequestedFor: item.RequestedFor.DisplayName,
+		RequesterID:  item.RequestedFor.UniqueName,
+		QueueTime:    item.QueueTime,
+		StartTime:    item.StartTime,
+		FinishTime:   item.FinishTime,
+		WebURL:       item.Links.Web.Href,
+	}
+}
+
+// GetBuild fetches a build of a project. An empty projectID means the
+// configured project.
+func (c *Client) GetBuild(projectID string, id int) (models.Build, error) {
+	resp, err := c.getWithBase(c.projectAPIURL(projectID), fmt.Sprintf("/build/builds/%d", id))
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	var item buildAPIItem
+	if err := decode(resp, &item); err != nil {
+		return models.Build{}, err
+	}
+	return convertBuild(item), nil
+}
+
+// GetLatestBuild fetches the most recently queued build of a branch of a
+// repository, e.g. "refs/pull/42/merge" for a pull request. It returns nil
+// if the branch has no builds.
+func (c *Client) GetLatestBuild(projectID, repositoryID, branch string) (*models.Build, error) {
+	query := url.Values{}
+	query.Set("branchName", branch)
+	query.Set("repositoryId", repositoryID)
+	query.Set("repositoryType", "TfsGit")
+	query.Set("queryOrder", "queueTimeDescending")
+	query.Set("$top", "1")
+
+	resp, err := c.getWithBase(c.projectAPIURL(projectID), "/build/builds?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp buildsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+	if len(apiResp.Value) == 0 {
+		return nil, nil
+	}
+
+	build := convertBuild(apiResp.Value[0])
+	return &build, nil
+}
+
+// buildDefinitionAPIItem represents a pipeline definition from the API
+type buildDefinitionAPIItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// buildDefinitionsResponse represents the response from the definitions API
+type buildDefinitionsResponse struct {
+	Count int                      `json:"count"`
+	Value []buildDefinitionAPIItem `json:"value"`
+}
+
+// GetBuildDefinitions fetches the pipelines of the configured project,
+// sorted by name
+func (c *Client) GetBuildDefinitions() ([]models.BuildDefinition, error) {
+	resp, err := c.get("/build/definitions?queryOrder=definitionNameAscending")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp buildDefinitionsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	definitions := make([]models.BuildDefinition, 0, len(apiResp.Value))
+	for _, item := range apiResp.Value {
+		definitions = append(definitions, models.BuildDefinition{
+			ID:   item.ID,
+			Name: item.Name,
+			Path: item.Path,
+		})
+	}
+	return definitions, nil
+}
+
+// GetRecentBuilds fetches the most recently queued runs of the configured
+// project, of one pipeline when definitionID is set
+func (c *Client) GetRecentBuilds(definitionID, top int) ([]models.Build, error) {
+	query := url.Values{}
+	query.Set("queryOrder", "queueTimeDescending")
+	query.Set("$top", strconv.Itoa(top))
+	if definitionID > 0 {
+		query.Set("definitions", strconv.Itoa(definitionID))
+	}
+
+	resp, err := c.get("/build/builds?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp buildsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	builds := make([]models.Build, 0, len(apiResp.Value))
+	for _, item := range apiResp.Value {
+		builds = append(builds, convertBuild(item))
+	}
+	return builds, nil
+}
+
+// timelineRecordAPIItem represents a stage, job or step of a build timeline
+type timelineRecordAPIItem struct {
+	ID           string    `json:"id"`
+	ParentID     string    `json:"parentId"`
+	Type         string    `json:"type"`
+	Name         string    `json:"name"`
+	Identifier   string    `json:"identifier"`
+	State        string    `json:"state"`
+	Result       string    `json:"result"`
+	Order        int       `json:"order"`
+	Attempt      int       `json:"attempt"`
+	StartTime    time.Time `json:"startTime"`
+	FinishTime   time.Time `json:"finishTime"`
+	ErrorCount   int       `json:"errorCount"`
+	WarningCount int       `json:"warningCount"`
+	Log          *struct {
+		ID int `json:"id"`
+	} `json:"log"`
+}
+
+// timelineResponse represents the response from the timeline API
+type timelineResponse struct {
+	Records []timelineRecordAPIItem `json:"records"`
+}
+
+// GetBuildTimeline fetches the stages, jobs and steps of a build of the
+// configured project
+func (c *Client) GetBuildTimeline(buildID int) ([]models.TimelineRecord, error) {
+	resp, err := c.get(fmt.Sprintf("/build/builds/%d/timeline", buildID))
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp timelineResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	records := make([]models.TimelineRecord, 0, len(apiResp.Records))
+	for _, item := range apiResp.Records {
+		record := models.TimelineRecord{
+			ID:         item.ID,
+			ParentID:   item.ParentID,
+			Type:       item.Type,
+			Name:       item.Name,
+			Identifier: item.Identifier,
+			State:      item.State,
+			Result:     item.Result,
+			Order:      item.Order,
+			Attempt:    item.Attempt,
+			StartTime:  item.StartTime,
+			FinishTime: item.FinishTime,
+			Errors:     item.ErrorCount,
+			Warnings:   item.WarningCount,
+		}
+		if item.Log != nil {
+			record.LogID = item.Log.ID
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// GetBuildLog fetches the lines of a build log of the configured project
+// from startLine (1-based) on, so logs being written can be tailed. Lines
+// start with their timestamp, and keep the ANSI colors of the tools that
+// wrote them.
+func (c *Client) GetBuildLog(buildID, logID, startLine int) ([]string, error) {
+	endpoint := fmt.Sprintf("/build/builds/%d/logs/%d", buildID, logID)
+	if startLine > 1 {
+		endpoint += fmt.Sprintf("?startLine=%d", startLine)
+	}
+	resp, err := c.get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Served as plain text, unless JSON is negotiated
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var apiResp struct {
+			Value []string `json:"value"`
+		}
+		if err := decode(resp, &apiResp); err != nil {
+			return nil, err
+		}
+		return apiResp.Value, nil
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading log: %w", err)
+	}
+	text := strings.TrimSuffix(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
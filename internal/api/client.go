@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/samuelenocsson/devops-tui/internal/config"
@@ -90,61 +91,79 @@ func (c *Client) getWithBase(baseURL, endpoint string) (*http.Response, error) {
 		url = fmt.Sprintf("%s/%s", baseURL, endpoint)
 	}
 
-	// Add API version
-	if len(url) > 0 {
-		separator := "?"
-		if len(url) > 0 && url[len(url)-1] != '?' {
-			for _, c := range url {
-				if c == '?' {
-					separator = "&"
-					break
-				}
-			}
-		}
-		url = fmt.Sprintf("%s%sapi-version=%s", url, separator, apiVersion)
-	}
-
-	return c.doRequest("GET", url, nil)
+	return c.doRequest("GET", withAPIVersion(url), nil)
 }
 
 // post performs a POST request
 func (c *Client) post(endpoint string, body io.Reader) (*http.Response, error) {
+	return c.postWithBase(c.baseURL, endpoint, body)
+}
+
+// postWithBase performs a POST request with a specific base URL
+func (c *Client) postWithBase(baseURL, endpoint string, body io.Reader) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+	if endpoint[0] != '/' {
+		url = fmt.Sprintf("%s/%s", baseURL, endpoint)
+	}
+
+	return c.doRequest("POST", withAPIVersion(url), body)
+}
+
+// patch performs a PATCH request (for work item updates)
+func (c *Client) patch(endpoint string, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 	if endpoint[0] != '/' {
 		url = fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 	}
 
-	// Add API version
-	separator := "?"
-	for _, ch := range url {
-		if ch == '?' {
-			separator = "&"
-			break
-		}
+	return c.doRequestWithContentType("PATCH", withAPIVersion(url), body, "application/json-patch+json")
+}
+
+// patchJSONWithBase performs a PATCH request with a plain JSON body (for
+// updates outside work items, e.g. pull requests)
+func (c *Client) patchJSONWithBase(baseURL, endpoint string, body io.Reader) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+	if endpoint[0] != '/' {
+		url = fmt.Sprintf("%s/%s", baseURL, endpoint)
 	}
-	url = fmt.Sprintf("%s%sapi-version=%s", url, separator, apiVersion)
 
-	return c.doRequest("POST", url, body)
+	return c.doRequest("PATCH", withAPIVersion(url), body)
 }
 
-// patch performs a PATCH request (for work item updates)
-func (c *Client) patch(endpoint string, body io.Reader) (*http.Response, error) {
+// putWithBase performs a PUT request with a plain JSON body
+func (c *Client) putWithBase(baseURL, endpoint string, body io.Reader) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", baseURL, endpoint)
+	if endpoint[0] != '/' {
+		url = fmt.Sprintf("%s/%s", baseURL, endpoint)
+	}
+
+	return c.doRequest("PUT", withAPIVersion(url), body)
+}
+
+// postPatch performs a POST request with a JSON patch body (for creating work items)
+func (c *Client) postPatch(endpoint string, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 	if endpoint[0] != '/' {
 		url = fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 	}
 
-	// Add API version
-	separator := "?"
-	for _, ch := range url {
-		if ch == '?' {
-			separator = "&"
-			break
-		}
-	}
-	url = fmt.Sprintf("%s%sapi-version=%s", url, separator, apiVersion)
+	return c.doRequestWithContentType("POST", withAPIVersion(url), body, "application/json-patch+json")
+}
 
-	return c.doRequestWithContentType("PATCH", url, body, "application/json-patch+json")
+// withAPIVersion adds the API version to a request URL, unless the endpoint
+// pins its own, e.g. preview APIs
+func withAPIVersion(url string) string {
+	if strings.Contains(url, "api-version=") {
+		return url
+	}
+	separator := "&"
+	switch {
+	case !strings.Contains(url, "?"):
+		separator = "?"
+	case strings.HasSuffix(url, "?"):
+		separator = ""
+	}
+	return url + separator + "api-version=" + apiVersion
 }
 
 // decode decodes a JSON response into the given target
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// commentsAPIVersion is the version of the work item comments API, which
+// is still in preview
+const commentsAPIVersion = "7.1-preview.4"
+
+// workItemCommentsResponse represents the comments of a work item
+type workItemCommentsResponse struct {
+	Comments []workItemCommentAPIItem `json:"comments"`
+}
+
+type workItemCommentAPIItem struct {
+	WorkItemID  int         `json:"workItemId"`
+	CommentID   int         `json:"commentId"`
+	Text        string      `json:"text"`
+	CreatedBy   identityRef `json:"createdBy"`
+	CreatedDate time.Time   `json:"createdDate"`
+	IsDeleted   bool        `json:"isDeleted"`
+	Mentions    []struct {
+		TargetID string `json:"targetId"`
+	} `json:"mentions"`
+}
+
+// mentionRe matches the mention markup of a comment, capturing the
+// identity ID
+var mentionRe = regexp.MustCompile(`data-vss-mention="version:[0-9.]+,([^"]+)"`)
+
+// lineBreakRe matches the tags that end a line in comment HTML
+var lineBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</(div|p|li|h[1-6])>`)
+
+// identityPickerResponse represents the identities matching a search
+type identityPickerResponse struct {
+	Results []struct {
+		Identities []struct {
+			LocalID       string `json:"localId"`
+			DisplayName   string `json:"displayName"`
+			SignInAddress string `json:"signInAddress"`
+			Mail          string `json:"mail"`
+		} `json:"identities"`
+	} `json:"results"`
+}
+
+// GetWorkItemComments fetches the latest comments in the discussion of a
+// work item, newest first
+func (c *Client) GetWorkItemComments(id int, top int) ([]models.WorkItemComment, error) {
+	endpoint := fmt.Sprintf("/wit/workItems/%d/comments?$top=%d&order=desc&api-version=%s", id, top, commentsAPIVersion)
+	resp, err := c.get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("loading comments of #%d: %w", id, err)
+	}
+
+	var apiResp workItemCommentsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	comments := make([]models.WorkItemComment, 0, len(apiResp.Comments))
+	for _, item := range apiResp.Comments {
+		if item.IsDeleted {
+			continue
+		}
+		comment := models.WorkItemComment{
+			ID:         item.CommentID,
+			WorkItemID: item.WorkItemID,
+			Author: models.TeamMember{
+				ID:          item.CreatedBy.ID,
+				DisplayName: item.CreatedBy.DisplayName,
+				UniqueName:  item.CreatedBy.UniqueName,
+			},
+			Text:    stripHTML(lineBreakRe.ReplaceAllString(item.Text, "\n")),
+			Created: item.CreatedDate,
+		}
+		for _, mention := range item.Mentions {
+			comment.MentionIDs = append(comment.MentionIDs, mention.TargetID)
+		}
+		// Not every API version lists the mentions; the markup always has them
+		for _, match := range mentionRe.FindAllStringSubmatch(item.Text, -1) {
+			comment.MentionIDs = append(comment.MentionIDs, strings.TrimSpace(match[1]))
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+// AddWorkItemComment adds a comment, in HTML, to the discussion of a work item
+func (c *Client) AddWorkItemComment(id int, text string) error {
+	bodyBytes, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("marshaling comment: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/wit/workItems/%d/comments?api-version=%s", id, commentsAPIVersion)
+	resp, err := c.post(endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("commenting on #%d: %w", id, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// mentionCommentsChecked is how many of the latest comments of an item are
+// looked through for mentions
+const mentionCommentsChecked = 20
+
+// mentionCommentLoads is how many items' comments are loaded at once
+const mentionCommentLoads = 5
+
+// GetMentions returns the recent discussion comments that mention an
+// identity, newest first. The items are the ones Azure DevOps lists as
+// mentioning me (@RecentMentions, the last 30 days), up to top of them.
+// Items whose comments can't be loaded are skipped, with their errors.
+func (c *Client) GetMentions(identityID string, top int) (mentions []models.Mention, skipped []error, err error) {
+	query := `SELECT [System.Id]
+FROM WorkItems
+WHERE [System.TeamProject] = @project
+  AND [System.Id] IN (@RecentMentions)
+ORDER BY [System.ChangedDate] DESC`
+
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	resp, err := c.post(fmt.Sprintf("/wit/wiql?$top=%d", top), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var wiqlResp wiqlResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(wiqlResp.WorkItems))
+	for _, wi := range wiqlResp.WorkItems {
+		ids = append(ids, strconv.Itoa(wi.ID))
+	}
+	items, err := c.GetWorkItems(ids, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		sema = make(chan struct{}, mentionCommentLoads)
+	)
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sema <- struct{}{}
+			defer func() { <-sema }()
+
+			comments, err := c.GetWorkItemComments(item.ID, mentionCommentsChecked)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				skipped = append(skipped, err)
+				return
+			}
+			for _, comment := range comments {
+				if comment.Mentions(identityID) {
+					mentions = append(mentions, models.Mention{Item: item, Comment: comment})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(items) > 0 && len(skipped) == len(items) {
+		return nil, nil, skipped[0]
+	}
+	models.SortMentions(mentions)
+	return mentions, skipped, nil
+}
+
+// SearchIdentities finds people in the organization by name or e-mail
+// through the identity picker, for mentioning them
+func (c *Client) SearchIdentities(query string) ([]models.TeamMember, error) {
+	reqBody := map[string]interface{}{
+		"query":           query,
+		"identityTypes":   []string{"user"},
+		"operationScopes": []string{"ims", "source"},
+		"options":         map[string]int{"MinResults": 5, "MaxResults": 20},
+		"properties":      []string{"DisplayName", "SignInAddress", "Mail"},
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling identity search: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/IdentityPicker/Identities?api-version=7.1-preview.1", c.organization)
+	resp, err := c.doRequest("POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("searching people: %w", err)
+	}
+
+	var apiResp identityPickerResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	var people []models.TeamMember
+	for _, result := range apiResp.Results {
+		for _, identity := range result.Identities {
+			// Identities not yet materialized in the organization can't be
+			// mentioned
+			if identity.LocalID == "" {
+				continue
+			}
+			account := identity.SignInAddress
+			if account == "" {
+				account = identity.Mail
+			}
+			people = append(people, models.TeamMember{
+				ID:          identity.LocalID,
+				DisplayName: identity.DisplayName,
+				UniqueName:  account,
+			})
+		}
+	}
+	return people, nil
+}
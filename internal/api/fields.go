@@ -0,0 +1,48 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// fieldsResponse represents the API response for work item fields
+type fieldsResponse struct {
+	Count int            `json:"count"`
+	Value []fieldAPIItem `json:"value"`
+}
+
+type fieldAPIItem struct {
+	Name          string `json:"name"`
+	ReferenceName string `json:"referenceName"`
+	Type          string `json:"type"`
+	ReadOnly      bool   `json:"readOnly"`
+}
+
+// GetFields fetches all work item field definitions for the project
+func (c *Client) GetFields() ([]models.FieldDefinition, error) {
+	resp, err := c.get("/wit/fields")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp fieldsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	fields := make([]models.FieldDefinition, 0, apiResp.Count)
+	for _, item := range apiResp.Value {
+		fields = append(fields, models.FieldDefinition{
+			Name:          item.Name,
+			ReferenceName: item.ReferenceName,
+			Type:          item.Type,
+		})
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+
+	return fields, nil
+}
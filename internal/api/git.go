@@ -0,0 +1,260 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// repositoryAPIItem represents a Git repository from the API
+type repositoryAPIItem struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DefaultBranch string `json:"defaultBranch"`
+	WebURL        string `json:"webUrl"`
+	Project       struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// identityRefAPIItem represents a user reference from the API
+type identityRefAPIItem struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// reviewerAPIItem represents a pull request reviewer from the API
+type reviewerAPIItem struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	Vote        int    `json:"vote"`
+	IsRequired  bool   `json:"isRequired"`
+	IsContainer bool   `json:"isContainer"`
+}
+
+// pullRequestAPIItem represents a pull request from the API
+type pullRequestAPIItem struct {
+	PullRequestID int                `json:"pullRequestId"`
+	Title         string             `json:"title"`
+	Description   string             `json:"description"`
+	Status        string             `json:"status"`
+	IsDraft       bool               `json:"isDraft"`
+	CreatedBy     identityRefAPIItem `json:"createdBy"`
+	CreationDate  time.Time          `json:"creationDate"`
+	SourceRefName string             `json:"sourceRefName"`
+	TargetRefName string             `json:"targetRefName"`
+	Repository    repositoryAPIItem  `json:"repository"`
+	Reviewers     []reviewerAPIItem  `json:"reviewers"`
+}
+
+// commitAPIItem represents a Git commit from the API
+type commitAPIItem struct {
+	CommitID string `json:"commitId"`
+	Comment  string `json:"comment"`
+	Author   struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	RemoteURL string `json:"remoteUrl"`
+}
+
+// projectAPIURL returns the API base URL of a project given by name or ID
+func (c *Client) projectAPIURL(project string) string {
+	if project == "" {
+		return c.baseURL
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis", c.organization, url.PathEscape(project))
+}
+
+// getRepository fetches a Git repository of a project by ID or name
+func (c *Client) getRepository(projectID, repositoryID string) (repositoryAPIItem, error) {
+	endpoint := fmt.Sprintf("/git/repositories/%s", url.PathEscape(repositoryID))
+	resp, err := c.getWithBase(c.projectAPIURL(projectID), endpoint)
+	if err != nil {
+		return repositoryAPIItem{}, err
+	}
+
+	var repo repositoryAPIItem
+	if err := decode(resp, &repo); err != nil {
+		return repositoryAPIItem{}, err
+	}
+	return repo, nil
+}
+
+// GetRepository fetches a Git repository of a project by name or ID
+func (c *Client) GetRepository(project, repository string) (models.Repository, error) {
+	repo, err := c.getRepository(project, repository)
+	if err != nil {
+		return models.Repository{}, fmt.Errorf("fetching repository %s: %w", repository, err)
+	}
+	return models.Repository{
+		ID:            repo.ID,
+		Name:          repo.Name,
+		ProjectID:     repo.Project.ID,
+		Project:       repo.Project.Name,
+		DefaultBranch: models.BranchName(repo.DefaultBranch),
+		WebURL:        repo.WebURL,
+	}, nil
+}
+
+// GetPullRequest fetches a pull request of a repository
+func (c *Client) GetPullRequest(projectID, repositoryID string, id int) (models.PullRequest, error) {
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullrequests/%d", url.PathEscape(repositoryID), id)
+	resp, err := c.getWithBase(c.projectAPIURL(projectID), endpoint)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	var item pullRequestAPIItem
+	if err := decode(resp, &item); err != nil {
+		return models.PullRequest{}, err
+	}
+	return c.convertPullRequest(item), nil
+}
+
+// convertPullRequest converts an API pull request to the model
+func (c *Client) convertPullRequest(item pullRequestAPIItem) models.PullRequest {
+	pr := models.PullRequest{
+		ID:           item.PullRequestID,
+		Title:        item.Title,
+		Description:  item.Description,
+		Status:       item.Status,
+		IsDraft:      item.IsDraft,
+		CreatedBy:    item.CreatedBy.DisplayName,
+		CreatorID:    item.CreatedBy.UniqueName,
+		Created:      item.CreationDate,
+		Repository:   item.Repository.Name,
+		RepositoryID: item.Repository.ID,
+		ProjectID:    item.Repository.Project.ID,
+		SourceBranch: models.BranchName(item.SourceRefName),
+		TargetBranch: models.BranchName(item.TargetRefName),
+		WebURL:       c.pullRequestWebURL(item.Repository, item.PullRequestID),
+	}
+	for _, r := range item.Reviewers {
+		pr.Reviewers = append(pr.Reviewers, models.Reviewer{
+			ID:       r.ID,
+			Name:     r.DisplayName,
+			Account:  r.UniqueName,
+			Vote:     r.Vote,
+			Required: r.IsRequired,
+			IsGroup:  r.IsContainer,
+		})
+	}
+	return pr
+}
+
+// pullRequestWebURL returns the web URL of a pull request
+func (c *Client) pullRequestWebURL(repo repositoryAPIItem, id int) string {
+	if repo.WebURL != "" {
+		return fmt.Sprintf("%s/pullrequest/%d", repo.WebURL, id)
+	}
+	project := repo.Project.Name
+	if project == "" {
+		project = c.project
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_git/%s/pullrequest/%d",
+		c.organization, url.PathEscape(project), url.PathEscape(repo.Name), id)
+}
+
+// GetCommit fetches a commit of a repository
+func (c *Client) GetCommit(projectID, repositoryID, sha string) (models.Commit, error) {
+	endpoint := fmt.Sprintf("/git/repositories/%s/commits/%s", url.PathEscape(repositoryID), url.PathEscape(sha))
+	resp, err := c.getWithBase(c.projectAPIURL(projectID), endpoint)
+	if err != nil {
+		return models.Commit{}, err
+	}
+
+	var item commitAPIItem
+	if err := decode(resp, &item); err != nil {
+		return models.Commit{}, err
+	}
+
+	return models.Commit{
+		SHA:     item.CommitID,
+		Message: item.Comment,
+		Author:  item.Author.Name,
+		WebURL:  item.RemoteURL,
+	}, nil
+}
+
+// createPullRequestRequest is the request body for creating a pull request
+type createPullRequestRequest struct {
+	SourceRefName string               `json:"sourceRefName"`
+	TargetRefName string               `json:"targetRefName"`
+	Title         string               `json:"title"`
+	Description   string               `json:"description"`
+	WorkItemRefs  []resourceRefAPIItem `json:"workItemRefs,omitempty"`
+	Reviewers     []resourceRefAPIItem `json:"reviewers,omitempty"`
+}
+
+// resourceRefAPIItem references a work item or identity by ID
+type resourceRefAPIItem struct {
+	ID string `json:"id"`
+}
+
+// CreatePullRequest opens a pull request in a repository, linking the
+// given work items and requesting the given reviewers
+func (c *Client) CreatePullRequest(repo models.Repository, pr models.NewPullRequest) (models.PullRequest, error) {
+	reqBody := createPullRequestRequest{
+		SourceRefName: "refs/heads/" + pr.SourceBranch,
+		TargetRefName: "refs/heads/" + pr.TargetBranch,
+		Title:         pr.Title,
+		Description:   pr.Description,
+	}
+	for _, id := range pr.WorkItemIDs {
+		reqBody.WorkItemRefs = append(reqBody.WorkItemRefs, resourceRefAPIItem{ID: strconv.Itoa(id)})
+	}
+	for _, id := range pr.ReviewerIDs {
+		reqBody.Reviewers = append(reqBody.Reviewers, resourceRefAPIItem{ID: id})
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("marshaling pull request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullrequests", url.PathEscape(repo.ID))
+	resp, err := c.postWithBase(c.projectAPIURL(repo.ProjectID), endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("creating pull request: %w", err)
+	}
+
+	var item pullRequestAPIItem
+	if err := decode(resp, &item); err != nil {
+		return models.PullRequest{}, err
+	}
+	return c.convertPullRequest(item), nil
+}
+
+// SetPullRequestAutoComplete makes a pull request complete by itself once
+// its policies pass, deleting the source branch. userID is the identity the
+// auto-complete is set by.
+func (c *Client) SetPullRequestAutoComplete(repo models.Repository, id int, userID string) error {
+	reqBody := map[string]interface{}{
+		"autoCompleteSetBy": resourceRefAPIItem{ID: userID},
+		"completionOptions": map[string]interface{}{
+			"deleteSourceBranch": true,
+		},
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("marshaling auto-complete: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullrequests/%d", url.PathEscape(repo.ID), id)
+	resp, err := c.patchJSONWithBase(c.projectAPIURL(repo.ProjectID), endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("setting auto-complete on pull request !%d: %w", id, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
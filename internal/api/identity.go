@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// connectionDataResponse represents the response from the connection data API
+type connectionDataResponse struct {
+	AuthenticatedUser struct {
+		ID                  string `json:"id"`
+		ProviderDisplayName string `json:"providerDisplayName"`
+		Properties          struct {
+			Account struct {
+				Value string `json:"$value"`
+			} `json:"Account"`
+		} `json:"properties"`
+	} `json:"authenticatedUser"`
+}
+
+// GetCurrentUser fetches the user the PAT belongs to
+func (c *Client) GetCurrentUser() (models.TeamMember, error) {
+	// Azure DevOps API: GET https://dev.azure.com/{org}/_apis/connectionData
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/connectionData", c.organization)
+
+	resp, err := c.doRequest("GET", url, nil)
+	if err != nil {
+		return models.TeamMember{}, err
+	}
+
+	var apiResp connectionDataResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return models.TeamMember{}, err
+	}
+
+	user := apiResp.AuthenticatedUser
+	return models.TeamMember{
+		ID:          user.ID,
+		DisplayName: user.ProviderDisplayName,
+		UniqueName:  user.Properties.Account.Value,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// changedDateFormat is how change dates are written in WIQL; Azure DevOps
+// keeps them to the millisecond
+const changedDateFormat = "2006-01-02T15:04:05.000Z"
+
+// GetMyChangedWorkItems returns the work items assigned to me, created by me
+// or mentioning me that someone else changed after since, oldest change
+// first. The next page starts after the last item: its change date and,
+// for items changed at the same time, its ID (afterID, 0 on the first
+// page). The items include who created and last changed them.
+func (c *Client) GetMyChangedWorkItems(since time.Time, afterID int, top int) ([]models.WorkItem, error) {
+	date := since.UTC().Format(changedDateFormat)
+	after := fmt.Sprintf("[System.ChangedDate] > '%s'", date)
+	if afterID > 0 {
+		after = fmt.Sprintf("([System.ChangedDate] > '%s' OR ([System.ChangedDate] = '%s' AND [System.Id] > %d))", date, date, afterID)
+	}
+
+	// @RecentMentions covers the discussion mentions of the last 30 days
+	query := fmt.Sprintf(`SELECT [System.Id]
+FROM WorkItems
+WHERE [System.TeamProject] = @project
+  AND ([System.AssignedTo] = @Me OR [System.CreatedBy] = @Me OR [System.Id] IN (@RecentMentions))
+  AND [System.ChangedBy] <> @Me
+  AND %s
+ORDER BY [System.ChangedDate] ASC, [System.Id] ASC`, after)
+
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	// Without timePrecision dates are compared by day
+	resp, err := c.post(fmt.Sprintf("/wit/wiql?timePrecision=true&$top=%d", top), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var wiqlResp wiqlResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(wiqlResp.WorkItems))
+	for _, wi := range wiqlResp.WorkItems {
+		ids = append(ids, strconv.Itoa(wi.ID))
+	}
+
+	// The batch API returns items in the requested order
+	return c.GetWorkItems(ids, []string{models.FieldCreatedBy, models.FieldChangedBy})
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// buildDefinitionDetailsAPIItem represents a single pipeline from the API
+type buildDefinitionDetailsAPIItem struct {
+	buildDefinitionAPIItem
+	Repository struct {
+		ID            string `json:"id"`
+		Type          string `json:"type"`
+		DefaultBranch string `json:"defaultBranch"`
+	} `json:"repository"`
+	Process struct {
+		YAMLFilename string `json:"yamlFilename"`
+	} `json:"process"`
+	Variables map[string]struct {
+		Value         string `json:"value"`
+		IsSecret      bool   `json:"isSecret"`
+		AllowOverride bool   `json:"allowOverride"`
+	} `json:"variables"`
+}
+
+// GetBuildDefinition fetches a pipeline of the configured project, with its
+// repository and the variables that can be set when queuing
+func (c *Client) GetBuildDefinition(id int) (models.BuildDefinition, error) {
+	resp, err := c.get(fmt.Sprintf("/build/definitions/%d", id))
+	if err != nil {
+		return models.BuildDefinition{}, err
+	}
+
+	var item buildDefinitionDetailsAPIItem
+	if err := decode(resp, &item); err != nil {
+		return models.BuildDefinition{}, err
+	}
+
+	def := models.BuildDefinition{
+		ID:             item.ID,
+		Name:           item.Name,
+		Path:           item.Path,
+		DefaultBranch:  models.BranchName(item.Repository.DefaultBranch),
+		RepositoryID:   item.Repository.ID,
+		RepositoryType: item.Repository.Type,
+		YAMLPath:       item.Process.YAMLFilename,
+	}
+	for name, v := range item.Variables {
+		if v.AllowOverride {
+			def.Variables = append(def.Variables, models.PipelineVariable{Name: name, Value: v.Value, Secret: v.IsSecret})
+		}
+	}
+	sort.Slice(def.Variables, func(i, j int) bool { return def.Variables[i].Name < def.Variables[j].Name })
+	return def, nil
+}
+
+// GetPipelineParameters reads the runtime parameters from a pipeline's YAML
+// file on a branch. Pipelines outside Azure Repos, and classic pipelines,
+// have none.
+func (c *Client) GetPipelineParameters(def models.BuildDefinition, branch string) ([]models.PipelineParameter, error) {
+	if def.YAMLPath == "" || def.RepositoryType != "TfsGit" {
+		return nil, nil
+	}
+
+	query := url.Values{}
+	query.Set("path", def.YAMLPath)
+	query.Set("includeContent", "true")
+	query.Set("versionDescriptor.version", branch)
+	query.Set("versionDescriptor.versionType", "branch")
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/items?%s", url.PathEscape(def.RepositoryID), query.Encode())
+	resp, err := c.get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s on %s: %w", def.YAMLPath, branch, err)
+	}
+
+	var item struct {
+		Content string `json:"content"`
+	}
+	if err := decode(resp, &item); err != nil {
+		return nil, err
+	}
+	return models.ParsePipelineParameters([]byte(item.Content))
+}
+
+// QueuePipelineRun queues a run of a pipeline and returns it as a build
+func (c *Client) QueuePipelineRun(run models.NewPipelineRun) (models.Build, error) {
+	reqBody := map[string]interface{}{
+		"resources": map[string]interface{}{
+			"repositories": map[string]interface{}{
+				"self": map[string]string{"refName": "refs/heads/" + run.Branch},
+			},
+		},
+	}
+	if len(run.Parameters) > 0 {
+		reqBody["templateParameters"] = run.Parameters
+	}
+	if len(run.Variables) > 0 {
+		variables := make(map[string]interface{}, len(run.Variables))
+		for name, value := range run.Variables {
+			variables[name] = map[string]string{"value": value}
+		}
+		reqBody["variables"] = variables
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return models.Build{}, fmt.Errorf("marshaling run: %w", err)
+	}
+
+	resp, err := c.post(fmt.Sprintf("/pipelines/%d/runs", run.DefinitionID), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return models.Build{}, fmt.Errorf("queuing run: %w", err)
+	}
+
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := decode(resp, &created); err != nil {
+		return models.Build{}, err
+	}
+
+	// Pipeline runs are builds with the same ID
+	return c.GetBuild("", created.ID)
+}
+
+// CancelBuild asks a queued or running build of the configured project to
+// stop
+func (c *Client) CancelBuild(id int) error {
+	bodyBytes, err := json.Marshal(map[string]string{"status": "cancelling"})
+	if err != nil {
+		return fmt.Errorf("marshaling cancel: %w", err)
+	}
+
+	resp, err := c.patchJSONWithBase(c.baseURL, fmt.Sprintf("/build/builds/%d", id), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("cancelling run %d: %w", id, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// RetryBuildStage reruns the failed and canceled jobs of a stage of a
+// completed build, by the stage's identifier
+func (c *Client) RetryBuildStage(buildID int, stage string) error {
+	reqBody := map[string]interface{}{
+		"state":             "retry",
+		"forceRetryAllJobs": false,
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("marshaling retry: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/build/builds/%d/stages/%s", buildID, url.PathEscape(stage))
+	resp, err := c.patchJSONWithBase(c.baseURL, endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("retrying stage %s: %w", stage, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// pullRequestsResponse represents the response from the pull requests API
+type pullRequestsResponse struct {
+	Count int                  `json:"count"`
+	Value []pullRequestAPIItem `json:"value"`
+}
+
+// GetActivePullRequests fetches the active pull requests of all
+// repositories of the configured project, newest first
+func (c *Client) GetActivePullRequests() ([]models.PullRequest, error) {
+	resp, err := c.get("/git/pullrequests?searchCriteria.status=active&$top=500")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp pullRequestsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	prs := make([]models.PullRequest, 0, len(apiResp.Value))
+	for _, item := range apiResp.Value {
+		prs = append(prs, c.convertPullRequest(item))
+	}
+	sort.SliceStable(prs, func(i, j int) bool { return prs[i].Created.After(prs[j].Created) })
+	return prs, nil
+}
+
+// policyEvaluationsResponse represents the response from the policy
+// evaluations API
+type policyEvaluationsResponse struct {
+	Value []struct {
+		Status        string `json:"status"` // queued, running, approved, rejected, notApplicable or broken
+		Configuration struct {
+			IsEnabled  bool `json:"isEnabled"`
+			IsBlocking bool `json:"isBlocking"`
+			Type       struct {
+				DisplayName string `json:"displayName"`
+			} `json:"type"`
+			Settings struct {
+				DisplayName string `json:"displayName"`
+			} `json:"settings"`
+		} `json:"configuration"`
+	} `json:"value"`
+}
+
+// pullRequestStatusesResponse represents the response from the pull request
+// statuses API
+type pullRequestStatusesResponse struct {
+	Value []struct {
+		ID          int    `json:"id"`
+		State       string `json:"state"` // pending, succeeded, failed, error or notApplicable
+		Description string `json:"description"`
+		Context     struct {
+			Name  string `json:"name"`
+			Genre string `json:"genre"`
+		} `json:"context"`
+	} `json:"value"`
+}
+
+// GetPullRequestChecks fetches the branch policies evaluated for a pull
+// request, and the statuses posted to it
+func (c *Client) GetPullRequestChecks(pr models.PullRequest) ([]models.PullRequestCheck, error) {
+	base := c.projectAPIURL(pr.ProjectID)
+
+	artifact := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", pr.ProjectID, pr.ID)
+	resp, err := c.getWithBase(base, "/policy/evaluations?api-version=7.1-preview.1&artifactId="+url.QueryEscape(artifact))
+	if err != nil {
+		return nil, fmt.Errorf("fetching policies: %w", err)
+	}
+	var policies policyEvaluationsResponse
+	if err := decode(resp, &policies); err != nil {
+		return nil, err
+	}
+
+	var checks []models.PullRequestCheck
+	for _, p := range policies.Value {
+		if !p.Configuration.IsEnabled {
+			continue
+		}
+		name := p.Configuration.Settings.DisplayName
+		if name == "" {
+			name = p.Configuration.Type.DisplayName
+		}
+		status := models.CheckPending
+		switch p.Status {
+		case "approved":
+			status = models.CheckSucceeded
+		case "rejected", "broken":
+			status = models.CheckFailed
+		case "notApplicable":
+			status = models.CheckNotApplicable
+		}
+		checks = append(checks, models.PullRequestCheck{Name: name, Status: status, Blocking: p.Configuration.IsBlocking})
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/statuses", url.PathEscape(pr.RepositoryID), pr.ID)
+	resp, err = c.getWithBase(base, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("fetching statuses: %w", err)
+	}
+	var statuses pullRequestStatusesResponse
+	if err := decode(resp, &statuses); err != nil {
+		return nil, err
+	}
+
+	// Statuses are posted again as they change; the latest one counts
+	sort.SliceStable(statuses.Value, func(i, j int) bool { return statuses.Value[i].ID > statuses.Value[j].ID })
+	seen := make(map[string]bool)
+	for _, s := range statuses.Value {
+		key := s.Context.Genre + "/" + s.Context.Name
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		name := s.Description
+		if name == "" {
+			name = key
+		}
+		status := s.State
+		if status == "error" {
+			status = models.CheckFailed
+		}
+		checks = append(checks, models.PullRequestCheck{Name: name, Status: status})
+	}
+	return checks, nil
+}
+
+// pullRequestIterationsResponse represents the response from the pull
+// request iterations API
+type pullRequestIterationsResponse struct {
+	Value []struct {
+		ID              int `json:"id"`
+		SourceRefCommit struct {
+			CommitID string `json:"commitId"`
+		} `json:"sourceRefCommit"`
+		CommonRefCommit struct {
+			CommitID string `json:"commitId"`
+		} `json:"commonRefCommit"`
+	} `json:"value"`
+}
+
+// pullRequestChangesResponse represents the response from the iteration
+// changes API
+type pullRequestChangesResponse struct {
+	ChangeEntries []struct {
+		ChangeTrackingID int    `json:"changeTrackingId"`
+		ChangeType       string `json:"changeType"`
+		OriginalPath     string `json:"originalPath"`
+		Item             struct {
+			Path          string `json:"path"`
+			GitObjectType string `json:"gitObjectType"`
+			IsFolder      bool   `json:"isFolder"`
+		} `json:"item"`
+	} `json:"changeEntries"`
+}
+
+// GetPullRequestChanges fetches the latest iteration (push) of a pull
+// request and the files it changes, compared to the target branch
+func (c *Client) GetPullRequestChanges(pr models.PullRequest) (models.PullRequestIteration, []models.PullRequestChange, error) {
+	base := c.projectAPIURL(pr.ProjectID)
+	prURL := fmt.Sprintf("/git/repositories/%s/pullRequests/%d", url.PathEscape(pr.RepositoryID), pr.ID)
+
+	var iteration models.PullRequestIteration
+	resp, err := c.getWithBase(base, prURL+"/iterations")
+	if err != nil {
+		return iteration, nil, fmt.Errorf("fetching iterations: %w", err)
+	}
+	var iterations pullRequestIterationsResponse
+	if err := decode(resp, &iterations); err != nil {
+		return iteration, nil, err
+	}
+	if len(iterations.Value) == 0 {
+		return iteration, nil, nil
+	}
+	latest := iterations.Value[len(iterations.Value)-1]
+	iteration = models.PullRequestIteration{
+		ID:           latest.ID,
+		SourceCommit: latest.SourceRefCommit.CommitID,
+		BaseCommit:   latest.CommonRefCommit.CommitID,
+	}
+
+	resp, err = c.getWithBase(base, fmt.Sprintf("%s/iterations/%d/changes?$top=2000&$compareTo=0", prURL, iteration.ID))
+	if err != nil {
+		return iteration, nil, fmt.Errorf("fetching changes: %w", err)
+	}
+	var changes pullRequestChangesResponse
+	if err := decode(resp, &changes); err != nil {
+		return iteration, nil, err
+	}
+
+	result := make([]models.PullRequestChange, 0, len(changes.ChangeEntries))
+	for _, e := range changes.ChangeEntries {
+		// Folders are listed as well, without content changes
+		if e.Item.Path == "" || e.Item.IsFolder || e.Item.GitObjectType == "tree" {
+			continue
+		}
+		result = append(result, models.PullRequestChange{
+			Path:             e.Item.Path,
+			OriginalPath:     e.OriginalPath,
+			ChangeType:       e.ChangeType,
+			ChangeTrackingID: e.ChangeTrackingID,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return iteration, result, nil
+}
+
+// VotePullRequest sets the vote of a reviewer on a pull request, adding
+// them as a reviewer when needed
+func (c *Client) VotePullRequest(pr models.PullRequest, reviewerID string, vote int) error {
+	bodyBytes, err := json.Marshal(map[string]int{"vote": vote})
+	if err != nil {
+		return fmt.Errorf("marshaling vote: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/reviewers/%s",
+		url.PathEscape(pr.RepositoryID), pr.ID, url.PathEscape(reviewerID))
+	resp, err := c.putWithBase(c.projectAPIURL(pr.ProjectID), endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("voting on pull request !%d: %w", pr.ID, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// threadPosition is a line in a file, as comment threads are placed
+type threadPosition struct {
+	Line   int `json:"line"`
+	Offset int `json:"offset"`
+}
+
+// pullRequestThreadsResponse represents the response from the pull request
+// threads API
+type pullRequestThreadsResponse struct {
+	Value []struct {
+		ID            int    `json:"id"`
+		Status        string `json:"status"`
+		IsDeleted     bool   `json:"isDeleted"`
+		ThreadContext *struct {
+			FilePath       string          `json:"filePath"`
+			LeftFileStart  *threadPosition `json:"leftFileStart"`
+			RightFileStart *threadPosition `json:"rightFileStart"`
+		} `json:"threadContext"`
+		Comments []struct {
+			ID              int                `json:"id"`
+			ParentCommentID int                `json:"parentCommentId"`
+			Author          identityRefAPIItem `json:"author"`
+			Content         string             `json:"content"`
+			PublishedDate   time.Time          `json:"publishedDate"`
+			CommentType     string             `json:"commentType"` // text, codeChange or system
+			IsDeleted       bool               `json:"isDeleted"`
+		} `json:"comments"`
+	} `json:"value"`
+}
+
+// GetPullRequestThreads fetches the comment threads of a pull request.
+// Threads of system messages, such as votes and pushes, are left out.
+func (c *Client) GetPullRequestThreads(pr models.PullRequest) ([]models.CommentThread, error) {
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads", url.PathEscape(pr.RepositoryID), pr.ID)
+	resp, err := c.getWithBase(c.projectAPIURL(pr.ProjectID), endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("fetching comment threads: %w", err)
+	}
+
+	var apiResp pullRequestThreadsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	var threads []models.CommentThread
+	for _, item := range apiResp.Value {
+		if item.IsDeleted {
+			continue
+		}
+		thread := models.CommentThread{ID: item.ID, Status: item.Status}
+		if ctx := item.ThreadContext; ctx != nil {
+			thread.Path = ctx.FilePath
+			switch {
+			case ctx.RightFileStart != nil:
+				thread.Line = ctx.RightFileStart.Line
+			case ctx.LeftFileStart != nil:
+				thread.Line = ctx.LeftFileStart.Line
+				thread.LeftSide = true
+			}
+		}
+		for _, comment := range item.Comments {
+			if comment.IsDeleted || comment.CommentType == "system" {
+				continue
+			}
+			thread.Comments = append(thread.Comments, models.ThreadComment{
+				ID:        comment.ID,
+				ParentID:  comment.ParentCommentID,
+				Author:    comment.Author.DisplayName,
+				Content:   comment.Content,
+				Published: comment.PublishedDate,
+			})
+		}
+		if len(thread.Comments) > 0 {
+			threads = append(threads, thread)
+		}
+	}
+	return threads, nil
+}
+
+// AddPullRequestThread starts a comment thread on a pull request, on a line
+// of a changed file when the thread has a path
+func (c *Client) AddPullRequestThread(pr models.PullRequest, thread models.NewCommentThread) error {
+	reqBody := map[string]interface{}{
+		"comments": []map[string]interface{}{
+			{"parentCommentId": 0, "content": thread.Text, "commentType": 1},
+		},
+		"status": 1, // Active
+	}
+	if thread.Path != "" {
+		position := threadPosition{Line: thread.Line, Offset: 1}
+		context := map[string]interface{}{"filePath": thread.Path}
+		if thread.LeftSide {
+			context["leftFileStart"] = position
+			context["leftFileEnd"] = position
+		} else {
+			context["rightFileStart"] = position
+			context["rightFileEnd"] = position
+		}
+		reqBody["threadContext"] = context
+
+		// Ties the thread to the file as of the latest push, so it moves
+		// along with later pushes
+		if thread.Iteration != 0 {
+			reqBody["pullRequestThreadContext"] = map[string]interface{}{
+				"changeTrackingId": thread.ChangeTrackingID,
+				"iterationContext": map[string]int{
+					"firstComparingIteration":  1,
+					"secondComparingIteration": thread.Iteration,
+				},
+			}
+		}
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("marshaling comment: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads", url.PathEscape(pr.RepositoryID), pr.ID)
+	resp, err := c.postWithBase(c.projectAPIURL(pr.ProjectID), endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("commenting on pull request !%d: %w", pr.ID, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// ReplyToThread adds a comment to a thread of a pull request, following
+// the comment with parentID
+func (c *Client) ReplyToThread(pr models.PullRequest, threadID, parentID int, text string) error {
+	reqBody := map[string]interface{}{
+		"parentCommentId": parentID,
+		"content":         text,
+		"commentType":     1,
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("marshaling reply: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads/%d/comments",
+		url.PathEscape(pr.RepositoryID), pr.ID, threadID)
+	resp, err := c.postWithBase(c.projectAPIURL(pr.ProjectID), endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("replying to thread %d: %w", threadID, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// SetThreadStatus changes the status of a comment thread, e.g. to resolve
+// it or make it active again
+func (c *Client) SetThreadStatus(pr models.PullRequest, threadID int, status string) error {
+	bodyBytes, err := json.Marshal(map[string]string{"status": status})
+	if err != nil {
+		return fmt.Errorf("marshaling thread status: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads/%d",
+		url.PathEscape(pr.RepositoryID), pr.ID, threadID)
+	resp, err := c.patchJSONWithBase(c.projectAPIURL(pr.ProjectID), endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("updating thread %d: %w", threadID, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// GetFileAtCommit fetches the content of a file of a repository as of a
+// commit. binary is set instead for files that aren't text.
+func (c *Client) GetFileAtCommit(pr models.PullRequest, path, commit string) (content string, binary bool, err error) {
+	query := url.Values{}
+	query.Set("path", path)
+	query.Set("includeContent", "true")
+	query.Set("versionDescriptor.version", commit)
+	query.Set("versionDescriptor.versionType", "commit")
+
+	endpoint := fmt.Sprintf("/git/repositories/%s/items?%s", url.PathEscape(pr.RepositoryID), query.Encode())
+	resp, err := c.getWithBase(c.projectAPIURL(pr.ProjectID), endpoint)
+	if err != nil {
+		return "", false, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var item struct {
+		Content         string `json:"content"`
+		ContentMetadata struct {
+			IsBinary bool `json:"isBinary"`
+		} `json:"contentMetadata"`
+	}
+	if err := decode(resp, &item); err != nil {
+		return "", false, err
+	}
+	if item.ContentMetadata.IsBinary {
+		return "", true, nil
+	}
+	return item.Content, false, nil
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
+)
+
+// workItemAPIURL returns the API URL of a work item, as used in relations
+func (c *Client) workItemAPIURL(id int) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/_apis/wit/workItems/%d", c.organization, id)
+}
+
+// getWorkItemRelations fetches the current revision and relations of a work item
+func (c *Client) getWorkItemRelations(id int) (int, []relationAPIItem, error) {
+	resp, err := c.get(fmt.Sprintf("/wit/workitems/%d?$expand=relations", id))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var item workItemAPIItem
+	if err := decode(resp, &item); err != nil {
+		return 0, nil, err
+	}
+
+	return item.Rev, item.Relations, nil
+}
+
+// patchWorkItem applies a JSON patch document to a work item
+func (c *Client) patchWorkItem(id int, patchDoc []map[string]interface{}) error {
+	bodyBytes, err := json.Marshal(patchDoc)
+	if err != nil {
+		return fmt.Errorf("marshaling patch document: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/wit/workitems/%d", id)
+	resp, err := c.patch(endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// relationOp returns a patch operation adding a work item link
+func (c *Client) relationOp(rel string, targetID int) map[string]interface{} {
+	return map[string]interface{}{
+		"op":   "add",
+		"path": "/relations/-",
+		"value": map[string]interface{}{
+			"rel": rel,
+			"url": c.workItemAPIURL(targetID),
+		},
+	}
+}
+
+// testRevOp returns a patch operation that fails if the item changed since rev,
+// so relation indexes can't point at the wrong link
+func testRevOp(rev int) map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "test",
+		"path":  "/rev",
+		"value": rev,
+	}
+}
+
+// AddArtifactLink links a work item to an artifact such as a branch, with
+// name as the link type shown in Azure DevOps, e.g. "Branch". Linking an
+// artifact that is already linked does nothing.
+func (c *Client) AddArtifactLink(id int, artifactURL, name string) error {
+	_, relations, err := c.getWorkItemRelations(id)
+	if err != nil {
+		return err
+	}
+	for _, r := range relations {
+		if r.Rel == models.RelArtifactLink && strings.EqualFold(r.URL, artifactURL) {
+			return nil
+		}
+	}
+
+	patchDoc := []map[string]interface{}{{
+		"op":   "add",
+		"path": "/relations/-",
+		"value": map[string]interface{}{
+			"rel": models.RelArtifactLink,
+			"url": artifactURL,
+			"attributes": map[string]interface{}{
+				"name": name,
+			},
+		},
+	}}
+	if err := c.patchWorkItem(id, patchDoc); err != nil {
+		return fmt.Errorf("linking %s to #%d: %w", strings.ToLower(name), id, err)
+	}
+	return nil
+}
+
+// LinkBranch links a work item to a branch of a repository, so it shows up
+// in the item's Development section
+func (c *Client) LinkBranch(id int, repo models.Repository, branch string) error {
+	return c.AddArtifactLink(id, models.BranchArtifactURL(repo.ProjectID, repo.ID, branch), "Branch")
+}
+
+// AddWorkItemLink links a work item to another work item.
+// rel is a link type reference name, e.g. models.RelRelated.
+func (c *Client) AddWorkItemLink(id int, rel string, targetID int) error {
+	if id == targetID {
+		return fmt.Errorf("cannot link #%d to itself", id)
+	}
+	if err := c.patchWorkItem(id, []map[string]interface{}{c.relationOp(rel, targetID)}); err != nil {
+		return fmt.Errorf("linking #%d to #%d: %w", id, targetID, err)
+	}
+	return nil
+}
+
+// RemoveWorkItemLink removes the relation with the given type and URL
+func (c *Client) RemoveWorkItemLink(id int, rel, linkURL string) error {
+	rev, relations, err := c.getWorkItemRelations(id)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range relations {
+		if r.Rel == rel && strings.EqualFold(r.URL, linkURL) {
+			patchDoc := []map[string]interface{}{
+				testRevOp(rev),
+				{"op": "remove", "path": fmt.Sprintf("/relations/%d", i)},
+			}
+			if err := c.patchWorkItem(id, patchDoc); err != nil {
+				return fmt.Errorf("removing link from #%d: %w", id, err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("link not found on #%d", id)
+}
+
+// SetWorkItemParent sets the parent of a work item, replacing the current
+// parent. A parentID of 0 removes the parent.
+func (c *Client) SetWorkItemParent(id, parentID int) error {
+	if id == parentID {
+		return fmt.Errorf("cannot make #%d its own parent", id)
+	}
+
+	rev, relations, err := c.getWorkItemRelations(id)
+	if err != nil {
+		return err
+	}
+
+	patchDoc := []map[string]interface{}{testRevOp(rev)}
+
+	// Remove from the end so earlier indexes stay valid
+	for i := len(relations) - 1; i >= 0; i-- {
+		if relations[i].Rel == models.RelHierarchyReverse {
+			patchDoc = append(patchDoc, map[string]interface{}{
+				"op":   "remove",
+				"path": fmt.Sprintf("/relations/%d", i),
+			})
+		}
+	}
+
+	if parentID > 0 {
+		patchDoc = append(patchDoc, c.relationOp(models.RelHierarchyReverse, parentID))
+	}
+
+	if len(patchDoc) == 1 {
+		return nil // No parent to remove
+	}
+
+	if err := c.patchWorkItem(id, patchDoc); err != nil {
+		return fmt.Errorf("setting parent of #%d: %w", id, err)
+	}
+	return nil
+}
+
+// SearchWorkItems finds work items in the project by ID or title,
+// most recently changed first
+func (c *Client) SearchWorkItems(text string, top int) ([]models.WorkItem, error) {
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "#"))
+	if text == "" {
+		return []models.WorkItem{}, nil
+	}
+
+	condition := fmt.Sprintf("[System.Title] CONTAINS '%s'", escapeWIQL(text))
+	if id, err := strconv.Atoi(text); err == nil {
+		condition = fmt.Sprintf("([System.Id] = %d OR %s)", id, condition)
+	}
+
+	query := fmt.Sprintf(`SELECT [System.Id]
+FROM WorkItems
+WHERE [System.TeamProject] = @project
+  AND %s
+ORDER BY [System.ChangedDate] DESC`, condition)
+
+	bodyBytes, err := json.Marshal(wiqlRequest{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling WIQL request: %w", err)
+	}
+
+	resp, err := c.post(fmt.Sprintf("/wit/wiql?$top=%d", top), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var wiqlResp wiqlResponse
+	if err := decode(resp, &wiqlResp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(wiqlResp.WorkItems))
+	for _, wi := range wiqlResp.WorkItems {
+		ids = append(ids, fmt.Sprintf("%d", wi.ID))
+	}
+
+	// The batch API returns items in the requested order
+	return c.GetWorkItems(ids, nil)
+}
+
+// GetWorkItemsWithRelations fetches work items by ID with all fields and relations
+func (c *Client) GetWorkItemsWithRelations(ids []int) ([]models.WorkItem, error) {
+	// API has a limit of 200 items per request
+	const batchSize = 200
+	var allItems []models.WorkItem
+
+	for i := 0; i < len(ids); i += batchSize {
+		end := i + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch := make([]string, 0, end-i)
+		for _, id := range ids[i:end] {
+			batch = append(batch, strconv.Itoa(id))
+		}
+
+		endpoint := fmt.Sprintf("/wit/workitems?ids=%s&$expand=relations&errorPolicy=omit", strings.Join(batch, ","))
+		resp, err := c.get(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		var apiResp workItemsResponse
+		if err := decode(resp, &apiResp); err != nil {
+			return nil, err
+		}
+
+		for _, item := range apiResp.Value {
+			if item.ID == 0 {
+				continue // Omitted (deleted or no access)
+			}
+			wi := c.convertWorkItem(item)
+			wi.DetailsLoaded = true
+			allItems = append(allItems, wi)
+		}
+	}
+
+	return allItems, nil
+}
+
+// CollectLinkedItems fetches the given items and, up to depth links away,
+// the items linked to them as parent, child, predecessor or successor.
+// Titles of links leading outside the collected items are filled in.
+func (c *Client) CollectLinkedItems(rootIDs []int, depth int) ([]models.WorkItem, error) {
+	// Keep graphs of large hierarchies readable
+	const maxItems = 500
+
+	var items []models.WorkItem
+	seen := make(map[int]bool)
+	next := make([]int, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		if !seen[id] {
+			seen[id] = true
+			next = append(next, id)
+		}
+	}
+
+	for level := 0; len(next) > 0; level++ {
+		fetched, err := c.GetWorkItemsWithRelations(next)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, fetched...)
+
+		next = nil
+		if level >= depth {
+			break
+		}
+		for _, item := range fetched {
+			for _, rel := range item.Relations {
+				switch rel.Rel {
+				case models.RelHierarchyForward, models.RelHierarchyReverse,
+					models.RelDependencyForward, models.RelDependencyReverse:
+				default:
+					continue
+				}
+				if rel.TargetID > 0 && !seen[rel.TargetID] && len(seen) < maxItems {
+					seen[rel.TargetID] = true
+					next = append(next, rel.TargetID)
+				}
+			}
+		}
+	}
+
+	c.populateRelationTargets(items)
+	return items, nil
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"sort"
+)
+
+// tagsResponse represents the API response for work item tags
+type tagsResponse struct {
+	Count int          `json:"count"`
+	Value []tagAPIItem `json:"value"`
+}
+
+type tagAPIItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetTags fetches all work item tags used in the project
+func (c *Client) GetTags() ([]string, error) {
+	// The tags API is only available as a preview version
+	resp, err := c.get("/wit/tags?api-version=7.1-preview.1")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp tagsResponse
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, apiResp.Count)
+	for _, item := range apiResp.Value {
+		tags = append(tags, item.Name)
+	}
+	sort.Strings(tags)
+
+	return tags, nil
+}
@@ -49,3 +49,29 @@ func (c *Client) GetTeamMembers() ([]models.TeamMember, error) {
 
 	return members, nil
 }
+
+// GetMyTeamIDs fetches the IDs of the teams the user belongs to, in all
+// projects. Teams review pull requests under these IDs.
+func (c *Client) GetMyTeamIDs() ([]string, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/teams?$mine=true&$top=1000&api-version=7.1-preview.3", c.organization)
+
+	resp, err := c.doRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Value []struct {
+			ID string `json:"id"`
+		} `json:"value"`
+	}
+	if err := decode(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(apiResp.Value))
+	for _, team := range apiResp.Value {
+		ids = append(ids, team.ID)
+	}
+	return ids, nil
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,10 +35,21 @@ type workItemsResponse struct {
 
 // workItemAPIItem represents a work item from the API
 type workItemAPIItem struct {
-	ID     int              `json:"id"`
-	Rev    int              `json:"rev"`
-	Fields workItemFields   `json:"fields"`
-	URL    string           `json:"url"`
+	ID        int               `json:"id"`
+	Rev       int               `json:"rev"`
+	Fields    workItemFields    `json:"fields"`
+	Relations []relationAPIItem `json:"relations"`
+	URL       string            `json:"url"`
+}
+
+// relationAPIItem represents a work item link from the API
+type relationAPIItem struct {
+	Rel        string `json:"rel"`
+	URL        string `json:"url"`
+	Attributes struct {
+		Name    string `json:"name"`
+		Comment string `json:"comment"`
+	} `json:"attributes"`
 }
 
 type workItemFields struct {
@@ -53,8 +67,49 @@ type workItemFields struct {
 	Tags          string     `json:"System.Tags"`
 	Parent        int        `json:"System.Parent"`
 	Priority      int        `json:"Microsoft.VSTS.Common.Priority"`
+	StoryPoints   float64    `json:"Microsoft.VSTS.Scheduling.StoryPoints"`
+	RemainingWork float64    `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
 	CreatedDate   time.Time  `json:"System.CreatedDate"`
 	ChangedDate   time.Time  `json:"System.ChangedDate"`
+
+	// All returned fields, including custom ones, keyed by reference name
+	All map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes the typed fields and keeps the raw field map
+func (f *workItemFields) UnmarshalJSON(data []byte) error {
+	type plain workItemFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &f.All)
+}
+
+// coreFields are always requested when listing work items; the rest of the
+// fields are requested per visible column, and full items are loaded on demand
+var coreFields = []string{
+	models.FieldID,
+	models.FieldTitle,
+	models.FieldState,
+	models.FieldType,
+	models.FieldAssignedTo,
+	models.FieldParent,
+	models.FieldChangedDate,
+	models.FieldIterationPath,
+}
+
+// listFields merges the core fields with the extra fields requested by the caller
+func listFields(extra []string) []string {
+	seen := make(map[string]bool)
+	var fields []string
+	for _, f := range append(append([]string{}, coreFields...), extra...) {
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		fields = append(fields, f)
+	}
+	return fields
 }
 
 // escapeWIQL escapes a string value for use in WIQL queries
@@ -63,43 +118,151 @@ func escapeWIQL(s string) string {
 	return strings.ReplaceAll(s, "'", "''")
 }
 
-// QueryWorkItems queries work items using WIQL
-func (c *Client) QueryWorkItems(sprintPath, state, assigned, areaPath string) ([]models.WorkItem, error) {
-	// Build WIQL query
+// wiqlList formats values as a quoted, comma separated WIQL list
+func wiqlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("'%s'", escapeWIQL(v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// buildWorkItemQuery builds the WIQL query for the given conditions
+func buildWorkItemQuery(q models.WorkItemQuery) string {
 	query := `SELECT [System.Id], [System.Title], [System.State], [System.WorkItemType]
 FROM WorkItems
 WHERE [System.TeamProject] = @project`
 
 	// Add sprint filter
-	if sprintPath != "" && sprintPath != "all" {
+	if q.Sprint != "" && q.Sprint != models.AllValue {
 		query += fmt.Sprintf(`
-  AND [System.IterationPath] = '%s'`, escapeWIQL(sprintPath))
+  AND [System.IterationPath] = '%s'`, escapeWIQL(q.Sprint))
 	}
 
 	// Add state filter
-	if state != "" && state != "all" {
+	if len(q.States) > 0 {
 		query += fmt.Sprintf(`
-  AND [System.State] = '%s'`, escapeWIQL(state))
+  AND [System.State] IN (%s)`, wiqlList(q.States))
 	}
 
-	// Add assigned filter
-	if assigned == "me" {
-		query += `
-  AND [System.AssignedTo] = @me`
+	// Add type filter
+	if len(q.Types) > 0 {
+		query += fmt.Sprintf(`
+  AND [System.WorkItemType] IN (%s)`, wiqlList(q.Types))
+	}
+
+	// Add assigned filter (macros can't be used inside IN, so OR the parts)
+	if len(q.Assigned) > 0 {
+		var conds []string
+		var people []string
+		for _, a := range q.Assigned {
+			switch a {
+			case models.AssignedMe:
+				conds = append(conds, "[System.AssignedTo] = @me")
+			case models.AssignedNone:
+				conds = append(conds, "[System.AssignedTo] = ''")
+			default:
+				people = append(people, a)
+			}
+		}
+		if len(people) > 0 {
+			conds = append(conds, fmt.Sprintf("[System.AssignedTo] IN (%s)", wiqlList(people)))
+		}
+		query += fmt.Sprintf(`
+  AND (%s)`, strings.Join(conds, " OR "))
+	}
+
+	// Add priority filter (numeric field, values are not quoted)
+	if len(q.Priorities) > 0 {
+		var priorities []string
+		for _, p := range q.Priorities {
+			if _, err := strconv.Atoi(p); err == nil {
+				priorities = append(priorities, p)
+			}
+		}
+		if len(priorities) > 0 {
+			query += fmt.Sprintf(`
+  AND [Microsoft.VSTS.Common.Priority] IN (%s)`, strings.Join(priorities, ", "))
+		}
+	}
+
+	// Add tag filter (matches items having any of the tags)
+	if len(q.Tags) > 0 {
+		conds := make([]string, len(q.Tags))
+		for i, tag := range q.Tags {
+			conds[i] = fmt.Sprintf("[System.Tags] CONTAINS '%s'", escapeWIQL(tag))
+		}
+		query += fmt.Sprintf(`
+  AND (%s)`, strings.Join(conds, " OR "))
 	}
 
 	// Add area filter
-	if areaPath != "" && areaPath != "all" {
-		// Clean up the path
-		areaPath = strings.TrimPrefix(areaPath, "\\")
-		areaPath = strings.TrimSuffix(areaPath, "\\")
+	if len(q.Areas) > 0 {
+		conds := make([]string, len(q.Areas))
+		for i, areaPath := range q.Areas {
+			// Clean up the path
+			areaPath = strings.TrimPrefix(areaPath, "\\")
+			areaPath = strings.TrimSuffix(areaPath, "\\")
+			conds[i] = fmt.Sprintf("[System.AreaPath] UNDER '%s'", escapeWIQL(areaPath))
+		}
 		query += fmt.Sprintf(`
-  AND [System.AreaPath] UNDER '%s'`, escapeWIQL(areaPath))
+  AND (%s)`, strings.Join(conds, " OR "))
+	}
+
+	// Add date conditions
+	for _, cond := range q.Dates {
+		field := cond.FieldRef()
+		switch {
+		case cond.IsRange():
+			if !cond.From.IsZero() {
+				query += fmt.Sprintf(`
+  AND [%s] >= '%s'`, field, cond.From.Format("2006-01-02"))
+			}
+			if !cond.To.IsZero() {
+				// The range end is inclusive, so compare against the next day
+				query += fmt.Sprintf(`
+  AND [%s] < '%s'`, field, cond.To.AddDate(0, 0, 1).Format("2006-01-02"))
+			}
+		case cond.OlderThanDays > 0:
+			query += fmt.Sprintf(`
+  AND [%s] < @today - %d`, field, cond.OlderThanDays)
+		case cond.SinceDays > 0:
+			query += fmt.Sprintf(`
+  AND [%s] >= @today - %d`, field, cond.SinceDays)
+		}
 	}
 
 	query += `
 ORDER BY [System.ChangedDate] DESC`
 
+	return query
+}
+
+// QueryWorkItems queries work items using WIQL. Only the core fields and the
+// given extra fields are fetched for each item.
+func (c *Client) QueryWorkItems(q models.WorkItemQuery, fields []string) ([]models.WorkItem, error) {
+	ids, err := c.QueryWorkItemIDs(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return []models.WorkItem{}, nil
+	}
+
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, fmt.Sprintf("%d", id))
+	}
+
+	// Fetch the work items
+	return c.GetWorkItems(idStrings, fields)
+}
+
+// QueryWorkItemIDs returns the IDs of the work items matching the query
+func (c *Client) QueryWorkItemIDs(q models.WorkItemQuery) ([]int, error) {
+	query := buildWorkItemQuery(q)
+
 	// Execute WIQL query
 	reqBody := wiqlRequest{Query: query}
 	bodyBytes, err := json.Marshal(reqBody)
@@ -117,22 +280,16 @@ ORDER BY [System.ChangedDate] DESC`
 		return nil, err
 	}
 
-	if len(wiqlResp.WorkItems) == 0 {
-		return []models.WorkItem{}, nil
-	}
-
-	// Get the IDs
-	ids := make([]string, 0, len(wiqlResp.WorkItems))
+	ids := make([]int, 0, len(wiqlResp.WorkItems))
 	for _, wi := range wiqlResp.WorkItems {
-		ids = append(ids, fmt.Sprintf("%d", wi.ID))
+		ids = append(ids, wi.ID)
 	}
-
-	// Fetch the full work items
-	return c.GetWorkItems(ids)
+	return ids, nil
 }
 
-// GetWorkItems fetches multiple work items by ID
-func (c *Client) GetWorkItems(ids []string) ([]models.WorkItem, error) {
+// GetWorkItems fetches multiple work items by ID, requesting the core fields
+// plus the given extra fields
+func (c *Client) GetWorkItems(ids []string, fields []string) ([]models.WorkItem, error) {
 	if len(ids) == 0 {
 		return []models.WorkItem{}, nil
 	}
@@ -148,9 +305,7 @@ func (c *Client) GetWorkItems(ids []string) ([]models.WorkItem, error) {
 		}
 
 		batch := ids[i:end]
-		fields := "System.Id,System.Title,System.State,System.WorkItemType,System.AssignedTo,System.IterationPath,System.AreaPath,System.Description,System.Tags,System.Parent,Microsoft.VSTS.Common.Priority,System.CreatedDate,System.ChangedDate"
-
-		endpoint := fmt.Sprintf("/wit/workitems?ids=%s&fields=%s", strings.Join(batch, ","), fields)
+		endpoint := fmt.Sprintf("/wit/workitems?ids=%s&fields=%s", strings.Join(batch, ","), url.QueryEscape(strings.Join(listFields(fields), ",")))
 		resp, err := c.get(endpoint)
 		if err != nil {
 			return nil, err
@@ -221,11 +376,9 @@ func (c *Client) populateParentTitles(items []models.WorkItem) {
 	}
 }
 
-// GetWorkItem fetches a single work item by ID
+// GetWorkItem fetches a single work item by ID with all of its fields and relations
 func (c *Client) GetWorkItem(id int) (*models.WorkItem, error) {
-	fields := "System.Id,System.Title,System.State,System.WorkItemType,System.AssignedTo,System.IterationPath,System.AreaPath,System.Description,System.Tags,System.Parent,Microsoft.VSTS.Common.Priority,System.CreatedDate,System.ChangedDate"
-
-	endpoint := fmt.Sprintf("/wit/workitems/%d?fields=%s", id, fields)
+	endpoint := fmt.Sprintf("/wit/workitems/%d?$expand=relations", id)
 	resp, err := c.get(endpoint)
 	if err != nil {
 		return nil, err
@@ -236,21 +389,72 @@ func (c *Client) GetWorkItem(id int) (*models.WorkItem, error) {
 		return nil, err
 	}
 
-	wi := c.convertWorkItem(item)
+	items := []models.WorkItem{c.convertWorkItem(item)}
+	items[0].DetailsLoaded = true
+
+	// Fetch titles of linked work items (includes the parent)
+	c.populateRelationTargets(items)
 
-	// Fetch parent title if parent exists
-	if wi.ParentID > 0 {
-		parentEndpoint := fmt.Sprintf("/wit/workitems/%d?fields=System.Title", wi.ParentID)
-		parentResp, err := c.get(parentEndpoint)
-		if err == nil {
-			var parentItem workItemAPIItem
-			if decode(parentResp, &parentItem) == nil {
-				wi.ParentTitle = parentItem.Fields.Title
+	return &items[0], nil
+}
+
+// populateRelationTargets fetches title, state and type of linked work items
+func (c *Client) populateRelationTargets(items []models.WorkItem) {
+	seen := make(map[int]bool)
+	var ids []string
+	for _, wi := range items {
+		for _, rel := range wi.Relations {
+			if rel.TargetID > 0 && !seen[rel.TargetID] {
+				seen[rel.TargetID] = true
+				ids = append(ids, fmt.Sprintf("%d", rel.TargetID))
 			}
 		}
 	}
 
-	return &wi, nil
+	if len(ids) == 0 {
+		return
+	}
+
+	// API has a limit of 200 items per request
+	targets := make(map[int]workItemFields)
+	const batchSize = 200
+	for i := 0; i < len(ids); i += batchSize {
+		end := i + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		endpoint := fmt.Sprintf("/wit/workitems?ids=%s&fields=System.Id,System.Title,System.State,System.WorkItemType&errorPolicy=omit", strings.Join(ids[i:end], ","))
+		resp, err := c.get(endpoint)
+		if err != nil {
+			return // Silently fail - titles are optional
+		}
+
+		var apiResp workItemsResponse
+		if err := decode(resp, &apiResp); err != nil {
+			return
+		}
+
+		for _, item := range apiResp.Value {
+			targets[item.ID] = item.Fields
+		}
+	}
+
+	for i := range items {
+		wi := &items[i]
+		for j := range wi.Relations {
+			rel := &wi.Relations[j]
+			if fields, ok := targets[rel.TargetID]; ok {
+				rel.TargetTitle = fields.Title
+				rel.TargetState = models.WorkItemState(fields.State)
+				rel.TargetType = models.WorkItemType(fields.WorkItemType)
+			}
+		}
+
+		if fields, ok := targets[wi.ParentID]; ok {
+			wi.ParentTitle = fields.Title
+		}
+	}
 }
 
 // convertWorkItem converts an API work item to our model
@@ -266,6 +470,9 @@ func (c *Client) convertWorkItem(item workItemAPIItem) models.WorkItem {
 		Description:   stripHTML(item.Fields.Description),
 		ParentID:      item.Fields.Parent,
 		Priority:      item.Fields.Priority,
+		StoryPoints:   item.Fields.StoryPoints,
+		RemainingWork: item.Fields.RemainingWork,
+		Fields:        item.Fields.All,
 		CreatedDate:   item.Fields.CreatedDate,
 		ChangedDate:   item.Fields.ChangedDate,
 		URL:           item.URL,
@@ -276,6 +483,16 @@ func (c *Client) convertWorkItem(item workItemAPIItem) models.WorkItem {
 		wi.AssignedTo = item.Fields.AssignedTo.DisplayName
 	}
 
+	for _, rel := range item.Relations {
+		wi.Relations = append(wi.Relations, models.WorkItemRelation{
+			Rel:      rel.Rel,
+			URL:      rel.URL,
+			Name:     rel.Attributes.Name,
+			Comment:  rel.Attributes.Comment,
+			TargetID: models.WorkItemIDFromURL(rel.URL),
+		})
+	}
+
 	// Parse tags
 	if item.Fields.Tags != "" {
 		tags := strings.Split(item.Fields.Tags, ";")
@@ -290,6 +507,48 @@ func (c *Client) convertWorkItem(item workItemAPIItem) models.WorkItem {
 	return wi
 }
 
+// CreateWorkItem creates a work item of the given type with the given field
+// values, optionally as a child of parentID
+func (c *Client) CreateWorkItem(workItemType string, fields map[string]interface{}, parentID int) (*models.WorkItem, error) {
+	// Sort the fields so the patch document is deterministic
+	refs := make([]string, 0, len(fields))
+	for ref := range fields {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	patchDoc := make([]map[string]interface{}, 0, len(fields)+1)
+	for _, ref := range refs {
+		patchDoc = append(patchDoc, map[string]interface{}{
+			"op":    "add",
+			"path":  "/fields/" + ref,
+			"value": fields[ref],
+		})
+	}
+	if parentID > 0 {
+		patchDoc = append(patchDoc, c.relationOp(models.RelHierarchyReverse, parentID))
+	}
+
+	bodyBytes, err := json.Marshal(patchDoc)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling patch document: %w", err)
+	}
+
+	endpoint := "/wit/workitems/$" + url.PathEscape(workItemType)
+	resp, err := c.postPatch(endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("creating %s: %w", workItemType, err)
+	}
+
+	var item workItemAPIItem
+	if err := decode(resp, &item); err != nil {
+		return nil, err
+	}
+
+	wi := c.convertWorkItem(item)
+	return &wi, nil
+}
+
 // UpdateWorkItemState updates a work item's state
 func (c *Client) UpdateWorkItemState(id int, newState string) error {
 	// Azure DevOps uses JSON Patch format
@@ -343,6 +602,17 @@ func (c *Client) AssignWorkItem(id int, userEmail string) error {
 	return nil
 }
 
+// SetWorkItemIteration moves a work item to an iteration (sprint) path
+func (c *Client) SetWorkItemIteration(id int, iterationPath string) error {
+	return c.patchWorkItem(id, []map[string]interface{}{
+		{
+			"op":    "add",
+			"path":  "/fields/System.IterationPath",
+			"value": iterationPath,
+		},
+	})
+}
+
 // stripHTML removes HTML tags from a string
 func stripHTML(s string) string {
 	// Simple HTML tag removal
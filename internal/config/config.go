@@ -4,20 +4,74 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/samuelenocsson/devops-tui/internal/models"
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Organization string   `mapstructure:"organization"`
-	Project      string   `mapstructure:"project"`
-	Team         string   `mapstructure:"team"`
-	PAT          string   `mapstructure:"pat"`
-	Theme        string   `mapstructure:"theme"`
-	Defaults     Defaults `mapstructure:"defaults"`
+	Organization   string             `mapstructure:"organization"`
+	Project        string             `mapstructure:"project"`
+	Team           string             `mapstructure:"team"`
+	PAT            string             `mapstructure:"pat"`
+	Theme          string             `mapstructure:"theme"`
+	StaleDays      int                `mapstructure:"stale_days"`
+	Columns        []models.Column    `mapstructure:"columns"`
+	BranchPatterns []string           `mapstructure:"branch_patterns"`
+	BranchNaming   BranchNaming       `mapstructure:"branch_naming"`
+	WorkStates     WorkStates         `mapstructure:"work_states"`
+	WorktreePath   string             `mapstructure:"worktree_path"`
+	Defaults       Defaults           `mapstructure:"defaults"`
+	Notifications  Notifications      `mapstructure:"notifications"`
+	Profile        string             `mapstructure:"profile"`
+	Profiles       map[string]Profile `mapstructure:"profiles"`
 }
 
+// Profile holds settings that override the top-level config when the
+// profile is active, e.g. a different project with its own columns
+type Profile struct {
+	Organization   string          `mapstructure:"organization"`
+	Project        string          `mapstructure:"project"`
+	Team           string          `mapstructure:"team"`
+	PAT            string          `mapstructure:"pat"`
+	Columns        []models.Column `mapstructure:"columns"`
+	BranchPatterns []string        `mapstructure:"branch_patterns"`
+	BranchNaming   BranchNaming    `mapstructure:"branch_naming"`
+	WorkStates     WorkStates      `mapstructure:"work_states"`
+	WorktreePath   string          `mapstructure:"worktree_path"`
+}
+
+// WorkStates sets the states items move to when starting and finishing
+// work, by lowercased work item type or "default". Types without one use
+// their first In Progress or Resolved state.
+type WorkStates struct {
+	Start  map[string]string `mapstructure:"start"`
+	Finish map[string]string `mapstructure:"finish"`
+}
+
+// BranchNaming configures the branch names suggested for work items
+type BranchNaming struct {
+	Template  string            `mapstructure:"template"`   // Go template, see models.BranchNameData
+	Types     map[string]string `mapstructure:"types"`      // Templates by work item type
+	MaxLength int               `mapstructure:"max_length"` // 0 uses the default
+}
+
+// Notifications configures polling for changes to my work items
+type Notifications struct {
+	Interval time.Duration `mapstructure:"interval"` // 0 disables polling
+	Bell     bool          `mapstructure:"bell"`     // Ring the terminal bell
+	Desktop  bool          `mapstructure:"desktop"`  // Send an OSC 9 desktop notification
+}
+
+// MinNotificationInterval is the shortest interval changes are polled at
+const MinNotificationInterval = 30 * time.Second
+
+// DefaultProfile is the profile name used when no profile is selected
+const DefaultProfile = "default"
+
 // Defaults holds default filter settings
 type Defaults struct {
 	Sprint   string `mapstructure:"sprint"`
@@ -42,6 +96,7 @@ func Load() (*Config, error) {
 
 	// Set defaults
 	v.SetDefault("theme", "default")
+	v.SetDefault("stale_days", 14)
 	v.SetDefault("defaults.sprint", "current")
 	v.SetDefault("defaults.state", "all")
 	v.SetDefault("defaults.assigned", "me")
@@ -62,12 +117,25 @@ func Load() (*Config, error) {
 	v.BindEnv("organization", "AZURE_DEVOPS_ORG")
 	v.BindEnv("project", "AZURE_DEVOPS_PROJECT")
 	v.BindEnv("team", "AZURE_DEVOPS_TEAM")
+	v.BindEnv("profile", "AZURE_DEVOPS_PROFILE")
 
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	// Apply the selected profile on top of the top-level settings
+	if err := cfg.applyProfile(); err != nil {
+		return nil, err
+	}
+
+	if _, err := cfg.BranchMatcher(); err != nil {
+		return nil, err
+	}
+	if _, err := cfg.BranchNamer(); err != nil {
+		return nil, err
+	}
+
 	// Validate required fields
 	if cfg.Organization == "" {
 		return nil, fmt.Errorf("organization is required (set in config or AZURE_DEVOPS_ORG)")
@@ -85,6 +153,88 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// applyProfile overrides the top-level settings with the active profile
+func (c *Config) applyProfile() error {
+	if c.Profile == "" || c.Profile == DefaultProfile {
+		return nil
+	}
+	if err := ValidateProfileName(c.Profile); err != nil {
+		return err
+	}
+
+	// Viper lowercases map keys, so profile names are case-insensitive
+	profile, ok := c.Profiles[strings.ToLower(c.Profile)]
+	if !ok {
+		return fmt.Errorf("profile %q not found in config", c.Profile)
+	}
+
+	if profile.Organization != "" {
+		c.Organization = profile.Organization
+	}
+	if profile.Project != "" {
+		c.Project = profile.Project
+	}
+	if profile.Team != "" {
+		c.Team = profile.Team
+	}
+	if profile.PAT != "" {
+		c.PAT = profile.PAT
+	}
+	if len(profile.Columns) > 0 {
+		c.Columns = profile.Columns
+	}
+	if len(profile.BranchPatterns) > 0 {
+		c.BranchPatterns = profile.BranchPatterns
+	}
+	if profile.BranchNaming.Template != "" {
+		c.BranchNaming.Template = profile.BranchNaming.Template
+	}
+	if len(profile.BranchNaming.Types) > 0 {
+		c.BranchNaming.Types = profile.BranchNaming.Types
+	}
+	if profile.BranchNaming.MaxLength > 0 {
+		c.BranchNaming.MaxLength = profile.BranchNaming.MaxLength
+	}
+	if profile.WorktreePath != "" {
+		c.WorktreePath = profile.WorktreePath
+	}
+	if len(profile.WorkStates.Start) > 0 {
+		c.WorkStates.Start = profile.WorkStates.Start
+	}
+	if len(profile.WorkStates.Finish) > 0 {
+		c.WorkStates.Finish = profile.WorkStates.Finish
+	}
+
+	return nil
+}
+
+// BranchMatcher returns the matcher finding work item IDs in branch names
+func (c *Config) BranchMatcher() (*models.BranchMatcher, error) {
+	return models.NewBranchMatcher(c.BranchPatterns)
+}
+
+// BranchNamer returns the namer suggesting branch names for work items
+func (c *Config) BranchNamer() (*models.BranchNamer, error) {
+	return models.NewBranchNamer(c.BranchNaming.Template, c.BranchNaming.Types, c.BranchNaming.MaxLength)
+}
+
+// ProfileName returns the name of the active profile
+func (c *Config) ProfileName() string {
+	if c.Profile == "" {
+		return DefaultProfile
+	}
+	return strings.ToLower(c.Profile)
+}
+
+// NotificationInterval returns how often to poll for changes to my work
+// items, or 0 when polling is off
+func (c *Config) NotificationInterval() time.Duration {
+	if c.Notifications.Interval <= 0 {
+		return 0
+	}
+	return max(c.Notifications.Interval, MinNotificationInterval)
+}
+
 // BaseURL returns the Azure DevOps API base URL
 func (c *Config) BaseURL() string {
 	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis", c.Organization, c.Project)
@@ -131,11 +281,68 @@ pat: ""
 # UI settings
 theme: "default"  # default, dark, light
 
+# Highlight open items that haven't changed in this many days (0 disables)
+stale_days: 14
+
 # Default filters at startup
 defaults:
   sprint: "current"      # "current", "all", or specific name
   state: "all"           # "all", "new", "active", "resolved", "closed"
   assigned: "me"         # "all", "me"
+
+# Work items table columns (field reference names; "flex: true" fills remaining space)
+# Columns can also be changed in the app with "c" and are then saved per profile.
+# columns:
+#   - field: "System.Id"
+#   - field: "System.WorkItemType"
+#   - field: "Microsoft.VSTS.Common.Priority"
+#   - field: "System.Title"
+#     flex: true
+
+# Regular expressions finding the work item ID in git branch names; the first
+# capture group is the ID. The default matches "feature/123-slug", "users/me/123"
+# and "bug/AB#123".
+# branch_patterns:
+#   - '^[a-z]+/(\d+)-'
+
+# Branch names suggested for work items (Go templates). Available: .ID, .Type,
+# .Prefix (feature, bugfix, task, epic), .Title, .Slug, .State, .Area,
+# .Iteration, .Alias and .Field "Custom.Field"; functions slug, lower, upper.
+# branch_naming:
+#   template: "users/{{.Alias}}/{{.ID}}-{{.Slug}}"
+#   types:
+#     bug: "{{.Type | slug}}/AB#{{.ID}}"
+#   max_length: 60
+
+# Where branches are checked out when created in a new worktree, relative
+# to the main working tree; {id} is the work item ID, {repo} the repository
+# directory name
+# worktree_path: "../wt/{id}"
+
+# States set by "start work" (w) and "finish work" (W) per work item type.
+# Without these, the type's first In Progress and Resolved states are used.
+# work_states:
+#   start:
+#     task: "In Progress"
+#   finish:
+#     default: "Resolved"
+#     task: "Done"
+
+# Poll for changes others make to items assigned to you, created by you or
+# mentioning you; new ones show in the inbox (n). At least 30s.
+# notifications:
+#   interval: "2m"
+#   bell: true       # ring the terminal bell
+#   desktop: false   # OSC 9 desktop notification (iTerm2, WezTerm, Windows Terminal...)
+
+# Named profiles override the settings above; select with "profile"
+# or the AZURE_DEVOPS_PROFILE environment variable
+# profile: "work"
+# profiles:
+#   work:
+#     organization: "other-organization"
+#     project: "other-project"
+#     team: "other-team"
 `
 
 	return os.WriteFile(configPath, []byte(content), 0600)
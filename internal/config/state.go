@@ -2,30 +2,66 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/samuelenocsson/devops-tui/internal/models"
 )
 
 // FilterState holds the persisted filter selections
 type FilterState struct {
-	Sprint   string `json:"sprint"`
-	State    string `json:"state"`
-	Assigned string `json:"assigned"`
-	Area     string `json:"area"`
+	// Selections maps a filter group key to its selected values
+	Selections map[string][]string `json:"selections,omitempty"`
+
+	// Legacy single-value fields, read for backwards compatibility
+	Sprint   string `json:"sprint,omitempty"`
+	State    string `json:"state,omitempty"`
+	Assigned string `json:"assigned,omitempty"`
+	Area     string `json:"area,omitempty"`
+}
+
+// defaultFilterState returns the filter state used when nothing is persisted
+func defaultFilterState() *FilterState {
+	return &FilterState{
+		Selections: map[string][]string{
+			"sprint":   {"current"},
+			"state":    {"all"},
+			"assigned": {"me"},
+			"area":     {"all"},
+		},
+	}
 }
 
-// getStatePath returns the path to the state file
-func getStatePath() (string, error) {
+// getStatePath returns the path to the filter state file of a profile.
+// Filters name the profile's iterations and areas, so other profiles'
+// selections don't apply. The default profile keeps the original file.
+func getStatePath(profile string) (string, error) {
+	if err := ValidateProfileName(profile); err != nil {
+		return "", err
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".config", "devops-tui", "state.json"), nil
+	if profile == DefaultProfile {
+		return filepath.Join(home, ".config", "devops-tui", "state.json"), nil
+	}
+	return filepath.Join(home, ".config", "devops-tui", "filters", profile+".json"), nil
 }
 
-// LoadFilterState loads the persisted filter state
-func LoadFilterState() (*FilterState, error) {
-	statePath, err := getStatePath()
+// ValidateProfileName checks that a profile name can be used in file names
+func ValidateProfileName(profile string) error {
+	if profile == "" || profile == "." || profile == ".." || strings.ContainsAny(profile, `/\`) {
+		return fmt.Errorf("invalid profile name %q", profile)
+	}
+	return nil
+}
+
+// LoadFilterState loads the persisted filter state of a profile
+func LoadFilterState(profile string) (*FilterState, error) {
+	statePath, err := getStatePath(profile)
 	if err != nil {
 		return nil, err
 	}
@@ -34,12 +70,7 @@ func LoadFilterState() (*FilterState, error) {
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Return default state if file doesn't exist
-			return &FilterState{
-				Sprint:   "current",
-				State:    "all",
-				Assigned: "me",
-				Area:     "all",
-			}, nil
+			return defaultFilterState(), nil
 		}
 		return nil, err
 	}
@@ -47,20 +78,95 @@ func LoadFilterState() (*FilterState, error) {
 	var state FilterState
 	if err := json.Unmarshal(data, &state); err != nil {
 		// Return default state if file is corrupted
-		return &FilterState{
-			Sprint:   "current",
-			State:    "all",
-			Assigned: "me",
-			Area:     "all",
-		}, nil
+		return defaultFilterState(), nil
+	}
+
+	// Convert state files written before multi-value filters existed
+	if state.Selections == nil {
+		state.Selections = make(map[string][]string)
+		legacy := map[string]string{
+			"sprint":   state.Sprint,
+			"state":    state.State,
+			"assigned": state.Assigned,
+			"area":     state.Area,
+		}
+		for key, value := range legacy {
+			if value != "" {
+				state.Selections[key] = []string{value}
+			}
+		}
+	}
+
+	return &state, nil
+}
+
+// SaveFilterState saves the filter state of a profile to disk
+func SaveFilterState(profile string, state *FilterState) error {
+	statePath, err := getStatePath(profile)
+	if err != nil {
+		return err
+	}
+
+	// Ensure directory exists
+	dir := filepath.Dir(statePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(statePath, data, 0600)
+}
+
+// ProfileState holds UI choices that are persisted per profile
+type ProfileState struct {
+	Columns []models.Column  `json:"columns,omitempty"`
+	Sort    []models.SortKey `json:"sort,omitempty"`
+	GroupBy string           `json:"group_by,omitempty"`
+}
+
+// getProfileStatePath returns the path to the state file of a profile
+func getProfileStatePath(profile string) (string, error) {
+	if err := ValidateProfileName(profile); err != nil {
+		return "", err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "devops-tui", "profiles", profile+".json"), nil
+}
+
+// LoadProfileState loads the persisted state of a profile.
+// A missing or corrupted file yields an empty state.
+func LoadProfileState(profile string) (*ProfileState, error) {
+	statePath, err := getProfileStatePath(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ProfileState{}, nil
+		}
+		return nil, err
+	}
+
+	var state ProfileState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return &ProfileState{}, nil
 	}
 
 	return &state, nil
 }
 
-// SaveFilterState saves the filter state to disk
-func SaveFilterState(state *FilterState) error {
-	statePath, err := getStatePath()
+// SaveProfileState saves the state of a profile to disk
+func SaveProfileState(profile string, state *ProfileState) error {
+	statePath, err := getProfileStatePath(profile)
 	if err != nil {
 		return err
 	}
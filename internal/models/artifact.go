@@ -0,0 +1,152 @@
+package models
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ArtifactKind is the kind of artifact a work item artifact link points at
+type ArtifactKind int
+
+const (
+	ArtifactUnknown ArtifactKind = iota
+	ArtifactPullRequest
+	ArtifactCommit
+	ArtifactBranch
+	ArtifactBuild
+)
+
+// ArtifactRef is a parsed vstfs:/// artifact link, e.g.
+// "vstfs:///Git/PullRequestId/<project>%2F<repo>%2F42"
+type ArtifactRef struct {
+	Kind         ArtifactKind
+	URL          string
+	ProjectID    string // Empty for builds, which only carry their ID
+	RepositoryID string
+	ID           string // Pull request ID, commit SHA, branch name or build ID
+}
+
+// ParseArtifactURL parses an artifact link URL. ok is false for URLs that
+// aren't vstfs:/// links.
+func ParseArtifactURL(u string) (ref ArtifactRef, ok bool) {
+	const prefix = "vstfs:///"
+	if !strings.HasPrefix(u, prefix) {
+		return ArtifactRef{}, false
+	}
+	ref.URL = u
+
+	// <tool>/<type>/<url encoded id>
+	parts := strings.SplitN(strings.TrimPrefix(u, prefix), "/", 3)
+	if len(parts) < 3 {
+		return ref, true
+	}
+	id, err := url.PathUnescape(parts[2])
+	if err != nil {
+		id = parts[2]
+	}
+
+	switch parts[0] + "/" + parts[1] {
+	case "Build/Build":
+		ref.Kind = ArtifactBuild
+		ref.ID = id
+		return ref, true
+	case "Git/PullRequestId":
+		ref.Kind = ArtifactPullRequest
+	case "Git/Commit":
+		ref.Kind = ArtifactCommit
+	case "Git/Ref":
+		ref.Kind = ArtifactBranch
+	default:
+		ref.ID = id
+		return ref, true
+	}
+
+	// Git artifacts are <project>/<repository>/<id>
+	gitParts := strings.SplitN(id, "/", 3)
+	if len(gitParts) < 3 {
+		ref.Kind = ArtifactUnknown
+		ref.ID = id
+		return ref, true
+	}
+	ref.ProjectID = gitParts[0]
+	ref.RepositoryID = gitParts[1]
+	ref.ID = gitParts[2]
+	if ref.Kind == ArtifactBranch {
+		// Branch refs are prefixed with GB (Git branch)
+		ref.ID = strings.TrimPrefix(ref.ID, "GB")
+	}
+	return ref, true
+}
+
+// BranchArtifactURL returns the artifact link URL of a Git branch, as used
+// by the Development section of work items
+func BranchArtifactURL(projectID, repositoryID, branch string) string {
+	return "vstfs:///Git/Ref/" + url.PathEscape(projectID+"/"+repositoryID+"/GB"+branch)
+}
+
+// String returns a short description of the artifact, e.g. "Pull request !42"
+func (r ArtifactRef) String() string {
+	switch r.Kind {
+	case ArtifactPullRequest:
+		return "Pull request !" + r.ID
+	case ArtifactCommit:
+		return "Commit " + ShortSHA(r.ID)
+	case ArtifactBranch:
+		return "Branch " + r.ID
+	case ArtifactBuild:
+		return "Build " + r.ID
+	}
+	return r.ID
+}
+
+// ShortSHA returns the abbreviated form of a commit SHA
+func ShortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// Commit is a Git commit
+type Commit struct {
+	SHA     string
+	Message string
+	Author  string
+	WebURL  string
+}
+
+// Subject returns the first line of the commit message
+func (c *Commit) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// Artifact is an artifact link resolved through the Git and Build APIs
+type Artifact struct {
+	Ref         ArtifactRef
+	Repository  string
+	PullRequest *PullRequest // Set for pull requests
+	Commit      *Commit      // Set for commits
+	Build       *Build       // The linked build, or the latest build of a pull request or branch
+	WebURL      string
+	Err         error // Set when the artifact couldn't be resolved
+}
+
+// Title returns a one line description of the artifact
+func (a *Artifact) Title() string {
+	switch {
+	case a.PullRequest != nil:
+		return "!" + strconv.Itoa(a.PullRequest.ID) + " " + a.PullRequest.Title
+	case a.Commit != nil:
+		return ShortSHA(a.Commit.SHA) + " " + a.Commit.Subject()
+	case a.Ref.Kind == ArtifactBranch:
+		if a.Repository != "" {
+			return a.Repository + "/" + a.Ref.ID
+		}
+		return a.Ref.ID
+	case a.Ref.Kind == ArtifactBuild && a.Build != nil:
+		return a.Build.Title()
+	}
+	return a.Ref.String()
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// DefaultBranchPattern finds a work item ID at the start of a branch name
+// segment, as in "feature/123-slug", "users/alice/123" or "bug/AB#123".
+// Version numbers such as "release/2024.1" don't match.
+const DefaultBranchPattern = `(?:^|/)(?:AB#)?(\d+)(?:[-_/]|$)`
+
+// BranchMatcher reads work item IDs back from branch names
+type BranchMatcher struct {
+	patterns []*regexp.Regexp
+}
+
+// NewBranchMatcher compiles branch patterns: regular expressions whose
+// first capture group is the work item ID, tried in order. Without
+// patterns DefaultBranchPattern is used.
+func NewBranchMatcher(patterns []string) (*BranchMatcher, error) {
+	if len(patterns) == 0 {
+		patterns = []string{DefaultBranchPattern}
+	}
+
+	m := &BranchMatcher{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid branch pattern %q: %w", p, err)
+		}
+		if re.NumSubexp() < 1 {
+			return nil, fmt.Errorf("branch pattern %q has no capture group for the work item ID", p)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// WorkItemID returns the work item ID in a branch name, or 0 if no
+// pattern matches
+func (m *BranchMatcher) WorkItemID(branch string) int {
+	for _, re := range m.patterns {
+		match := re.FindStringSubmatch(branch)
+		if match == nil {
+			continue
+		}
+		if id, err := strconv.Atoi(match[1]); err == nil && id > 0 {
+			return id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,223 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DefaultBranchTemplate is the branch name suggested when no template is
+// configured, e.g. "bugfix/123-fix-login-timeout"
+const DefaultBranchTemplate = "{{.Prefix}}/{{.ID}}-{{.Slug}}"
+
+// DefaultBranchMaxLength is the longest suggested branch name when no
+// maximum is configured
+const DefaultBranchMaxLength = 60
+
+// DefaultWorktreePath is where worktrees for work items are created when no
+// pattern is configured, relative to the repository's main working tree
+const DefaultWorktreePath = "../wt/{id}"
+
+// ExpandWorktreePath fills in a worktree path pattern: {id} is the work
+// item ID and {repo} the name of the repository's directory
+func ExpandWorktreePath(pattern string, id int, repo string) string {
+	if pattern == "" {
+		pattern = DefaultWorktreePath
+	}
+	return strings.NewReplacer("{id}", strconv.Itoa(id), "{repo}", repo).Replace(pattern)
+}
+
+// BranchNameData is what branch name templates are executed with
+type BranchNameData struct {
+	ID        int
+	Type      string // Work item type, e.g. "User Story"
+	Prefix    string // Conventional prefix of the type: feature, bugfix, task or epic
+	Title     string
+	Slug      string // Title transliterated to lowercase ASCII words joined by hyphens
+	State     string
+	Area      string // Last segment of the area path
+	Iteration string // Last segment of the iteration path
+	Alias     string // Current user's alias, e.g. "alice" for alice@example.com
+
+	item *WorkItem
+}
+
+// Field returns the raw value of a field by reference name, or "" when the
+// item doesn't have it
+func (d BranchNameData) Field(ref string) string {
+	v, ok := d.item.Fields[ref]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// BranchNamer suggests branch names for work items from Go templates
+type BranchNamer struct {
+	template  *template.Template
+	byType    map[string]*template.Template // Keyed by lowercased type
+	maxLength int
+}
+
+// branchTemplateFuncs are the functions available in branch name templates
+var branchTemplateFuncs = template.FuncMap{
+	"slug":  Slugify,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// NewBranchNamer compiles the default template and the per work item type
+// templates. An empty template uses DefaultBranchTemplate, a max length of
+// 0 or less uses DefaultBranchMaxLength.
+func NewBranchNamer(defaultTemplate string, byType map[string]string, maxLength int) (*BranchNamer, error) {
+	if defaultTemplate == "" {
+		defaultTemplate = DefaultBranchTemplate
+	}
+	if maxLength <= 0 {
+		maxLength = DefaultBranchMaxLength
+	}
+
+	tmpl, err := parseBranchTemplate("default", defaultTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &BranchNamer{
+		template:  tmpl,
+		byType:    make(map[string]*template.Template),
+		maxLength: maxLength,
+	}
+	for typ, text := range byType {
+		tmpl, err := parseBranchTemplate(typ, text)
+		if err != nil {
+			return nil, err
+		}
+		n.byType[strings.ToLower(typ)] = tmpl
+	}
+	return n, nil
+}
+
+// parseBranchTemplate parses a branch name template
+func parseBranchTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(branchTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch template %q: %w", text, err)
+	}
+	return tmpl, nil
+}
+
+// Name suggests a branch name for a work item. The slug is shortened, at a
+// word boundary when possible, to keep the name within the max length.
+func (n *BranchNamer) Name(item *WorkItem, alias string) (string, error) {
+	tmpl, ok := n.byType[strings.ToLower(string(item.Type))]
+	if !ok {
+		tmpl = n.template
+	}
+
+	data := BranchNameData{
+		ID:        item.ID,
+		Type:      string(item.Type),
+		Prefix:    branchPrefix(item.Type),
+		Title:     item.Title,
+		Slug:      Slugify(item.Title),
+		State:     string(item.State),
+		Area:      item.AreaName(),
+		Iteration: item.SprintName(),
+		Alias:     alias,
+		item:      item,
+	}
+
+	name, err := executeBranchTemplate(tmpl, data)
+	if err != nil {
+		return "", err
+	}
+
+	// Shorten the slug by what the name is over
+	if over := len(name) - n.maxLength; over > 0 && data.Slug != "" {
+		data.Slug = shortenSlug(data.Slug, len(data.Slug)-over)
+		if name, err = executeBranchTemplate(tmpl, data); err != nil {
+			return "", err
+		}
+	}
+
+	// Templates without a slug are cut as a last resort
+	if runes := []rune(name); len(runes) > n.maxLength {
+		name = strings.TrimRight(string(runes[:n.maxLength]), "-_./")
+	}
+	return name, nil
+}
+
+// executeBranchTemplate renders a branch name template
+func executeBranchTemplate(tmpl *template.Template, data BranchNameData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("branch template: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// branchPrefix returns the conventional branch prefix of a work item type
+func branchPrefix(t WorkItemType) string {
+	switch t {
+	case WorkItemTypeBug:
+		return "bugfix"
+	case WorkItemTypeTask:
+		return "task"
+	case WorkItemTypeEpic:
+		return "epic"
+	default:
+		return "feature"
+	}
+}
+
+// shortenSlug cuts a slug to at most maxLen characters, preferring not to
+// cut in the middle of a word
+func shortenSlug(slug string, maxLen int) string {
+	if maxLen <= 0 {
+		return ""
+	}
+	if len(slug) <= maxLen {
+		return slug
+	}
+	slug = slug[:maxLen]
+	if lastHyphen := strings.LastIndex(slug, "-"); lastHyphen > maxLen/2 {
+		slug = slug[:lastHyphen]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// transliterations maps letters to their ASCII spelling in branch names
+var transliterations = map[rune]string{
+	'å': "a", 'ä': "a", 'à': "a", 'á': "a", 'â': "a", 'ã': "a",
+	'æ': "ae",
+	'ç': "c",
+	'ð': "d",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ö': "o", 'ø': "o", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o",
+	'œ': "oe",
+	'ß': "ss",
+	'þ': "th",
+	'ü': "u", 'ù': "u", 'ú': "u", 'û': "u",
+	'ý': "y", 'ÿ': "y",
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify turns text into lowercase ASCII words joined by hyphens,
+// transliterating accented letters ("Åtgärda fel" becomes "atgarda-fel")
+func Slugify(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Trim(nonSlugChars.ReplaceAllString(b.String(), "-"), "-")
+}
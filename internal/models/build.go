@@ -0,0 +1,128 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Build is a pipeline run
+type Build struct {
+	ID           int
+	Number       string
+	DefinitionID int
+	Definition   string
+	Status       string // notStarted, inProgress, cancelling or completed
+	Result       string // succeeded, partiallySucceeded, failed or canceled once completed
+	SourceBranch string
+	RequestedFor string // Display name of who triggered the run
+	RequesterID  string // Account (unique name) of who triggered the run
+	QueueTime    time.Time
+	StartTime    time.Time
+	FinishTime   time.Time
+	WebURL       string
+}
+
+// BuildDefinition is a pipeline
+type BuildDefinition struct {
+	ID   int
+	Name string
+	Path string // Folder, e.g. "\\Services"
+
+	// Only set when a single pipeline is fetched
+	DefaultBranch  string // Without the refs/heads/ prefix
+	RepositoryID   string
+	RepositoryType string // TfsGit for Azure Repos
+	YAMLPath       string // "" for classic pipelines
+	Variables      []PipelineVariable
+}
+
+// Title returns "<definition> <number>"
+func (b *Build) Title() string {
+	return strings.TrimSpace(b.Definition + " " + b.Number)
+}
+
+// StatusLabel returns the result of a completed build, or its status
+func (b *Build) StatusLabel() string {
+	switch {
+	case b.Status == "completed" && b.Result != "":
+		return splitCamel(b.Result)
+	case b.Status != "":
+		return splitCamel(b.Status)
+	}
+	return ""
+}
+
+// IsRunning reports whether the build is queued or in progress
+func (b *Build) IsRunning() bool {
+	return b.Status != "" && b.Status != "completed"
+}
+
+// CanRetry reports whether the build completed without succeeding, so its
+// failed stages can be run again
+func (b *Build) CanRetry() bool {
+	return b.Status == "completed" && b.Result != "succeeded"
+}
+
+// Branch returns the source branch without its refs/heads/ prefix
+func (b *Build) Branch() string {
+	return BranchName(b.SourceBranch)
+}
+
+// Duration returns how long the build ran, or has been running; 0 when it
+// hasn't started
+func (b *Build) Duration(now time.Time) time.Duration {
+	switch {
+	case b.StartTime.IsZero():
+		return 0
+	case b.FinishTime.IsZero():
+		return now.Sub(b.StartTime)
+	default:
+		return b.FinishTime.Sub(b.StartTime)
+	}
+}
+
+// FormatDuration formats a duration compactly, e.g. "1h02m" or "3m05s"
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// FormatAge formats how long ago a time was, e.g. "5m ago" or "3d ago"
+func FormatAge(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// splitCamel turns API enum values like "partiallySucceeded" into
+// "Partially succeeded"
+func splitCamel(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case i == 0:
+			b.WriteString(strings.ToUpper(string(r)))
+		case r >= 'A' && r <= 'Z':
+			b.WriteString(" " + strings.ToLower(string(r)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
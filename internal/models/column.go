@@ -0,0 +1,109 @@
+package models
+
+import "strings"
+
+// Column describes a column in the work items table
+type Column struct {
+	Field string `json:"field"`           // Field reference name, e.g. "System.Title"
+	Title string `json:"title,omitempty"` // Header text; derived from the field if empty
+	Width int    `json:"width,omitempty"` // Fixed width; 0 uses the field's default width
+	Flex  bool   `json:"flex,omitempty"`  // If true, the column takes the remaining space
+}
+
+// defaultColumnWidth is the width of fields without a built-in definition
+const defaultColumnWidth = 12
+
+// KnownColumns lists the columns offered by the column chooser out of the box.
+// Any other field reference name (e.g. custom fields) can be added as well.
+var KnownColumns = []Column{
+	{Field: FieldID, Title: "ID", Width: 7},
+	{Field: FieldType, Title: "TYPE", Width: 8},
+	{Field: FieldState, Title: "STATE", Width: 12},
+	{Field: FieldAssignedTo, Title: "ASSIGNED", Width: 14},
+	{Field: FieldTitle, Title: "TITLE", Flex: true},
+	{Field: FieldPriority, Title: "PRI", Width: 4},
+	{Field: FieldIterationPath, Title: "SPRINT", Width: 12},
+	{Field: FieldAreaPath, Title: "AREA", Width: 12},
+	{Field: FieldTags, Title: "TAGS", Width: 16},
+	{Field: FieldStoryPoints, Title: "SP", Width: 4},
+	{Field: FieldRemainingWork, Title: "REMAINING", Width: 9},
+	{Field: FieldChangedDate, Title: "CHANGED", Width: 10},
+	{Field: FieldCreatedDate, Title: "CREATED", Width: 10},
+	{Field: FieldParent, Title: "PARENT", Width: 7},
+}
+
+// DefaultColumns returns the columns shown when nothing is configured
+func DefaultColumns() []Column {
+	return []Column{
+		{Field: FieldID, Title: "ID", Width: 7},
+		{Field: FieldType, Title: "TYPE", Width: 8},
+		{Field: FieldState, Title: "STATE", Width: 12},
+		{Field: FieldAssignedTo, Title: "ASSIGNED", Width: 14},
+		{Field: FieldTitle, Title: "TITLE", Flex: true},
+	}
+}
+
+// KnownColumn returns the built-in definition for a field, if there is one
+func KnownColumn(field string) (Column, bool) {
+	for _, col := range KnownColumns {
+		if col.Field == field {
+			return col, true
+		}
+	}
+	return Column{}, false
+}
+
+// HeaderTitle returns the column header text
+func (c Column) HeaderTitle() string {
+	if c.Title != "" {
+		return c.Title
+	}
+	if known, ok := KnownColumn(c.Field); ok {
+		return known.Title
+	}
+	// "Custom.ReleaseTrain" -> "RELEASETRAIN"
+	name := c.Field
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.ToUpper(name)
+}
+
+// IsFlex returns true if the column takes the remaining width
+func (c Column) IsFlex() bool {
+	if c.Flex {
+		return true
+	}
+	if c.Width > 0 {
+		return false
+	}
+	known, ok := KnownColumn(c.Field)
+	return ok && known.Flex
+}
+
+// ColumnWidth returns the fixed width of the column
+func (c Column) ColumnWidth() int {
+	if c.Width > 0 {
+		return c.Width
+	}
+	if known, ok := KnownColumn(c.Field); ok && known.Width > 0 {
+		return known.Width
+	}
+	return defaultColumnWidth
+}
+
+// ColumnFields returns the field reference names needed to render the columns
+func ColumnFields(columns []Column) []string {
+	fields := make([]string, 0, len(columns))
+	for _, col := range columns {
+		fields = append(fields, col.Field)
+	}
+	return fields
+}
+
+// FieldDefinition describes a work item field available in the project
+type FieldDefinition struct {
+	Name          string `json:"name"`
+	ReferenceName string `json:"referenceName"`
+	Type          string `json:"type"`
+}
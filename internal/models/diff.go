@@ -0,0 +1,227 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiffLineKind is what a line of a diff shows
+type DiffLineKind int
+
+const (
+	DiffContext DiffLineKind = iota
+	DiffAdded
+	DiffRemoved
+	DiffHunk // "@@ -1,3 +1,4 @@" header starting a hunk
+)
+
+// DiffLine is a line of a unified diff
+type DiffLine struct {
+	Kind    DiffLineKind
+	OldLine int // Line number in the old file, 0 for added lines and headers
+	NewLine int // Line number in the new file, 0 for removed lines and headers
+	Text    string
+}
+
+// diffContextLines is how many unchanged lines surround each change
+const diffContextLines = 3
+
+// maxDiffCells caps the work of comparing the changed middle of two files;
+// beyond it the whole middle shows as replaced
+const maxDiffCells = 4_000_000
+
+// ParseUnifiedDiff parses the hunks of a single file's `git diff` output.
+// binary is set when git found the file binary and showed no lines.
+func ParseUnifiedDiff(diff string) (lines []DiffLine, binary bool) {
+	oldLine, newLine := 0, 0
+	inHunk := false
+	for _, text := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(text, "@@"):
+			oldLine, newLine = parseHunkHeader(text)
+			inHunk = true
+			lines = append(lines, DiffLine{Kind: DiffHunk, Text: text})
+		case !inHunk:
+			if strings.HasPrefix(text, "Binary files ") {
+				binary = true
+			}
+		case strings.HasPrefix(text, "+"):
+			lines = append(lines, DiffLine{Kind: DiffAdded, NewLine: newLine, Text: text[1:]})
+			newLine++
+		case strings.HasPrefix(text, "-"):
+			lines = append(lines, DiffLine{Kind: DiffRemoved, OldLine: oldLine, Text: text[1:]})
+			oldLine++
+		case strings.HasPrefix(text, " "), text == "":
+			lines = append(lines, DiffLine{Kind: DiffContext, OldLine: oldLine, NewLine: newLine, Text: strings.TrimPrefix(text, " ")})
+			oldLine++
+			newLine++
+		}
+		// "\ No newline at end of file" markers are dropped
+	}
+	return lines, binary
+}
+
+// parseHunkHeader returns the first old and new line numbers of a hunk
+// header like "@@ -12,7 +12,9 @@ func main() {"
+func parseHunkHeader(header string) (oldStart, newStart int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 1, 1
+	}
+	start := func(field string) int {
+		n, err := strconv.Atoi(strings.SplitN(field[1:], ",", 2)[0])
+		if err != nil {
+			return 1
+		}
+		return n
+	}
+	return start(fields[1]), start(fields[2])
+}
+
+// DiffFiles compares two versions of a file line by line and returns the
+// changes as unified diff hunks. A file that is added or deleted has no
+// lines on the other side.
+func DiffFiles(oldLines, newLines []string) []DiffLine {
+	// Unchanged start and end of the file are skipped before comparing
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	// Every line of both files, in order, as kept, removed or added
+	var edits []lcsEdit
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, lcsEdit{DiffContext, i, i})
+	}
+	a := oldLines[prefix : len(oldLines)-suffix]
+	b := newLines[prefix : len(newLines)-suffix]
+	for _, e := range lcsEdits(a, b) {
+		edits = append(edits, lcsEdit{e.kind, e.old + prefix, e.new + prefix})
+	}
+	for i := 0; i < suffix; i++ {
+		edits = append(edits, lcsEdit{DiffContext, len(oldLines) - suffix + i, len(newLines) - suffix + i})
+	}
+
+	// Keep the changes and the unchanged lines near them, grouped in hunks
+	keep := make([]bool, len(edits))
+	for i, e := range edits {
+		if e.kind == DiffContext {
+			continue
+		}
+		for j := max(i-diffContextLines, 0); j <= min(i+diffContextLines, len(edits)-1); j++ {
+			keep[j] = true
+		}
+	}
+
+	var lines []DiffLine
+	for i := 0; i < len(edits); {
+		if !keep[i] {
+			i++
+			continue
+		}
+		end := i
+		for end < len(edits) && keep[end] {
+			end++
+		}
+
+		oldStart, newStart, oldCount, newCount := 0, 0, 0, 0
+		var hunk []DiffLine
+		for _, e := range edits[i:end] {
+			line := DiffLine{Kind: e.kind}
+			switch e.kind {
+			case DiffContext:
+				line.OldLine, line.NewLine, line.Text = e.old+1, e.new+1, newLines[e.new]
+				oldCount++
+				newCount++
+			case DiffRemoved:
+				line.OldLine, line.Text = e.old+1, oldLines[e.old]
+				oldCount++
+			case DiffAdded:
+				line.NewLine, line.Text = e.new+1, newLines[e.new]
+				newCount++
+			}
+			if oldStart == 0 && line.OldLine != 0 {
+				oldStart = line.OldLine
+			}
+			if newStart == 0 && line.NewLine != 0 {
+				newStart = line.NewLine
+			}
+			hunk = append(hunk, line)
+		}
+		header := fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)
+		lines = append(lines, DiffLine{Kind: DiffHunk, Text: header})
+		lines = append(lines, hunk...)
+		i = end
+	}
+	return lines
+}
+
+// lcsEdit is a line kept, removed or added, by 0-based index
+type lcsEdit struct {
+	kind     DiffLineKind
+	old, new int
+}
+
+// lcsEdits returns the fewest removals and additions turning a into b,
+// using their longest common subsequence. Inputs too large to compare show
+// as all of a removed and all of b added.
+func lcsEdits(a, b []string) []lcsEdit {
+	var edits []lcsEdit
+	if len(a)*len(b) > maxDiffCells {
+		for i := range a {
+			edits = append(edits, lcsEdit{DiffRemoved, i, 0})
+		}
+		for j := range b {
+			edits = append(edits, lcsEdit{DiffAdded, len(a), j})
+		}
+		return edits
+	}
+
+	// length[i][j] is the LCS length of a[i:] and b[j:]
+	length := make([][]int, len(a)+1)
+	for i := range length {
+		length[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				length[i][j] = length[i+1][j+1] + 1
+			} else {
+				length[i][j] = max(length[i+1][j], length[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, lcsEdit{DiffContext, i, j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && length[i+1][j] >= length[i][j+1]):
+			edits = append(edits, lcsEdit{DiffRemoved, i, j})
+			i++
+		default:
+			edits = append(edits, lcsEdit{DiffAdded, i, j})
+			j++
+		}
+	}
+	return edits
+}
+
+// SplitLines splits file content into lines, without a trailing empty line
+// for the final newline
+func SplitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"sort"
+	"strings"
+)
+
 // FilterType represents the type of filter
 type FilterType int
 
@@ -8,8 +13,18 @@ const (
 	FilterTypeState
 	FilterTypeAssigned
 	FilterTypeArea
+	FilterTypeType
+	FilterTypeTag
+	FilterTypePriority
+	FilterTypeDate
 )
 
+// AllValue is the option value meaning "no restriction" for a filter group
+const AllValue = "all"
+
+// CustomDateValue is the date option that asks the user for a custom range
+const CustomDateValue = "custom"
+
 // FilterOption represents a selectable filter option
 type FilterOption struct {
 	Label    string
@@ -20,8 +35,10 @@ type FilterOption struct {
 // FilterGroup represents a group of filter options
 type FilterGroup struct {
 	Type    FilterType
+	Key     string // Stable key used when persisting selections
 	Title   string
 	Options []FilterOption
+	Multi   bool // If true, several options can be selected at once
 	Cursor  int
 	Offset  int // Scroll offset for viewing
 }
@@ -49,11 +66,114 @@ func (f *FilterGroup) Select(index int) {
 	}
 }
 
-// SelectCurrent marks the option at the current cursor as selected
+// Toggle flips the selection of the option at the given index.
+// The "All" option is exclusive: selecting it clears the other options,
+// and clearing the last selected option falls back to "All".
+func (f *FilterGroup) Toggle(index int) {
+	if index < 0 || index >= len(f.Options) {
+		return
+	}
+
+	if f.Options[index].Value == AllValue {
+		f.Select(index)
+		return
+	}
+
+	f.Options[index].Selected = !f.Options[index].Selected
+
+	anySelected := false
+	for i := range f.Options {
+		if f.Options[i].Value == AllValue {
+			continue
+		}
+		if f.Options[i].Selected {
+			anySelected = true
+		}
+	}
+	for i := range f.Options {
+		if f.Options[i].Value == AllValue {
+			f.Options[i].Selected = !anySelected
+		}
+	}
+}
+
+// SelectCurrent marks the option at the current cursor as selected,
+// or toggles it for multi-select groups
 func (f *FilterGroup) SelectCurrent() {
+	if f.Multi {
+		f.Toggle(f.Cursor)
+		return
+	}
 	f.Select(f.Cursor)
 }
 
+// SelectedValues returns the values of all selected options except "All".
+// An empty result means the group does not restrict the query.
+func (f *FilterGroup) SelectedValues() []string {
+	var values []string
+	for _, opt := range f.Options {
+		if opt.Selected && opt.Value != AllValue {
+			values = append(values, opt.Value)
+		}
+	}
+	return values
+}
+
+// SelectValues selects exactly the options matching the given values.
+// Unknown values are ignored; if nothing matches the selection is left unchanged.
+func (f *FilterGroup) SelectValues(values []string) {
+	wanted := make(map[string]bool, len(values))
+	for _, v := range values {
+		wanted[v] = true
+	}
+
+	matched := false
+	for _, opt := range f.Options {
+		if wanted[opt.Value] {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return
+	}
+
+	if !f.Multi {
+		for i, opt := range f.Options {
+			if wanted[opt.Value] {
+				f.Select(i)
+				return
+			}
+		}
+	}
+
+	for i := range f.Options {
+		f.Options[i].Selected = wanted[f.Options[i].Value]
+	}
+	// "All" together with specific values makes no sense; specific values win
+	if len(f.SelectedValues()) > 0 {
+		for i := range f.Options {
+			if f.Options[i].Value == AllValue {
+				f.Options[i].Selected = false
+			}
+		}
+	}
+}
+
+// Summary returns a short description of the current selection
+func (f *FilterGroup) Summary() string {
+	var labels []string
+	for _, opt := range f.Options {
+		if opt.Selected && opt.Value != AllValue {
+			labels = append(labels, opt.Label)
+		}
+	}
+	if len(labels) == 0 {
+		return "All"
+	}
+	return strings.Join(labels, ", ")
+}
+
 // MoveUp moves the cursor up
 func (f *FilterGroup) MoveUp() {
 	if f.Cursor > 0 {
@@ -88,10 +208,10 @@ type FilterState struct {
 }
 
 // NewFilterState creates a new filter state with default groups
-func NewFilterState(iterations []Iteration, areas []Area, statesByType map[string][]WorkItemStateInfo) *FilterState {
+func NewFilterState(iterations []Iteration, areas []Area, statesByType map[string][]WorkItemStateInfo, members []TeamMember, tags []string) *FilterState {
 	// Build sprint options from iterations
 	sprintOptions := []FilterOption{
-		{Label: "All", Value: "all", Selected: false},
+		{Label: "All", Value: AllValue, Selected: false},
 	}
 
 	for _, iter := range iterations {
@@ -117,7 +237,7 @@ func NewFilterState(iterations []Iteration, areas []Area, statesByType map[strin
 
 	// Build area options from areas
 	areaOptions := []FilterOption{
-		{Label: "All", Value: "all", Selected: true},
+		{Label: "All", Value: AllValue, Selected: true},
 	}
 	for _, area := range areas {
 		areaOptions = append(areaOptions, FilterOption{
@@ -129,7 +249,7 @@ func NewFilterState(iterations []Iteration, areas []Area, statesByType map[strin
 
 	// Build state options from all work item types (unique states)
 	stateOptions := []FilterOption{
-		{Label: "All", Value: "all", Selected: true},
+		{Label: "All", Value: AllValue, Selected: true},
 	}
 	if len(statesByType) > 0 {
 		// Collect unique states preserving order by category
@@ -176,33 +296,143 @@ func NewFilterState(iterations []Iteration, areas []Area, statesByType map[strin
 		}
 	}
 
+	// Build type options from the types we know states for
+	typeOptions := []FilterOption{
+		{Label: "All", Value: AllValue, Selected: true},
+	}
+	typeNames := make([]string, 0, len(statesByType))
+	for t := range statesByType {
+		typeNames = append(typeNames, t)
+	}
+	sort.Strings(typeNames)
+	if len(typeNames) == 0 {
+		typeNames = []string{
+			string(WorkItemTypeEpic),
+			string(WorkItemTypeFeature),
+			string(WorkItemTypeStory),
+			string(WorkItemTypeTask),
+			string(WorkItemTypeBug),
+		}
+	}
+	for _, t := range typeNames {
+		typeOptions = append(typeOptions, FilterOption{
+			Label: t,
+			Value: t,
+		})
+	}
+
+	// Build assigned options: the special values followed by team members
+	assignedOptions := []FilterOption{
+		{Label: "All", Value: AllValue, Selected: false},
+		{Label: "Me", Value: AssignedMe, Selected: true},
+		{Label: "Unassigned", Value: AssignedNone, Selected: false},
+	}
+	for _, member := range members {
+		assignedOptions = append(assignedOptions, FilterOption{
+			Label: member.DisplayName,
+			Value: member.UniqueName,
+		})
+	}
+
+	// Build tag options
+	tagOptions := []FilterOption{
+		{Label: "All", Value: AllValue, Selected: true},
+	}
+	for _, tag := range tags {
+		tagOptions = append(tagOptions, FilterOption{
+			Label: tag,
+			Value: tag,
+		})
+	}
+
+	// Priorities are fixed at 1-4 in all process templates
+	priorityOptions := []FilterOption{
+		{Label: "All", Value: AllValue, Selected: true},
+		{Label: "1", Value: "1"},
+		{Label: "2", Value: "2"},
+		{Label: "3", Value: "3"},
+		{Label: "4", Value: "4"},
+	}
+
+	// Date conditions; a custom range is added once the user enters one
+	dateOptions := []FilterOption{
+		{Label: "All", Value: AllValue, Selected: true},
+	}
+	for _, cond := range []DateCondition{
+		{Field: DateFieldChanged, SinceDays: 1},
+		{Field: DateFieldChanged, SinceDays: 7},
+		{Field: DateFieldCreated, SinceDays: 7},
+		{Field: DateFieldChanged, OlderThanDays: 30},
+	} {
+		dateOptions = append(dateOptions, FilterOption{
+			Label: cond.Label(),
+			Value: cond.Value(),
+		})
+	}
+	dateOptions = append(dateOptions, FilterOption{Label: "Custom range...", Value: CustomDateValue})
+
 	return &FilterState{
 		Groups: []*FilterGroup{
 			{
 				Type:    FilterTypeSprint,
+				Key:     "sprint",
 				Title:   "Sprint",
 				Options: sprintOptions,
 				Cursor:  0,
 			},
 			{
 				Type:    FilterTypeState,
+				Key:     "state",
 				Title:   "State",
 				Options: stateOptions,
+				Multi:   true,
 				Cursor:  0,
 			},
 			{
-				Type:  FilterTypeAssigned,
-				Title: "Assigned",
-				Options: []FilterOption{
-					{Label: "All", Value: "all", Selected: false},
-					{Label: "Me", Value: "me", Selected: true},
-				},
-				Cursor: 0,
+				Type:    FilterTypeAssigned,
+				Key:     "assigned",
+				Title:   "Assigned",
+				Options: assignedOptions,
+				Multi:   true,
+				Cursor:  0,
+			},
+			{
+				Type:    FilterTypeType,
+				Key:     "type",
+				Title:   "Type",
+				Options: typeOptions,
+				Multi:   true,
+				Cursor:  0,
+			},
+			{
+				Type:    FilterTypePriority,
+				Key:     "priority",
+				Title:   "Priority",
+				Options: priorityOptions,
+				Multi:   true,
+				Cursor:  0,
+			},
+			{
+				Type:    FilterTypeTag,
+				Key:     "tag",
+				Title:   "Tags",
+				Options: tagOptions,
+				Multi:   true,
+				Cursor:  0,
 			},
 			{
 				Type:    FilterTypeArea,
+				Key:     "area",
 				Title:   "Area",
 				Options: areaOptions,
+				Multi:   true,
+				Cursor:  0,
+			},
+			{
+				Type:    FilterTypeDate,
+				Key:     "date",
+				Title:   "Date",
+				Options: dateOptions,
 				Cursor:  0,
 			},
 		},
@@ -232,86 +462,114 @@ func (f *FilterState) PrevGroup() {
 	}
 }
 
-// GetSelectedSprint returns the selected sprint path
-func (f *FilterState) GetSelectedSprint() string {
+// Group returns the filter group of the given type, or nil
+func (f *FilterState) Group(t FilterType) *FilterGroup {
 	for _, g := range f.Groups {
-		if g.Type == FilterTypeSprint {
-			if opt := g.SelectedOption(); opt != nil {
-				return opt.Value
-			}
+		if g.Type == t {
+			return g
 		}
 	}
-	return "all"
+	return nil
 }
 
-// GetSelectedState returns the selected state filter
-func (f *FilterState) GetSelectedState() string {
-	for _, g := range f.Groups {
-		if g.Type == FilterTypeState {
-			if opt := g.SelectedOption(); opt != nil {
-				return opt.Value
-			}
+// SelectedValues returns the selected values for the given group type
+func (f *FilterState) SelectedValues(t FilterType) []string {
+	if g := f.Group(t); g != nil {
+		return g.SelectedValues()
+	}
+	return nil
+}
+
+// GetSelectedSprint returns the selected sprint path
+func (f *FilterState) GetSelectedSprint() string {
+	if g := f.Group(FilterTypeSprint); g != nil {
+		if opt := g.SelectedOption(); opt != nil {
+			return opt.Value
 		}
 	}
-	return "all"
+	return AllValue
 }
 
-// GetSelectedAssigned returns the selected assigned filter
-func (f *FilterState) GetSelectedAssigned() string {
-	for _, g := range f.Groups {
-		if g.Type == FilterTypeAssigned {
-			if opt := g.SelectedOption(); opt != nil {
-				return opt.Value
-			}
+// SetCustomDateRange adds (or replaces) the custom date range option and selects it
+func (f *FilterState) SetCustomDateRange(cond DateCondition) {
+	g := f.Group(FilterTypeDate)
+	if g == nil {
+		return
+	}
+
+	option := FilterOption{Label: cond.Label(), Value: cond.Value()}
+
+	// Replace a previously entered range, keeping "Custom range..." last
+	for i, opt := range g.Options {
+		if c, ok := ParseDateCondition(opt.Value); ok && c.IsRange() {
+			g.Options[i] = option
+			g.Select(i)
+			return
 		}
 	}
-	return "all"
+
+	insertAt := len(g.Options)
+	for i, opt := range g.Options {
+		if opt.Value == CustomDateValue {
+			insertAt = i
+			break
+		}
+	}
+	g.Options = append(g.Options[:insertAt], append([]FilterOption{option}, g.Options[insertAt:]...)...)
+	g.Select(insertAt)
 }
 
-// GetSelectedArea returns the selected area path
-func (f *FilterState) GetSelectedArea() string {
-	for _, g := range f.Groups {
-		if g.Type == FilterTypeArea {
-			if opt := g.SelectedOption(); opt != nil {
-				return opt.Value
-			}
+// Query builds the work item query described by the current selections
+func (f *FilterState) Query() WorkItemQuery {
+	var dates []DateCondition
+	for _, value := range f.SelectedValues(FilterTypeDate) {
+		if cond, ok := ParseDateCondition(value); ok {
+			dates = append(dates, cond)
 		}
 	}
-	return "all"
+
+	return WorkItemQuery{
+		Sprint:     f.GetSelectedSprint(),
+		States:     f.SelectedValues(FilterTypeState),
+		Types:      f.SelectedValues(FilterTypeType),
+		Assigned:   f.SelectedValues(FilterTypeAssigned),
+		Areas:      f.SelectedValues(FilterTypeArea),
+		Tags:       f.SelectedValues(FilterTypeTag),
+		Priorities: f.SelectedValues(FilterTypePriority),
+		Dates:      dates,
+	}
 }
 
-// ApplySavedSelections applies saved filter selections
-func (f *FilterState) ApplySavedSelections(sprint, state, assigned, area string) {
+// Selections returns the selected values of every group keyed by group key
+func (f *FilterState) Selections() map[string][]string {
+	selections := make(map[string][]string, len(f.Groups))
 	for _, g := range f.Groups {
-		var targetValue string
-		switch g.Type {
-		case FilterTypeSprint:
-			targetValue = sprint
-		case FilterTypeState:
-			targetValue = state
-		case FilterTypeAssigned:
-			targetValue = assigned
-		case FilterTypeArea:
-			targetValue = area
+		values := g.SelectedValues()
+		if len(values) == 0 {
+			values = []string{AllValue}
 		}
+		selections[g.Key] = values
+	}
+	return selections
+}
 
-		if targetValue == "" {
+// ApplySavedSelections applies saved filter selections keyed by group key
+func (f *FilterState) ApplySavedSelections(selections map[string][]string) {
+	for _, g := range f.Groups {
+		values, ok := selections[g.Key]
+		if !ok || len(values) == 0 {
 			continue
 		}
 
-		// Find and select the matching option
-		found := false
-		for i, opt := range g.Options {
-			if opt.Value == targetValue {
-				g.Select(i)
-				found = true
-				break
+		// A custom date range only exists as an option once it's been entered
+		if g.Type == FilterTypeDate {
+			if cond, ok := ParseDateCondition(values[0]); ok && cond.IsRange() {
+				f.SetCustomDateRange(cond)
+				continue
 			}
 		}
 
-		// If not found and it's sprint with "current", keep the current selection
-		if !found && g.Type == FilterTypeSprint && targetValue == "current" {
-			// Already handled in NewFilterState
-		}
+		// For sprint, "current" keeps the selection made in NewFilterState
+		g.SelectValues(values)
 	}
 }
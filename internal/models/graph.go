@@ -0,0 +1,313 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EdgeKind is the kind of link an edge of a dependency graph stands for
+type EdgeKind int
+
+const (
+	EdgeChild     EdgeKind = iota // Parent -> child
+	EdgeSuccessor                 // Predecessor -> successor
+)
+
+// GraphNode is a work item in a dependency graph
+type GraphNode struct {
+	ID     int
+	Title  string
+	State  WorkItemState
+	Type   WorkItemType
+	WebURL string
+
+	Closed   bool
+	Blocked  bool // Waits on a predecessor that isn't closed
+	External bool // Only known through a link, outside the loaded items
+}
+
+// Label returns "#id title"
+func (n *GraphNode) Label() string {
+	return fmt.Sprintf("#%d %s", n.ID, n.Title)
+}
+
+// GraphEdge is a link between two nodes of a dependency graph
+type GraphEdge struct {
+	From int
+	To   int
+	Kind EdgeKind
+}
+
+// DependencyGraph is the graph of parent/child and predecessor/successor
+// links between work items
+type DependencyGraph struct {
+	Nodes map[int]*GraphNode
+	Edges []GraphEdge
+}
+
+// BuildDependencyGraph builds the graph of the given items, which must have
+// their relations loaded. Linked items that aren't in items are added as
+// external nodes for dependencies; hierarchy links to them are left out.
+func BuildDependencyGraph(items []WorkItem, statesByType map[string][]WorkItemStateInfo) *DependencyGraph {
+	g := &DependencyGraph{Nodes: make(map[int]*GraphNode)}
+
+	for i := range items {
+		item := &items[i]
+		g.Nodes[item.ID] = &GraphNode{
+			ID:     item.ID,
+			Title:  item.Title,
+			State:  item.State,
+			Type:   item.Type,
+			WebURL: item.WebURL,
+			Closed: item.IsClosed(statesByType),
+		}
+	}
+
+	seen := make(map[GraphEdge]bool)
+	addEdge := func(e GraphEdge) {
+		if e.From != e.To && !seen[e] {
+			seen[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+		for _, rel := range item.Relations {
+			if rel.TargetID == 0 {
+				continue
+			}
+
+			var edge GraphEdge
+			switch rel.Rel {
+			case RelHierarchyForward:
+				edge = GraphEdge{From: item.ID, To: rel.TargetID, Kind: EdgeChild}
+			case RelHierarchyReverse:
+				edge = GraphEdge{From: rel.TargetID, To: item.ID, Kind: EdgeChild}
+			case RelDependencyForward:
+				edge = GraphEdge{From: item.ID, To: rel.TargetID, Kind: EdgeSuccessor}
+			case RelDependencyReverse:
+				edge = GraphEdge{From: rel.TargetID, To: item.ID, Kind: EdgeSuccessor}
+			default:
+				continue
+			}
+
+			if _, ok := g.Nodes[rel.TargetID]; !ok {
+				if edge.Kind == EdgeChild {
+					continue
+				}
+				target := WorkItem{Type: rel.TargetType, State: rel.TargetState}
+				g.Nodes[rel.TargetID] = &GraphNode{
+					ID:       rel.TargetID,
+					Title:    rel.TargetTitle,
+					State:    rel.TargetState,
+					Type:     rel.TargetType,
+					Closed:   target.IsClosed(statesByType),
+					External: true,
+				}
+			}
+			addEdge(edge)
+		}
+	}
+
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.To < b.To
+	})
+
+	for _, e := range g.Edges {
+		if e.Kind == EdgeSuccessor && !g.Nodes[e.From].Closed {
+			g.Nodes[e.To].Blocked = true
+		}
+	}
+
+	return g
+}
+
+// nodeIDs returns the node IDs in ascending order
+func (g *DependencyGraph) nodeIDs() []int {
+	ids := make([]int, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// GraphLine is a line of the indented text rendering of a graph
+type GraphLine struct {
+	Prefix string   // Tree drawing up to and including the connector
+	Kind   EdgeKind // Link from the node this line hangs under
+	Root   bool
+	Node   *GraphNode
+	Repeat bool // Already shown above, so not expanded again
+}
+
+// Lines renders the graph as an indented DAG: every node without incoming
+// links starts a tree, children and successors are indented below it
+func (g *DependencyGraph) Lines() []GraphLine {
+	outgoing := make(map[int][]GraphEdge)
+	incoming := make(map[int]int)
+	for _, e := range g.Edges {
+		outgoing[e.From] = append(outgoing[e.From], e)
+		incoming[e.To]++
+	}
+
+	var lines []GraphLine
+	visited := make(map[int]bool)
+
+	var walk func(id int, prefix string)
+	walk = func(id int, prefix string) {
+		edges := outgoing[id]
+		for i, e := range edges {
+			last := i == len(edges)-1
+			connector, indent := "├", "│  "
+			if last {
+				connector, indent = "└", "   "
+			}
+			if e.Kind == EdgeSuccessor {
+				connector += "→ "
+			} else {
+				connector += "─ "
+			}
+
+			lines = append(lines, GraphLine{
+				Prefix: prefix + connector,
+				Kind:   e.Kind,
+				Node:   g.Nodes[e.To],
+				Repeat: visited[e.To],
+			})
+			if !visited[e.To] {
+				visited[e.To] = true
+				walk(e.To, prefix+indent)
+			}
+		}
+	}
+
+	root := func(id int) {
+		lines = append(lines, GraphLine{Root: true, Node: g.Nodes[id], Repeat: visited[id]})
+		if !visited[id] {
+			visited[id] = true
+			walk(id, "")
+		}
+	}
+
+	ids := g.nodeIDs()
+	for _, id := range ids {
+		if incoming[id] == 0 {
+			root(id)
+		}
+	}
+	// Nodes only reachable through cycles
+	for _, id := range ids {
+		if !visited[id] {
+			root(id)
+		}
+	}
+
+	return lines
+}
+
+// Text renders the graph as plain indented text
+func (g *DependencyGraph) Text() string {
+	var b strings.Builder
+	for _, line := range g.Lines() {
+		b.WriteString(line.Prefix + line.Node.Label())
+		if line.Node.State != "" {
+			b.WriteString(" [" + string(line.Node.State) + "]")
+		}
+		if line.Repeat {
+			b.WriteString(" (see above)")
+		} else if line.Node.Blocked {
+			b.WriteString(" BLOCKED")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n─ child   → successor   BLOCKED: waits on an unfinished predecessor\n")
+	return b.String()
+}
+
+// DOT renders the graph in Graphviz DOT format
+func (g *DependencyGraph) DOT() string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+
+	var b strings.Builder
+	b.WriteString("digraph workitems {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n\n")
+
+	for _, id := range g.nodeIDs() {
+		n := g.Nodes[id]
+		details := strings.TrimSpace(string(n.Type) + " " + string(n.State))
+		attrs := fmt.Sprintf(`label="%s\n%s"`, escape(n.Label()), escape(details))
+		switch {
+		case n.Blocked:
+			attrs += `, color="#EF4444", style="rounded,filled", fillcolor="#FEE2E2"`
+		case n.Closed:
+			attrs += `, color="#9CA3AF", fontcolor="#6B7280"`
+		}
+		if n.External {
+			attrs += `, peripheries=2`
+		}
+		fmt.Fprintf(&b, "  wi%d [%s];\n", id, attrs)
+	}
+
+	if len(g.Edges) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range g.Edges {
+		if e.Kind == EdgeChild {
+			fmt.Fprintf(&b, "  wi%d -> wi%d [style=dashed, arrowhead=none];\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  wi%d -> wi%d;\n", e.From, e.To)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart
+func (g *DependencyGraph) Mermaid() string {
+	escape := strings.NewReplacer(`"`, "#quot;").Replace
+
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+
+	var blocked, closed []string
+	for _, id := range g.nodeIDs() {
+		n := g.Nodes[id]
+		fmt.Fprintf(&b, "  wi%d[\"%s<br/>%s\"]\n", id, escape(n.Label()), escape(string(n.State)))
+		if n.Blocked {
+			blocked = append(blocked, fmt.Sprintf("wi%d", id))
+		} else if n.Closed {
+			closed = append(closed, fmt.Sprintf("wi%d", id))
+		}
+	}
+
+	for _, e := range g.Edges {
+		if e.Kind == EdgeChild {
+			fmt.Fprintf(&b, "  wi%d -.- wi%d\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  wi%d --> wi%d\n", e.From, e.To)
+		}
+	}
+
+	b.WriteString("  classDef blocked fill:#FEE2E2,stroke:#EF4444\n")
+	b.WriteString("  classDef closed fill:#F3F4F6,stroke:#9CA3AF,color:#6B7280\n")
+	if len(blocked) > 0 {
+		b.WriteString("  class " + strings.Join(blocked, ",") + " blocked\n")
+	}
+	if len(closed) > 0 {
+		b.WriteString("  class " + strings.Join(closed, ",") + " closed\n")
+	}
+
+	return b.String()
+}
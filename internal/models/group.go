@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GroupByFields lists the fields the work items list can be grouped by,
+// in the order they are cycled through
+var GroupByFields = []string{
+	FieldAssignedTo,
+	FieldState,
+	FieldType,
+	FieldParent,
+	FieldAreaPath,
+	FieldIterationPath,
+	FieldTags,
+}
+
+// GroupByLabel returns a display name for a group-by field
+func GroupByLabel(field string) string {
+	switch field {
+	case FieldAssignedTo:
+		return "Assignee"
+	case FieldState:
+		return "State"
+	case FieldType:
+		return "Type"
+	case FieldParent:
+		return "Parent"
+	case FieldAreaPath:
+		return "Area"
+	case FieldIterationPath:
+		return "Iteration"
+	case FieldTags:
+		return "Tag"
+	}
+	return field
+}
+
+// WorkItemGroup is a group of work items sharing a value of the group-by field
+type WorkItemGroup struct {
+	Key           string
+	Label         string
+	Items         []int // Indexes into the grouped slice, in slice order
+	StoryPoints   float64
+	RemainingWork float64
+
+	rank int // Sort rank used before the label (e.g. workflow order of states)
+	last bool
+}
+
+// groupKeys returns the group keys and labels of an item. Items can be in
+// several groups when grouping by tag.
+func groupKeys(item *WorkItem, field string) (keys, labels []string) {
+	switch field {
+	case FieldAssignedTo:
+		if item.AssignedTo == "" {
+			return []string{""}, []string{"Unassigned"}
+		}
+		return []string{item.AssignedTo}, []string{item.AssignedTo}
+	case FieldState:
+		return []string{string(item.State)}, []string{string(item.State)}
+	case FieldType:
+		return []string{string(item.Type)}, []string{string(item.Type)}
+	case FieldParent:
+		if item.ParentID == 0 {
+			return []string{""}, []string{"No parent"}
+		}
+		label := fmt.Sprintf("#%d", item.ParentID)
+		if item.ParentTitle != "" {
+			label += " " + item.ParentTitle
+		}
+		return []string{fmt.Sprintf("%d", item.ParentID)}, []string{label}
+	case FieldAreaPath:
+		return []string{item.AreaPath}, []string{item.AreaName()}
+	case FieldIterationPath:
+		return []string{item.IterationPath}, []string{item.SprintName()}
+	case FieldTags:
+		if len(item.Tags) == 0 {
+			return []string{""}, []string{"No tags"}
+		}
+		return item.Tags, item.Tags
+	}
+	value := item.FieldValue(field)
+	return []string{value}, []string{value}
+}
+
+// GroupWorkItems groups items by the given field. States are ordered by
+// workflow category, other groups by label with the empty group last.
+func GroupWorkItems(items []WorkItem, field string, statesByType map[string][]WorkItemStateInfo) []WorkItemGroup {
+	byKey := make(map[string]*WorkItemGroup)
+	var order []string
+
+	for i := range items {
+		item := &items[i]
+		keys, labels := groupKeys(item, field)
+		for k, key := range keys {
+			g, ok := byKey[key]
+			if !ok {
+				g = &WorkItemGroup{Key: key, Label: labels[k], last: key == ""}
+				if field == FieldState {
+					g.rank = stateRank(item, statesByType)
+				}
+				byKey[key] = g
+				order = append(order, key)
+			}
+			g.Items = append(g.Items, i)
+			g.StoryPoints += item.StoryPoints
+			g.RemainingWork += item.RemainingWork
+		}
+	}
+
+	groups := make([]WorkItemGroup, 0, len(order))
+	for _, key := range order {
+		groups = append(groups, *byKey[key])
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.last != b.last {
+			return b.last
+		}
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		return compareStrings(a.Label, b.Label) < 0
+	})
+
+	return groups
+}
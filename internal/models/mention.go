@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WorkItemComment is a comment in the discussion of a work item
+type WorkItemComment struct {
+	ID         int
+	WorkItemID int
+	Author     TeamMember
+	Text       string   // Plain text, without the HTML markup
+	MentionIDs []string // Identity IDs of the people mentioned
+	Created    time.Time
+}
+
+// Mentions reports whether the comment mentions an identity
+func (c WorkItemComment) Mentions(identityID string) bool {
+	for _, id := range c.MentionIDs {
+		if identityID != "" && strings.EqualFold(id, identityID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mention is a discussion comment that mentions me, with the work item it
+// was made on
+type Mention struct {
+	Item    WorkItem
+	Comment WorkItemComment
+}
+
+// SortMentions sorts mentions newest first
+func SortMentions(mentions []Mention) {
+	sort.SliceStable(mentions, func(i, j int) bool {
+		return mentions[i].Comment.Created.After(mentions[j].Comment.Created)
+	})
+}
+
+// MentionMarkup returns the HTML mentioning a person in a work item
+// discussion, which notifies them
+func MentionMarkup(person TeamMember) string {
+	return fmt.Sprintf(`<a href="#" data-vss-mention="version:2.0,%s">@%s</a>`,
+		html.EscapeString(person.ID), html.EscapeString(person.DisplayName))
+}
+
+// CommentHTML converts a plain text comment to HTML for a work item
+// discussion, turning "@Display Name" of each mentioned person into mention
+// markup
+func CommentHTML(text string, mentioned []TeamMember) string {
+	escaped := html.EscapeString(text)
+	escaped = replaceMentions(escaped, mentioned, func(p TeamMember) string {
+		return "@" + html.EscapeString(p.DisplayName)
+	}, MentionMarkup)
+	return strings.ReplaceAll(escaped, "\n", "<br>")
+}
+
+// CommentMarkdown turns "@Display Name" of each mentioned person into the
+// "@<id>" mention syntax of pull request comments
+func CommentMarkdown(text string, mentioned []TeamMember) string {
+	return replaceMentions(text, mentioned, func(p TeamMember) string {
+		return "@" + p.DisplayName
+	}, func(p TeamMember) string {
+		return "@<" + p.ID + ">"
+	})
+}
+
+// replaceMentions replaces the written form of each mention with its
+// markup. Longer names go first, so "@Ann Lee" wins over "@Ann".
+func replaceMentions(text string, mentioned []TeamMember, written, markup func(TeamMember) string) string {
+	people := append([]TeamMember(nil), mentioned...)
+	sort.SliceStable(people, func(i, j int) bool {
+		return len(people[i].DisplayName) > len(people[j].DisplayName)
+	})
+
+	// Placeholders keep a replaced mention from matching a shorter name
+	var replacements []string
+	for _, p := range people {
+		if p.ID == "" || p.DisplayName == "" {
+			continue
+		}
+		placeholder := fmt.Sprintf("\x00%d\x00", len(replacements))
+		if strings.Contains(text, written(p)) {
+			text = strings.ReplaceAll(text, written(p), placeholder)
+			replacements = append(replacements, markup(p))
+		}
+	}
+	for i, r := range replacements {
+		text = strings.ReplaceAll(text, fmt.Sprintf("\x00%d\x00", i), r)
+	}
+	return text
+}
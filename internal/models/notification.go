@@ -0,0 +1,111 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// NotificationReason is why a change to a work item concerns me
+type NotificationReason string
+
+const (
+	NotifyAssigned  NotificationReason = "assigned to you"
+	NotifyCreated   NotificationReason = "created by you"
+	NotifyMentioned NotificationReason = "mentions you"
+)
+
+// maxNotifications caps the inbox; the oldest notifications are dropped
+const maxNotifications = 100
+
+// Notification is a change someone else made to a work item that is
+// assigned to me, created by me or mentions me
+type Notification struct {
+	Item      WorkItem
+	ChangedBy string
+	Reason    NotificationReason
+	Read      bool
+}
+
+// NewNotification describes a changed item for the account of the current
+// user. The item needs the created by and changed by fields.
+func NewNotification(item WorkItem, account string) Notification {
+	n := Notification{Item: item, Reason: NotifyMentioned}
+	switch {
+	case account != "" && strings.EqualFold(item.AssignedToAccount(), account):
+		n.Reason = NotifyAssigned
+	case account != "" && strings.EqualFold(item.IdentityAccount(FieldCreatedBy), account):
+		n.Reason = NotifyCreated
+	}
+	if by := item.FieldValue(FieldChangedBy); by != "-" {
+		n.ChangedBy = by
+	}
+	return n
+}
+
+// Inbox holds the notifications of the session, one per work item, most
+// recently changed first
+type Inbox struct {
+	Notifications []Notification
+}
+
+// Add adds notifications for changed items. An item already in the inbox
+// is replaced by its newer change and becomes unread again; changes that
+// aren't newer than the one shown are ignored. Returns how many
+// notifications were added or renewed.
+func (i *Inbox) Add(notifications []Notification) int {
+	added := 0
+	for _, n := range notifications {
+		n.Read = false
+		replaced := false
+		for j, existing := range i.Notifications {
+			if existing.Item.ID != n.Item.ID {
+				continue
+			}
+			replaced = true
+			if n.Item.Rev > existing.Item.Rev {
+				i.Notifications[j] = n
+				added++
+			}
+			break
+		}
+		if !replaced {
+			i.Notifications = append(i.Notifications, n)
+			added++
+		}
+	}
+
+	sort.SliceStable(i.Notifications, func(a, b int) bool {
+		return i.Notifications[a].Item.ChangedDate.After(i.Notifications[b].Item.ChangedDate)
+	})
+	if len(i.Notifications) > maxNotifications {
+		i.Notifications = i.Notifications[:maxNotifications]
+	}
+	return added
+}
+
+// Unread returns the number of unread notifications
+func (i *Inbox) Unread() int {
+	count := 0
+	for _, n := range i.Notifications {
+		if !n.Read {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkRead marks the notification of a work item read
+func (i *Inbox) MarkRead(id int) {
+	for j := range i.Notifications {
+		if i.Notifications[j].Item.ID == id {
+			i.Notifications[j].Read = true
+		}
+	}
+}
+
+// MarkAllRead marks every notification read
+func (i *Inbox) MarkAllRead() {
+	for j := range i.Notifications {
+		i.Notifications[j].Read = true
+	}
+}
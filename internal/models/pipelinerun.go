@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// PipelineParameter is a runtime parameter of a YAML pipeline
+type PipelineParameter struct {
+	Name        string
+	DisplayName string
+	Type        string // string, number or boolean
+	Default     string
+	Values      []string // Allowed values, empty for any
+}
+
+// Label returns the display name of the parameter, or its name
+func (p *PipelineParameter) Label() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Name
+}
+
+// PipelineVariable is a variable of a pipeline that can be set when queuing
+type PipelineVariable struct {
+	Name   string
+	Value  string // Empty for secrets
+	Secret bool
+}
+
+// NewPipelineRun is a pipeline run to be queued
+type NewPipelineRun struct {
+	DefinitionID int
+	Branch       string            // Without the refs/heads/ prefix
+	Parameters   map[string]string // Runtime parameters by name
+	Variables    map[string]string // Only variables that differ from the pipeline's
+}
+
+// ParsePipelineParameters reads the runtime parameters of a YAML pipeline.
+// Parameters of types that can't be typed in (objects, steps, jobs and
+// stages) are left out; they keep their defaults.
+func ParsePipelineParameters(content []byte) ([]PipelineParameter, error) {
+	var doc struct {
+		Parameters yaml.Node `yaml:"parameters"`
+	}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("parsing pipeline: %w", err)
+	}
+
+	var params []PipelineParameter
+	switch doc.Parameters.Kind {
+	case yaml.SequenceNode:
+		for _, node := range doc.Parameters.Content {
+			var p struct {
+				Name        string    `yaml:"name"`
+				DisplayName string    `yaml:"displayName"`
+				Type        string    `yaml:"type"`
+				Default     yaml.Node `yaml:"default"`
+				Values      []string  `yaml:"values"`
+			}
+			if err := node.Decode(&p); err != nil {
+				return nil, fmt.Errorf("parsing pipeline parameters: %w", err)
+			}
+			if p.Type == "" {
+				p.Type = "string"
+			}
+			if p.Name == "" || !isScalarParameterType(p.Type) {
+				continue
+			}
+			params = append(params, PipelineParameter{
+				Name:        p.Name,
+				DisplayName: p.DisplayName,
+				Type:        strings.ToLower(p.Type),
+				Default:     scalarValue(&p.Default),
+				Values:      p.Values,
+			})
+		}
+
+	// Older "name: default" form
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(doc.Parameters.Content); i += 2 {
+			value := doc.Parameters.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				continue
+			}
+			params = append(params, PipelineParameter{
+				Name:    doc.Parameters.Content[i].Value,
+				Type:    "string",
+				Default: value.Value,
+			})
+		}
+	}
+	return params, nil
+}
+
+func isScalarParameterType(t string) bool {
+	switch strings.ToLower(t) {
+	case "string", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// scalarValue returns the value of a scalar YAML node, "" for anything else
+func scalarValue(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	return ""
+}
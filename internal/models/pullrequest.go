@@ -0,0 +1,98 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Reviewer votes on a pull request
+const (
+	VoteApproved                = 10
+	VoteApprovedWithSuggestions = 5
+	VoteNone                    = 0
+	VoteWaitingForAuthor        = -5
+	VoteRejected                = -10
+)
+
+// Reviewer is a reviewer of a pull request
+type Reviewer struct {
+	ID       string // Identity ID
+	Name     string
+	Account  string // Unique name
+	Vote     int
+	Required bool
+	IsGroup  bool // A team or group rather than a person
+}
+
+// VoteLabel returns the display name of the reviewer's vote
+func (r *Reviewer) VoteLabel() string {
+	switch {
+	case r.Vote >= VoteApproved:
+		return "Approved"
+	case r.Vote >= VoteApprovedWithSuggestions:
+		return "Approved with suggestions"
+	case r.Vote <= VoteRejected:
+		return "Rejected"
+	case r.Vote <= VoteWaitingForAuthor:
+		return "Waiting for author"
+	}
+	return "No vote"
+}
+
+// VoteSymbol returns a one character symbol for the reviewer's vote
+func (r *Reviewer) VoteSymbol() string {
+	switch {
+	case r.Vote >= VoteApprovedWithSuggestions:
+		return "✓"
+	case r.Vote <= VoteRejected:
+		return "✗"
+	case r.Vote <= VoteWaitingForAuthor:
+		return "…"
+	}
+	return "·"
+}
+
+// PullRequest is a Git pull request
+type PullRequest struct {
+	ID           int
+	Title        string
+	Description  string
+	Status       string // active, completed or abandoned
+	IsDraft      bool
+	CreatedBy    string
+	CreatorID    string // Account (unique name) of the author
+	Created      time.Time
+	Repository   string
+	RepositoryID string
+	ProjectID    string
+	SourceBranch string // Without the refs/heads/ prefix
+	TargetBranch string
+	Reviewers    []Reviewer
+	WebURL       string
+}
+
+// StatusLabel returns the display status, e.g. "Active" or "Draft"
+func (p *PullRequest) StatusLabel() string {
+	if p.IsDraft && p.Status == "active" {
+		return "Draft"
+	}
+	if p.Status == "" {
+		return ""
+	}
+	return strings.ToUpper(p.Status[:1]) + p.Status[1:]
+}
+
+// BranchName strips the refs/heads/ prefix of a Git ref name
+func BranchName(ref string) string {
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+// NewPullRequest is a pull request to be created
+type NewPullRequest struct {
+	SourceBranch string // Without the refs/heads/ prefix
+	TargetBranch string
+	Title        string
+	Description  string
+	WorkItemIDs  []int
+	ReviewerIDs  []string // Identity IDs
+}
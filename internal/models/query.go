@@ -0,0 +1,154 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Special values for the assigned filter
+const (
+	AssignedMe   = "me"
+	AssignedNone = "unassigned"
+)
+
+// WorkItemQuery describes the conditions used when querying work items.
+// An empty slice means the dimension is not restricted.
+type WorkItemQuery struct {
+	Sprint     string   // Iteration path, or "all"
+	States     []string // System.State IN (...)
+	Types      []string // System.WorkItemType IN (...)
+	Assigned   []string // "me", "unassigned" or unique names
+	Areas      []string // Area paths, matched with UNDER
+	Tags       []string // Matches items having any of the tags
+	Priorities []string // Microsoft.VSTS.Common.Priority IN (...)
+	Dates      []DateCondition
+}
+
+// Date fields that can be filtered on
+const (
+	DateFieldChanged = "changed"
+	DateFieldCreated = "created"
+)
+
+// DateCondition restricts a date field of a work item.
+// Exactly one of SinceDays, OlderThanDays or the From/To range is used.
+type DateCondition struct {
+	Field         string    // DateFieldChanged or DateFieldCreated
+	SinceDays     int       // Field >= @today - SinceDays
+	OlderThanDays int       // Field < @today - OlderThanDays
+	From          time.Time // Range start (inclusive), zero if open
+	To            time.Time // Range end (inclusive), zero if open
+}
+
+// IsRange returns true if the condition is a custom date range
+func (d DateCondition) IsRange() bool {
+	return !d.From.IsZero() || !d.To.IsZero()
+}
+
+// FieldRef returns the WIQL reference name of the condition's field
+func (d DateCondition) FieldRef() string {
+	if d.Field == DateFieldCreated {
+		return "System.CreatedDate"
+	}
+	return "System.ChangedDate"
+}
+
+// Value encodes the condition as a filter option value, e.g. "changed:since:7"
+// or "created:range:2024-01-01:2024-01-31"
+func (d DateCondition) Value() string {
+	switch {
+	case d.IsRange():
+		return fmt.Sprintf("%s:range:%s:%s", d.Field, formatDate(d.From), formatDate(d.To))
+	case d.OlderThanDays > 0:
+		return fmt.Sprintf("%s:older:%d", d.Field, d.OlderThanDays)
+	default:
+		return fmt.Sprintf("%s:since:%d", d.Field, d.SinceDays)
+	}
+}
+
+// Label returns a human readable description of the condition
+func (d DateCondition) Label() string {
+	field := "Changed"
+	if d.Field == DateFieldCreated {
+		field = "Created"
+	}
+	switch {
+	case d.IsRange():
+		from, to := formatDate(d.From), formatDate(d.To)
+		if from == "" {
+			from = "…"
+		}
+		if to == "" {
+			to = "…"
+		}
+		return fmt.Sprintf("%s %s – %s", field, from, to)
+	case d.OlderThanDays > 0:
+		return fmt.Sprintf("%s over %d days ago", field, d.OlderThanDays)
+	case d.SinceDays == 1:
+		return field + " since yesterday"
+	default:
+		return fmt.Sprintf("%s last %d days", field, d.SinceDays)
+	}
+}
+
+// ParseDateCondition parses a value produced by DateCondition.Value
+func ParseDateCondition(value string) (DateCondition, bool) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 3 {
+		return DateCondition{}, false
+	}
+
+	cond := DateCondition{Field: parts[0]}
+	if cond.Field != DateFieldChanged && cond.Field != DateFieldCreated {
+		return DateCondition{}, false
+	}
+
+	switch parts[1] {
+	case "since", "older":
+		days, err := strconv.Atoi(parts[2])
+		if err != nil || days <= 0 {
+			return DateCondition{}, false
+		}
+		if parts[1] == "since" {
+			cond.SinceDays = days
+		} else {
+			cond.OlderThanDays = days
+		}
+	case "range":
+		if len(parts) != 4 {
+			return DateCondition{}, false
+		}
+		var err error
+		if cond.From, err = parseDate(parts[2]); err != nil {
+			return DateCondition{}, false
+		}
+		if cond.To, err = parseDate(parts[3]); err != nil {
+			return DateCondition{}, false
+		}
+		if !cond.IsRange() {
+			return DateCondition{}, false
+		}
+	default:
+		return DateCondition{}, false
+	}
+
+	return cond, true
+}
+
+// formatDate formats a date as YYYY-MM-DD, or "" for the zero time
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// parseDate parses a YYYY-MM-DD date, treating "" as the zero time
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Relation type reference names
+const (
+	RelHierarchyForward  = "System.LinkTypes.Hierarchy-Forward"
+	RelHierarchyReverse  = "System.LinkTypes.Hierarchy-Reverse"
+	RelRelated           = "System.LinkTypes.Related"
+	RelDependencyForward = "System.LinkTypes.Dependency-Forward"
+	RelDependencyReverse = "System.LinkTypes.Dependency-Reverse"
+	RelDuplicateForward  = "System.LinkTypes.Duplicate-Forward"
+	RelDuplicateReverse  = "System.LinkTypes.Duplicate-Reverse"
+	RelArtifactLink      = "ArtifactLink"
+	RelHyperlink         = "Hyperlink"
+	RelAttachedFile      = "AttachedFile"
+)
+
+// WorkItemRelation is a link from a work item to another work item,
+// an artifact (commit, pull request, build, branch) or a URL
+type WorkItemRelation struct {
+	Rel     string `json:"rel"`
+	URL     string `json:"url"`
+	Name    string `json:"name"` // Link name from the API, e.g. "Pull Request"
+	Comment string `json:"comment"`
+
+	// Target details, set for links to other work items
+	TargetID    int           `json:"targetId"`
+	TargetTitle string        `json:"targetTitle"`
+	TargetState WorkItemState `json:"targetState"`
+	TargetType  WorkItemType  `json:"targetType"`
+}
+
+// relationOrder is the display order of the relation groups
+var relationOrder = []string{
+	RelHierarchyReverse,
+	RelHierarchyForward,
+	RelRelated,
+	RelDependencyReverse,
+	RelDependencyForward,
+	RelDuplicateForward,
+	RelDuplicateReverse,
+	RelArtifactLink,
+	RelHyperlink,
+	RelAttachedFile,
+}
+
+// IsWorkItem reports whether the relation points at another work item
+func (r *WorkItemRelation) IsWorkItem() bool {
+	return r.TargetID > 0
+}
+
+// TypeLabel returns the display name of the link type. Artifact links are
+// labeled by artifact kind so commits, pull requests and builds group apart.
+func (r *WorkItemRelation) TypeLabel() string {
+	switch r.Rel {
+	case RelHierarchyForward:
+		return "Child"
+	case RelHierarchyReverse:
+		return "Parent"
+	case RelRelated:
+		return "Related"
+	case RelDependencyForward:
+		return "Successor"
+	case RelDependencyReverse:
+		return "Predecessor"
+	case RelDuplicateForward:
+		return "Duplicate"
+	case RelDuplicateReverse:
+		return "Duplicate Of"
+	case RelHyperlink:
+		return "Hyperlink"
+	case RelAttachedFile:
+		return "Attachment"
+	case RelArtifactLink:
+		if r.Name != "" {
+			return r.Name
+		}
+		return "Artifact"
+	}
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Rel
+}
+
+// Label returns a one line description of the link target
+func (r *WorkItemRelation) Label() string {
+	if r.IsWorkItem() {
+		label := "#" + strconv.Itoa(r.TargetID)
+		if r.TargetTitle != "" {
+			label += " " + r.TargetTitle
+		}
+		return label
+	}
+	if ref, ok := ParseArtifactURL(r.URL); ok {
+		return ref.String()
+	}
+	return r.URL
+}
+
+// WorkItemIDFromURL extracts the work item ID from a work item API URL,
+// e.g. ".../_apis/wit/workItems/123", returning 0 for other URLs
+func WorkItemIDFromURL(u string) int {
+	i := strings.LastIndex(strings.ToLower(u), "/workitems/")
+	if i < 0 {
+		return 0
+	}
+	id, err := strconv.Atoi(u[i+len("/workitems/"):])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// RelationGroup is a set of relations of the same link type
+type RelationGroup struct {
+	Label     string
+	Relations []WorkItemRelation
+}
+
+// GroupRelations groups relations by link type in a fixed display order.
+// Work item links within a group are ordered by ID.
+func GroupRelations(relations []WorkItemRelation) []RelationGroup {
+	rank := func(rel string) int {
+		for i, r := range relationOrder {
+			if r == rel {
+				return i
+			}
+		}
+		return len(relationOrder)
+	}
+
+	sorted := append([]WorkItemRelation{}, relations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := &sorted[i], &sorted[j]
+		if ra, rb := rank(a.Rel), rank(b.Rel); ra != rb {
+			return ra < rb
+		}
+		if la, lb := a.TypeLabel(), b.TypeLabel(); la != lb {
+			return la < lb
+		}
+		return a.TargetID < b.TargetID
+	})
+
+	var groups []RelationGroup
+	for _, r := range sorted {
+		label := r.TypeLabel()
+		if len(groups) == 0 || groups[len(groups)-1].Label != label {
+			groups = append(groups, RelationGroup{Label: label})
+		}
+		g := &groups[len(groups)-1]
+		g.Relations = append(g.Relations, r)
+	}
+	return groups
+}
@@ -0,0 +1,11 @@
+package models
+
+// Repository is a Git repository in Azure Repos
+type Repository struct {
+	ID            string
+	Name          string
+	ProjectID     string
+	Project       string
+	DefaultBranch string // Without the refs/heads/ prefix
+	WebURL        string
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Check statuses, normalized across branch policies and status checks
+const (
+	CheckSucceeded     = "succeeded"
+	CheckFailed        = "failed"
+	CheckPending       = "pending"
+	CheckNotApplicable = "notApplicable"
+)
+
+// PullRequestCheck is a branch policy or a status posted to a pull request
+type PullRequestCheck struct {
+	Name     string
+	Status   string // One of the Check* statuses
+	Blocking bool   // Must succeed before the pull request can complete
+}
+
+// Symbol returns a one character symbol for the check's status
+func (c *PullRequestCheck) Symbol() string {
+	switch c.Status {
+	case CheckSucceeded:
+		return "✓"
+	case CheckFailed:
+		return "✗"
+	case CheckPending:
+		return "●"
+	}
+	return "·"
+}
+
+// PullRequestChange is a file changed by a pull request
+type PullRequestChange struct {
+	Path             string
+	OriginalPath     string // Path before a rename
+	ChangeType       string // add, edit, delete or rename, possibly combined ("edit, rename")
+	ChangeTrackingID int    // Identifies the file across iterations, for new comment threads
+}
+
+// IsAdded reports whether the file is new, so it has no old version
+func (c *PullRequestChange) IsAdded() bool {
+	return strings.Contains(c.ChangeType, "add")
+}
+
+// IsDeleted reports whether the file was deleted, so it has no new version
+func (c *PullRequestChange) IsDeleted() bool {
+	return strings.Contains(c.ChangeType, "delete")
+}
+
+// OldPath returns the path of the file before the pull request
+func (c *PullRequestChange) OldPath() string {
+	if c.OriginalPath != "" {
+		return c.OriginalPath
+	}
+	return c.Path
+}
+
+// Label returns the change as "M path", "R old → new", ...
+func (c *PullRequestChange) Label() string {
+	kind := "M"
+	switch {
+	case c.IsAdded():
+		kind = "A"
+	case c.IsDeleted():
+		kind = "D"
+	case strings.Contains(c.ChangeType, "rename"):
+		kind = "R"
+	}
+	if c.OriginalPath != "" && c.OriginalPath != c.Path {
+		return fmt.Sprintf("%s %s → %s", kind, c.OriginalPath, c.Path)
+	}
+	return kind + " " + c.Path
+}
+
+// PullRequestIteration is a push to a pull request's source branch
+type PullRequestIteration struct {
+	ID           int    // 1 for the first push, 0 when unknown
+	SourceCommit string // Head of the source branch
+	BaseCommit   string // Merge base with the target branch, what the changes compare to
+}
+
+// PullRequestReview is what reviewing a pull request needs besides the pull
+// request itself
+type PullRequestReview struct {
+	Checks    []PullRequestCheck
+	Iteration PullRequestIteration // Latest push
+	Changes   []PullRequestChange
+}
+
+// Comment thread statuses
+const (
+	ThreadActive   = "active"
+	ThreadFixed    = "fixed"
+	ThreadWontFix  = "wontFix"
+	ThreadClosed   = "closed"
+	ThreadByDesign = "byDesign"
+	ThreadPending  = "pending"
+)
+
+// CommentThread is a discussion on a pull request, or on a line of one of
+// its files
+type CommentThread struct {
+	ID       int
+	Status   string // One of the Thread* statuses, "" for none
+	Path     string // "" for the pull request as a whole
+	Line     int    // Line the thread is on, 0 for the whole file
+	LeftSide bool   // Line is in the old version of the file
+	Comments []ThreadComment
+}
+
+// NewCommentThread is a comment thread to be started
+type NewCommentThread struct {
+	Text string
+
+	// Line of a changed file the thread is on; no path for the pull
+	// request as a whole
+	Path             string
+	Line             int
+	LeftSide         bool
+	ChangeTrackingID int
+	Iteration        int // Latest iteration, which the line is in
+}
+
+// ThreadComment is a comment in a thread; replies follow the comment they
+// reply to
+type ThreadComment struct {
+	ID        int
+	ParentID  int // 0 for the first comment of the thread
+	Author    string
+	Content   string
+	Published time.Time
+}
+
+// IsResolved reports whether the thread's discussion is over
+func (t *CommentThread) IsResolved() bool {
+	switch t.Status {
+	case ThreadFixed, ThreadWontFix, ThreadClosed, ThreadByDesign:
+		return true
+	}
+	return false
+}
+
+// StatusLabel returns the display status, e.g. "Active" or "Resolved"
+func (t *CommentThread) StatusLabel() string {
+	switch t.Status {
+	case ThreadFixed:
+		return "Resolved"
+	case ThreadWontFix:
+		return "Won't fix"
+	case "":
+		return ""
+	}
+	return splitCamel(t.Status)
+}
+
+// LastCommentID returns the ID of the last comment, the one a reply
+// follows
+func (t *CommentThread) LastCommentID() int {
+	if len(t.Comments) == 0 {
+		return 0
+	}
+	return t.Comments[len(t.Comments)-1].ID
+}
+
+// Reviewer returns the reviewer with an identity ID, or nil
+func (p *PullRequest) Reviewer(id string) *Reviewer {
+	for i := range p.Reviewers {
+		if strings.EqualFold(p.Reviewers[i].ID, id) {
+			return &p.Reviewers[i]
+		}
+	}
+	return nil
+}
+
+// HasReviewer reports whether any of the identities reviews the pull request
+func (p *PullRequest) HasReviewer(ids ...string) bool {
+	for _, id := range ids {
+		if id != "" && p.Reviewer(id) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// VoteSummary returns the reviewer votes as symbols with counts, e.g. "✓2 ✗1"
+func (p *PullRequest) VoteSummary() string {
+	var approved, waiting, rejected int
+	for _, r := range p.Reviewers {
+		switch {
+		case r.Vote >= VoteApprovedWithSuggestions:
+			approved++
+		case r.Vote <= VoteRejected:
+			rejected++
+		case r.Vote <= VoteWaitingForAuthor:
+			waiting++
+		}
+	}
+
+	var parts []string
+	if approved > 0 {
+		parts = append(parts, fmt.Sprintf("✓%d", approved))
+	}
+	if waiting > 0 {
+		parts = append(parts, fmt.Sprintf("…%d", waiting))
+	}
+	if rejected > 0 {
+		parts = append(parts, fmt.Sprintf("✗%d", rejected))
+	}
+	return strings.Join(parts, " ")
+}
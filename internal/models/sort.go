@@ -0,0 +1,118 @@
+package models
+
+import (
+	"strings"
+)
+
+// SortKey is one key of a (multi-key) work item sort
+type SortKey struct {
+	Field string `json:"field"`          // Field reference name
+	Desc  bool   `json:"desc,omitempty"` // Descending order
+}
+
+// stateCategoryOrder is the workflow order of state categories
+var stateCategoryOrder = map[string]int{
+	"Proposed":   0,
+	"InProgress": 1,
+	"Resolved":   2,
+	"Completed":  3,
+	"Removed":    4,
+}
+
+// stateRank returns the workflow position of the item's state.
+// Unknown categories sort after the known ones.
+func stateRank(item *WorkItem, statesByType map[string][]WorkItemStateInfo) int {
+	if rank, ok := stateCategoryOrder[item.StateCategory(statesByType)]; ok {
+		return rank
+	}
+	return len(stateCategoryOrder)
+}
+
+// CompareWorkItems compares two work items by a single field, returning
+// -1, 0 or 1. States compare by workflow category before name.
+func CompareWorkItems(a, b *WorkItem, field string, statesByType map[string][]WorkItemStateInfo) int {
+	switch field {
+	case FieldID:
+		return compareInts(a.ID, b.ID)
+	case FieldState:
+		if c := compareInts(stateRank(a, statesByType), stateRank(b, statesByType)); c != 0 {
+			return c
+		}
+		return compareStrings(string(a.State), string(b.State))
+	case FieldType:
+		return compareStrings(string(a.Type), string(b.Type))
+	case FieldTitle:
+		return compareStrings(a.Title, b.Title)
+	case FieldAssignedTo:
+		return compareStrings(a.AssignedTo, b.AssignedTo)
+	case FieldIterationPath:
+		return compareStrings(a.IterationPath, b.IterationPath)
+	case FieldAreaPath:
+		return compareStrings(a.AreaPath, b.AreaPath)
+	case FieldTags:
+		return compareStrings(strings.Join(a.Tags, ";"), strings.Join(b.Tags, ";"))
+	case FieldParent:
+		return compareInts(a.ParentID, b.ParentID)
+	case FieldPriority:
+		return compareInts(a.Priority, b.Priority)
+	case FieldStoryPoints:
+		return compareFloats(a.StoryPoints, b.StoryPoints)
+	case FieldRemainingWork:
+		return compareFloats(a.RemainingWork, b.RemainingWork)
+	case FieldCreatedDate:
+		return compareInts64(a.CreatedDate.Unix(), b.CreatedDate.Unix())
+	case FieldChangedDate:
+		return compareInts64(a.ChangedDate.Unix(), b.ChangedDate.Unix())
+	}
+
+	// Custom fields: compare numerically when both values are numbers
+	av, aNum := a.Fields[field].(float64)
+	bv, bNum := b.Fields[field].(float64)
+	if aNum && bNum {
+		return compareFloats(av, bv)
+	}
+	return compareStrings(a.FieldValue(field), b.FieldValue(field))
+}
+
+// SortWorkItemsLess reports whether a sorts before b using the given keys
+func SortWorkItemsLess(a, b *WorkItem, keys []SortKey, statesByType map[string][]WorkItemStateInfo) bool {
+	for _, key := range keys {
+		c := CompareWorkItems(a, b, key.Field, statesByType)
+		if c == 0 {
+			continue
+		}
+		if key.Desc {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
+
+func compareInts(a, b int) int {
+	return compareInts64(int64(a), int64(b))
+}
+
+func compareInts64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareStrings(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -1,8 +1,23 @@
 package models
 
+import "strings"
+
 // TeamMember represents a team member
 type TeamMember struct {
 	ID          string `json:"id"`
 	DisplayName string `json:"displayName"`
 	UniqueName  string `json:"uniqueName"`
 }
+
+// Alias returns the account name without domain, e.g. "alice" for
+// alice@example.com or DOMAIN\alice
+func (m TeamMember) Alias() string {
+	alias := m.UniqueName
+	if i := strings.Index(alias, "@"); i >= 0 {
+		alias = alias[:i]
+	}
+	if i := strings.LastIndex(alias, `\`); i >= 0 {
+		alias = alias[i+1:]
+	}
+	return strings.ToLower(alias)
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineRecord is a stage, job or step of a pipeline run
+type TimelineRecord struct {
+	ID         string
+	ParentID   string
+	Type       string // Stage, Phase, Job, Task or Checkpoint
+	Name       string
+	Identifier string // Stage name in YAML, e.g. "Deploy"
+	State      string // pending, inProgress or completed
+	Result     string // succeeded, succeededWithIssues, failed, canceled, skipped or abandoned once completed
+	Order      int
+	Attempt    int
+	StartTime  time.Time
+	FinishTime time.Time
+	LogID      int // 0 when the record has no log (yet)
+	Errors     int
+	Warnings   int
+}
+
+// IsRunning reports whether the record hasn't completed
+func (r *TimelineRecord) IsRunning() bool {
+	return r.State != "completed"
+}
+
+// Duration returns how long the record ran, or has been running; 0 when it
+// hasn't started
+func (r *TimelineRecord) Duration(now time.Time) time.Duration {
+	switch {
+	case r.StartTime.IsZero():
+		return 0
+	case r.FinishTime.IsZero():
+		return now.Sub(r.StartTime)
+	default:
+		return r.FinishTime.Sub(r.StartTime)
+	}
+}
+
+// TimelineNode is a record placed in the stage > job > step tree
+type TimelineNode struct {
+	Record TimelineRecord
+	Depth  int
+}
+
+// BuildTimelineTree orders the records of a run depth first, stages before
+// their jobs and jobs before their steps. Phases are left out; their jobs
+// take their place, as in the web UI.
+func BuildTimelineTree(records []TimelineRecord) []TimelineNode {
+	byID := make(map[string]bool, len(records))
+	for _, r := range records {
+		byID[r.ID] = true
+	}
+
+	children := make(map[string][]TimelineRecord)
+	for _, r := range records {
+		parent := r.ParentID
+		if !byID[parent] {
+			parent = ""
+		}
+		children[parent] = append(children[parent], r)
+	}
+	for _, list := range children {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Order != list[j].Order {
+				return list[i].Order < list[j].Order
+			}
+			return list[i].StartTime.Before(list[j].StartTime)
+		})
+	}
+
+	var nodes []TimelineNode
+	var walk func(parent string, depth int)
+	walk = func(parent string, depth int) {
+		for _, r := range children[parent] {
+			if strings.EqualFold(r.Type, "Phase") {
+				walk(r.ID, depth)
+				continue
+			}
+			nodes = append(nodes, TimelineNode{Record: r, Depth: depth})
+			walk(r.ID, depth+1)
+		}
+	}
+	walk("", 0)
+	return nodes
+}
+
+// CanRetry reports whether the record is a stage that failed or was canceled
+func (r *TimelineRecord) CanRetry() bool {
+	if !strings.EqualFold(r.Type, "Stage") || r.Identifier == "" || r.IsRunning() {
+		return false
+	}
+	switch r.Result {
+	case "failed", "canceled", "abandoned":
+		return true
+	}
+	return false
+}
+
+// RetryableStages returns the identifiers of the stages of a run that failed
+// or were canceled
+func RetryableStages(records []TimelineRecord) []string {
+	var stages []string
+	for _, r := range BuildTimelineTree(records) {
+		if r.Record.CanRetry() {
+			stages = append(stages, r.Record.Identifier)
+		}
+	}
+	return stages
+}
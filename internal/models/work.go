@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// State categories items move to when work on them starts and finishes
+const (
+	StateCategoryInProgress = "InProgress"
+	StateCategoryResolved   = "Resolved"
+	StateCategoryCompleted  = "Completed"
+)
+
+// WorkflowState returns the state an item of a type moves to: the state
+// configured for the type (keys are lowercased type names, "default"
+// applies to all types), or else the first of the type's states in one of
+// the given categories. fallback is used when the type's states are unknown.
+func WorkflowState(t WorkItemType, configured map[string]string, statesByType map[string][]WorkItemStateInfo, fallback string, categories ...string) string {
+	if state := configured[strings.ToLower(string(t))]; state != "" {
+		return state
+	}
+	if state := configured["default"]; state != "" {
+		return state
+	}
+
+	for _, category := range categories {
+		for _, s := range statesByType[string(t)] {
+			if s.Category == category {
+				return s.Name
+			}
+		}
+	}
+	return fallback
+}
+
+// WorkStepKind is a change made when starting or finishing work
+type WorkStepKind int
+
+const (
+	WorkStepAssign WorkStepKind = iota
+	WorkStepState
+	WorkStepIteration
+	WorkStepBranch
+	WorkStepPullRequest
+)
+
+// WorkStep is one change of a work plan. From is what it replaces, so the
+// step can be undone.
+type WorkStep struct {
+	Kind    WorkStepKind
+	Value   string // Assignee account, state, iteration path or branch name
+	Label   string // Value as shown, e.g. the assignee's display name
+	From    string
+	Enabled bool
+}
+
+// Description describes the step for the preview
+func (s WorkStep) Description() string {
+	label := s.Label
+	if label == "" {
+		label = s.Value
+	}
+
+	switch s.Kind {
+	case WorkStepAssign:
+		return "Assign to " + label
+	case WorkStepState:
+		return fmt.Sprintf("Set state %s → %s", s.From, label)
+	case WorkStepIteration:
+		return "Move to " + label
+	case WorkStepBranch:
+		return label
+	case WorkStepPullRequest:
+		return "Create a pull request"
+	}
+	return label
+}
+
+// WorkPlan lists the changes of starting or finishing work on an item, each
+// of which can be switched off in the preview
+type WorkPlan struct {
+	Item   WorkItem
+	Finish bool
+	Steps  []WorkStep
+}
+
+// Title names the action
+func (p WorkPlan) Title() string {
+	if p.Finish {
+		return "Finish Work"
+	}
+	return "Start Work"
+}
+
+// Step returns the enabled step of a kind, if any
+func (p WorkPlan) Step(kind WorkStepKind) (WorkStep, bool) {
+	for _, s := range p.Steps {
+		if s.Kind == kind && s.Enabled {
+			return s, true
+		}
+	}
+	return WorkStep{}, false
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // WorkItemType represents the type of work item
 type WorkItemType string
@@ -23,6 +28,27 @@ const (
 	WorkItemStateClosed   WorkItemState = "Closed"
 )
 
+// Field reference names used by the work item model
+const (
+	FieldID            = "System.Id"
+	FieldTitle         = "System.Title"
+	FieldState         = "System.State"
+	FieldType          = "System.WorkItemType"
+	FieldAssignedTo    = "System.AssignedTo"
+	FieldIterationPath = "System.IterationPath"
+	FieldAreaPath      = "System.AreaPath"
+	FieldDescription   = "System.Description"
+	FieldTags          = "System.Tags"
+	FieldParent        = "System.Parent"
+	FieldPriority      = "Microsoft.VSTS.Common.Priority"
+	FieldStoryPoints   = "Microsoft.VSTS.Scheduling.StoryPoints"
+	FieldRemainingWork = "Microsoft.VSTS.Scheduling.RemainingWork"
+	FieldCreatedDate   = "System.CreatedDate"
+	FieldChangedDate   = "System.ChangedDate"
+	FieldCreatedBy     = "System.CreatedBy"
+	FieldChangedBy     = "System.ChangedBy"
+)
+
 // WorkItem represents an Azure DevOps work item
 type WorkItem struct {
 	ID            int           `json:"id"`
@@ -38,10 +64,24 @@ type WorkItem struct {
 	ParentID      int           `json:"parentId"`
 	ParentTitle   string        `json:"parentTitle"`
 	Priority      int           `json:"priority"`
+	StoryPoints   float64       `json:"storyPoints"`
+	RemainingWork float64       `json:"remainingWork"`
 	CreatedDate   time.Time     `json:"createdDate"`
 	ChangedDate   time.Time     `json:"changedDate"`
 	URL           string        `json:"url"`
 	WebURL        string        `json:"webUrl"`
+
+	// Fields holds every field returned by the API keyed by reference name,
+	// including custom fields that have no typed counterpart above
+	Fields map[string]interface{} `json:"fields,omitempty"`
+
+	// Relations holds the links to other items and artifacts.
+	// Only loaded with the full item.
+	Relations []WorkItemRelation `json:"relations,omitempty"`
+
+	// DetailsLoaded is true once the full item (all fields) has been fetched.
+	// List queries only request the fields the visible columns need.
+	DetailsLoaded bool `json:"-"`
 }
 
 // ShortType returns a short version of the work item type
@@ -90,3 +130,147 @@ type WorkItemStateInfo struct {
 	Color    string `json:"color"`
 	Category string `json:"category"` // Proposed, InProgress, Resolved, Completed, Removed
 }
+
+// CanHaveTasks reports whether the item is a backlog item that is broken
+// down into tasks (stories, backlog items, requirements, bugs, issues)
+func (w *WorkItem) CanHaveTasks() bool {
+	switch w.Type {
+	case WorkItemTypeStory, WorkItemTypeBug, "Product Backlog Item", "Requirement", "Issue":
+		return true
+	}
+	return false
+}
+
+// StateCategory returns the workflow category of the item's state
+// (Proposed, InProgress, Resolved, Completed, Removed), or "" if unknown
+func (w *WorkItem) StateCategory(statesByType map[string][]WorkItemStateInfo) string {
+	for _, state := range statesByType[string(w.Type)] {
+		if state.Name == string(w.State) {
+			return state.Category
+		}
+	}
+	return ""
+}
+
+// IsStale returns true if the item is still open and hasn't changed in the given number of days
+func (w *WorkItem) IsStale(days int, statesByType map[string][]WorkItemStateInfo, now time.Time) bool {
+	if days <= 0 || w.ChangedDate.IsZero() || w.IsClosed(statesByType) {
+		return false
+	}
+	return now.Sub(w.ChangedDate) > time.Duration(days)*24*time.Hour
+}
+
+// IsClosed returns true if the item is completed or removed
+func (w *WorkItem) IsClosed(statesByType map[string][]WorkItemStateInfo) bool {
+	switch w.StateCategory(statesByType) {
+	case "Completed", "Removed":
+		return true
+	case "":
+		// Without state metadata, fall back to the common closed states
+		return w.State == WorkItemStateClosed || w.State == "Done" || w.State == "Removed"
+	}
+	return false
+}
+
+// FieldValue returns the display value of a field given its reference name
+func (w *WorkItem) FieldValue(ref string) string {
+	switch ref {
+	case FieldID:
+		return fmt.Sprintf("#%d", w.ID)
+	case FieldTitle:
+		return w.Title
+	case FieldState:
+		return string(w.State)
+	case FieldType:
+		return w.ShortType()
+	case FieldAssignedTo:
+		if w.AssignedTo == "" {
+			return "-"
+		}
+		return w.AssignedTo
+	case FieldIterationPath:
+		return w.SprintName()
+	case FieldAreaPath:
+		return w.AreaName()
+	case FieldTags:
+		return strings.Join(w.Tags, ", ")
+	case FieldParent:
+		if w.ParentID == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("#%d", w.ParentID)
+	case FieldPriority:
+		if w.Priority == 0 {
+			return "-"
+		}
+		return strconv.Itoa(w.Priority)
+	case FieldStoryPoints:
+		return formatNumber(w.StoryPoints)
+	case FieldRemainingWork:
+		return formatNumber(w.RemainingWork)
+	case FieldCreatedDate:
+		if w.CreatedDate.IsZero() {
+			return "-"
+		}
+		return formatDate(w.CreatedDate)
+	case FieldChangedDate:
+		if w.ChangedDate.IsZero() {
+			return "-"
+		}
+		return formatDate(w.ChangedDate)
+	}
+
+	return formatFieldValue(w.Fields[ref])
+}
+
+// formatNumber formats a numeric field, using "-" for zero
+func formatNumber(n float64) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// formatFieldValue formats a raw field value as decoded from JSON
+func formatFieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		// Dates come back as RFC 3339 strings
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.Format("2006-01-02")
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case map[string]interface{}:
+		// Identity fields
+		if name, ok := v["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// AssignedToAccount returns the unique name of the assignee, as needed to
+// assign the item, or "" when it is unassigned
+func (w *WorkItem) AssignedToAccount() string {
+	return w.IdentityAccount(FieldAssignedTo)
+}
+
+// IdentityAccount returns the unique name of the identity in a field like
+// System.CreatedBy, or "" when the field is empty or wasn't fetched
+func (w *WorkItem) IdentityAccount(ref string) string {
+	if identity, ok := w.Fields[ref].(map[string]interface{}); ok {
+		if name, ok := identity["uniqueName"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
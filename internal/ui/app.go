@@ -2,7 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -30,6 +33,12 @@ type ViewMode int
 const (
 	ViewMain ViewMode = iota
 	ViewDetail
+	ViewGraph
+	ViewPipelines
+	ViewRun
+	ViewPullRequests
+	ViewDiff
+	ViewMentions
 )
 
 // App is the main application model
@@ -43,10 +52,27 @@ type App struct {
 	stateModal     components.StateModal
 	branchModal    components.BranchModal
 	assignModal    components.AssignModal
+	dateModal      components.DateRangeModal
+	columnModal    components.ColumnModal
+	sortModal      components.SortModal
+	linkModal      components.LinkModal
+	taskModal      components.TaskModal
+	prModal        components.PullRequestModal
+	workModal      components.WorkModal
+	queueModal     components.QueueModal
+	commentModal   components.CommentModal
+	inboxModal     components.NotificationsModal
+	graphView      components.GraphView
+	pipelinesView  components.PipelinesView
+	runView        components.RunView
+	prsView        components.PullRequestsView
+	diffView       components.DiffView
+	mentionsView   components.MentionsView
 
 	// State
 	activePanel Panel
 	viewMode    ViewMode
+	detailFrom  ViewMode // View the detail view returns to
 	loading     bool
 	err         error
 	statusMsg   string // Temporary status message
@@ -57,11 +83,78 @@ type App struct {
 	workItems    []models.WorkItem
 	statesByType map[string][]models.WorkItemStateInfo
 	teamMembers  []models.TeamMember
+	tags         []string
+	fieldDefs    []models.FieldDefinition
+	currentUser  models.TeamMember
+
+	// Full work items (all fields) loaded on demand, keyed by ID
+	detailsCache   map[int]models.WorkItem
+	detailsPending int
+
+	// Linked item being loaded for navigation in the detail view
+	navigatePending int
+
+	// Dependency graph: item it was drawn for, and the latest load request
+	graphItemID int
+	graphSeq    int
+
+	// Pipelines view: pipeline whose runs are shown (0 for all), and the
+	// latest load request; older results and refresh ticks are dropped
+	pipelineDefID int
+	pipelineSeq   int
+
+	// Run view: latest timeline and log load requests
+	runSeq int
+	logSeq int
+
+	// Pull requests view: latest list and details load requests, and the
+	// teams I review pull requests for
+	prSeq     int
+	reviewSeq int
+	myTeamIDs []string
+
+	// Diff view: latest changes, file diff and threads load requests
+	diffSeq     int
+	fileDiffSeq int
+	threadSeq   int
+
+	// Mentions view: latest load request
+	mentionSeq int
+
+	// Notifications: changes others made to my items, the last change seen
+	// (time and item) the next poll starts after, and the outcome of the
+	// last poll
+	inbox         models.Inbox
+	notifySince   time.Time
+	notifyAfterID int
+	lastPoll      time.Time
+	pollErr       error
+
+	// Latest request resolving the detail view's pull request, commit and
+	// build links; older results and refresh ticks are dropped
+	artifactSeq int
+
+	// Working tree git commands run in; switching worktrees changes it
+	// instead of the process's directory
+	gitRepo git.Repo
+
+	// Work item started on or named by the checked out git branch, 0 when
+	// there is none.
+	// The list lands on it once, when both the branch and the items are known.
+	branchMatcher  *models.BranchMatcher
+	branchNamer    *models.BranchNamer
+	branchDetected bool
+	currentBranch  string
+	currentItemID  int
+	currentItem    *models.WorkItem
+	landed         bool
 
 	// Services
 	client *api.Client
+	out    *Terminal // Program output, for alerts
 
 	// Config
+	cfg    *config.Config
 	styles theme.Styles
 	keys   theme.KeyMap
 
@@ -71,26 +164,72 @@ type App struct {
 }
 
 // NewApp creates a new application
-func NewApp(client *api.Client) App {
+func NewApp(client *api.Client, cfg *config.Config, out *Terminal) App {
 	styles := theme.DefaultStyles()
 	keys := theme.DefaultKeyMap()
 
 	// Create empty filter state (will be populated after loading data)
-	filterState := models.NewFilterState(nil, nil, nil)
+	filterState := models.NewFilterState(nil, nil, nil, nil, nil)
+
+	workItemsPanel := components.NewWorkItemsPanel(styles, keys)
+	workItemsPanel.SetStaleDays(cfg.StaleDays)
+
+	// Columns chosen in the app win over the configured ones
+	columns := cfg.Columns
+	if profileState, err := config.LoadProfileState(cfg.ProfileName()); err == nil {
+		if len(profileState.Columns) > 0 {
+			columns = profileState.Columns
+		}
+		workItemsPanel.SetSortKeys(profileState.Sort)
+		workItemsPanel.SetGroupBy(profileState.GroupBy)
+	}
+	workItemsPanel.SetColumns(columns)
+
+	// Branch patterns and templates were validated when the config was
+	// loaded; the default patterns keep the matcher usable regardless
+	branchMatcher, err := cfg.BranchMatcher()
+	if err != nil {
+		branchMatcher, _ = models.NewBranchMatcher(nil)
+	}
+	branchNamer, _ := cfg.BranchNamer()
+	branchModal := components.NewBranchModal(styles, keys)
+	branchModal.SetNamer(branchNamer)
 
 	return App{
 		filterPanel:    components.NewFilterPanel(filterState, styles, keys),
-		workItemsPanel: components.NewWorkItemsPanel(styles, keys),
+		workItemsPanel: workItemsPanel,
 		detailsPanel:   components.NewDetailsPanel(styles),
 		detailView:     components.NewDetailView(styles, keys),
 		helpPanel:      components.NewHelpPanel(keys, styles),
 		stateModal:     components.NewStateModal(styles, keys),
-		branchModal:    components.NewBranchModal(styles, keys),
+		branchModal:    branchModal,
 		assignModal:    components.NewAssignModal(styles, keys),
+		dateModal:      components.NewDateRangeModal(styles, keys),
+		columnModal:    components.NewColumnModal(styles, keys),
+		sortModal:      components.NewSortModal(styles, keys),
+		linkModal:      components.NewLinkModal(styles, keys),
+		taskModal:      components.NewTaskModal(styles, keys),
+		prModal:        components.NewPullRequestModal(styles, keys),
+		workModal:      components.NewWorkModal(styles, keys),
+		queueModal:     components.NewQueueModal(styles, keys),
+		commentModal:   components.NewCommentModal(styles, keys),
+		inboxModal:     components.NewNotificationsModal(styles, keys),
+		graphView:      components.NewGraphView(styles, keys),
+		pipelinesView:  components.NewPipelinesView(styles, keys),
+		runView:        components.NewRunView(styles, keys),
+		prsView:        components.NewPullRequestsView(styles, keys),
+		diffView:       components.NewDiffView(styles, keys),
+		mentionsView:   components.NewMentionsView(styles, keys),
+		detailsCache:   make(map[int]models.WorkItem),
+		notifySince:    time.Now(),
+		branchMatcher:  branchMatcher,
+		branchNamer:    branchNamer,
 		activePanel:    PanelWorkItems,
 		viewMode:       ViewMain,
 		loading:        true,
 		client:         client,
+		out:            out,
+		cfg:            cfg,
 		styles:         styles,
 		keys:           keys,
 	}
@@ -100,6 +239,8 @@ func NewApp(client *api.Client) App {
 func (a App) Init() tea.Cmd {
 	return tea.Batch(
 		loadDataCmd(a.client),
+		detectCurrentWorkItemCmd(a.gitRepo, a.client, a.branchMatcher),
+		a.scheduleNotificationPollCmd(),
 	)
 }
 
@@ -142,12 +283,102 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Batch(cmds...)
 		}
 
+		if a.dateModal.IsVisible() {
+			newModal, cmd := a.dateModal.Update(msg)
+			a.dateModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.columnModal.IsVisible() {
+			newModal, cmd := a.columnModal.Update(msg)
+			a.columnModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.sortModal.IsVisible() {
+			newModal, cmd := a.sortModal.Update(msg)
+			a.sortModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.linkModal.IsVisible() {
+			newModal, cmd := a.linkModal.Update(msg)
+			a.linkModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.taskModal.IsVisible() {
+			newModal, cmd := a.taskModal.Update(msg)
+			a.taskModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.prModal.IsVisible() {
+			newModal, cmd := a.prModal.Update(msg)
+			a.prModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.workModal.IsVisible() {
+			newModal, cmd := a.workModal.Update(msg)
+			a.workModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.queueModal.IsVisible() {
+			newModal, cmd := a.queueModal.Update(msg)
+			a.queueModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.commentModal.IsVisible() {
+			newModal, cmd := a.commentModal.Update(msg)
+			a.commentModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		if a.inboxModal.IsVisible() {
+			newModal, cmd := a.inboxModal.Update(msg)
+			a.inboxModal = newModal
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
 		// Global keys
 		if key.Matches(msg, a.keys.Quit) && !a.helpPanel.IsVisible() && a.viewMode == ViewMain {
 			return a, tea.Quit
 		}
 
-		if key.Matches(msg, a.keys.Help) {
+		if key.Matches(msg, a.keys.Help) && !(a.viewMode == ViewRun && a.runView.Searching()) {
 			a.helpPanel.Toggle()
 			return a, nil
 		}
@@ -160,13 +391,101 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// Handle graph view mode
+		if a.viewMode == ViewGraph {
+			newGraphView, cmd := a.graphView.Update(msg)
+			a.graphView = newGraphView
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle pipelines view mode
+		if a.viewMode == ViewPipelines {
+			newPipelinesView, cmd := a.pipelinesView.Update(msg)
+			a.pipelinesView = newPipelinesView
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle run view mode
+		if a.viewMode == ViewRun {
+			newRunView, cmd := a.runView.Update(msg)
+			a.runView = newRunView
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle pull requests view mode
+		if a.viewMode == ViewPullRequests {
+			newPullRequestsView, cmd := a.prsView.Update(msg)
+			a.prsView = newPullRequestsView
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle diff view mode
+		if a.viewMode == ViewDiff {
+			newDiffView, cmd := a.diffView.Update(msg)
+			a.diffView = newDiffView
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
+		// Handle mentions view mode
+		if a.viewMode == ViewMentions {
+			newMentionsView, cmd := a.mentionsView.Update(msg)
+			a.mentionsView = newMentionsView
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			return a, tea.Batch(cmds...)
+		}
+
 		// Handle detail view mode
 		if a.viewMode == ViewDetail {
+			if key.Matches(msg, a.keys.Links) {
+				return a, a.openLinkModal(a.detailView.Item())
+			}
+			if key.Matches(msg, a.keys.PullRequest) {
+				return a, a.openPullRequestModal(a.detailView.Item())
+			}
+			if key.Matches(msg, a.keys.Comment) {
+				if item := a.detailView.Item(); item != nil {
+					target := components.CommentTarget{WorkItem: item}
+					return a, func() tea.Msg { return components.ComposeCommentMsg{Target: target} }
+				}
+			}
+			if key.Matches(msg, a.keys.CurrentItem) {
+				if a.currentItemID == 0 {
+					a.detailView.SetStatus(a.noCurrentItemStatus())
+					return a, nil
+				}
+				if a.currentItemID == a.detailView.ItemID() {
+					return a, nil
+				}
+				id := a.currentItemID
+				return a, func() tea.Msg { return components.NavigateWorkItemMsg{ID: id} }
+			}
+			shownID := a.detailView.ItemID()
 			newDetailView, cmd := a.detailView.Update(msg)
 			a.detailView = newDetailView
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+			// Back/forward through the history shows another item
+			if a.detailView.ItemID() != shownID {
+				cmds = append(cmds, a.resolveArtifactsCmd())
+			}
 			return a, tea.Batch(cmds...)
 		}
 
@@ -184,7 +503,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if key.Matches(msg, a.keys.Refresh) {
 			a.loading = true
 			a.statusMsg = ""
-			return a, loadWorkItemsCmd(a.client, a.filterPanel.FilterState())
+			return a, tea.Batch(
+				a.loadWorkItemsCmd(),
+				detectCurrentWorkItemCmd(a.gitRepo, a.client, a.branchMatcher),
+			)
+		}
+
+		// Jump to the work item of the current git branch
+		if key.Matches(msg, a.keys.CurrentItem) {
+			return a, a.goToCurrentItem()
 		}
 
 		// Open state change modal (only when work items panel is active)
@@ -203,7 +530,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.branchModal.SetItem(item)
 				a.branchModal.SetSize(a.width, a.height)
 				a.branchModal.SetVisible(true)
-				return a, nil
+				return a, tea.Batch(
+					loadBranchesCmd(a.gitRepo, item.ID, a.branchMatcher),
+					listWorktreesCmd(a.gitRepo, item.ID, a.cfg.WorktreePath, a.branchMatcher),
+				)
 			}
 		}
 
@@ -218,6 +548,102 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Open column chooser (only when work items panel is active)
+		if key.Matches(msg, a.keys.Columns) && a.activePanel == PanelWorkItems {
+			a.columnModal.SetColumns(a.workItemsPanel.Columns())
+			a.columnModal.SetFields(a.fieldDefs)
+			a.columnModal.SetSize(a.width, a.height)
+			a.columnModal.SetVisible(true)
+			return a, nil
+		}
+
+		// Open link editor (only when work items panel is active)
+		if key.Matches(msg, a.keys.Links) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				return a, a.openLinkModal(item)
+			}
+		}
+
+		// Open task breakdown prompt (only for items that have tasks)
+		if key.Matches(msg, a.keys.AddTasks) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				if !item.CanHaveTasks() {
+					a.statusMsg = fmt.Sprintf("Tasks can't be added to a %s", item.Type)
+					return a, nil
+				}
+				a.taskModal.SetItem(item)
+				a.taskModal.SetSize(a.width, a.height)
+				a.taskModal.SetVisible(true)
+				return a, nil
+			}
+		}
+
+		// Create a pull request from the current branch for the selected item
+		if key.Matches(msg, a.keys.PullRequest) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				return a, a.openPullRequestModal(item)
+			}
+		}
+
+		// Preview starting or finishing work on the selected item
+		if key.Matches(msg, a.keys.StartWork) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				return a, a.startWorkCmd(*item)
+			}
+		}
+		if key.Matches(msg, a.keys.FinishWork) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				return a, a.finishWorkCmd(*item)
+			}
+		}
+
+		// Show dependency graph of the selected item
+		if key.Matches(msg, a.keys.Graph) && a.activePanel == PanelWorkItems {
+			if item := a.workItemsPanel.SelectedItem(); item != nil {
+				a.graphItemID = item.ID
+				a.viewMode = ViewGraph
+				return a, a.loadGraphCmd(components.GraphScopeItem)
+			}
+		}
+
+		// Show recent pipeline runs of the project
+		if key.Matches(msg, a.keys.Pipelines) && a.activePanel == PanelWorkItems {
+			a.viewMode = ViewPipelines
+			a.pipelinesView.SetMine(nil, a.currentUser.UniqueName)
+			return a, a.loadPipelinesCmd(a.pipelineDefID)
+		}
+
+		// Show active pull requests of the project
+		if key.Matches(msg, a.keys.PullRequests) && a.activePanel == PanelWorkItems {
+			a.viewMode = ViewPullRequests
+			a.prsView.SetMe(a.currentUser.UniqueName, append([]string{a.currentUser.ID}, a.myTeamIDs...))
+			return a, a.loadPullRequestsCmd()
+		}
+
+		// Show the discussion comments mentioning me
+		if key.Matches(msg, a.keys.Mentions) && a.activePanel == PanelWorkItems {
+			a.viewMode = ViewMentions
+			return a, a.loadMentionsCmd()
+		}
+
+		// Show the notification inbox
+		if key.Matches(msg, a.keys.Notifications) && a.activePanel == PanelWorkItems {
+			a.inboxModal.SetNotifications(a.inbox.Notifications)
+			a.inboxModal.SetPollState(a.cfg.NotificationInterval() > 0, a.lastPoll, a.pollErr)
+			a.inboxModal.SetSize(a.width, a.height)
+			a.inboxModal.SetVisible(true)
+			return a, nil
+		}
+
+		// Open sort chooser (only when work items panel is active)
+		if key.Matches(msg, a.keys.Sort) && a.activePanel == PanelWorkItems {
+			a.sortModal.SetColumns(a.workItemsPanel.Columns())
+			a.sortModal.SetSortKeys(a.workItemsPanel.SortKeys())
+			a.sortModal.SetSize(a.width, a.height)
+			a.sortModal.SetVisible(true)
+			return a, nil
+		}
+
 		// Update active panel
 		switch a.activePanel {
 		case PanelFilter:
@@ -239,37 +665,109 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.areas = msg.areas
 		a.statesByType = msg.statesByType
 		a.teamMembers = msg.teamMembers
+		a.tags = msg.tags
+		a.fieldDefs = msg.fieldDefs
+		a.currentUser = msg.currentUser
+		a.branchModal.SetAlias(a.currentUser.Alias())
 		a.stateModal.SetStatesByType(a.statesByType)
-		filterState := models.NewFilterState(a.iterations, a.areas, a.statesByType)
+		a.workItemsPanel.SetStatesByType(a.statesByType)
+		filterState := models.NewFilterState(a.iterations, a.areas, a.statesByType, a.teamMembers, a.tags)
 
 		// Apply saved filter selections
-		if savedState, err := config.LoadFilterState(); err == nil {
-			filterState.ApplySavedSelections(savedState.Sprint, savedState.State, savedState.Assigned, savedState.Area)
+		if savedState, err := config.LoadFilterState(a.cfg.ProfileName()); err == nil {
+			filterState.ApplySavedSelections(savedState.Selections)
 		}
 
 		a.filterPanel.SetFilterState(filterState)
 		// Load work items with initial filters
-		return a, loadWorkItemsCmd(a.client, filterState)
+		return a, a.loadWorkItemsCmd()
 
 	case workItemsLoadedMsg:
 		a.loading = false
+		// Reuse fully loaded items that haven't changed since
+		for i, item := range msg.items {
+			if cached, ok := a.detailsCache[item.ID]; ok && cached.Rev == item.Rev {
+				msg.items[i] = cached
+			}
+		}
 		a.workItems = msg.items
 		a.workItemsPanel.SetItems(msg.items)
-		a.updateSelectedItem()
+		a.landOnCurrentItem()
+
+	case currentWorkItemMsg:
+		a.branchDetected = true
+		a.currentBranch = msg.branch
+		a.currentItemID = msg.id
+		a.currentItem = msg.item
+		if msg.item != nil {
+			a.detailsCache[msg.item.ID] = *msg.item
+		}
+		a.workItemsPanel.SetCurrentItemID(msg.id)
+		a.landOnCurrentItem()
+
+	case workItemDetailsLoadedMsg:
+		a.detailsCache[msg.item.ID] = msg.item
+		a.workItemsPanel.UpdateItem(msg.item)
+		if a.viewMode == ViewDetail && a.detailView.ItemID() == msg.item.ID {
+			a.detailView.UpdateItem(&msg.item)
+			cmds = append(cmds, a.resolveArtifactsCmd())
+		}
+		if a.detailsPending == msg.item.ID {
+			a.detailsPending = 0
+		}
+		if a.navigatePending == msg.item.ID {
+			a.navigatePending = 0
+			a.detailView.Navigate(&msg.item)
+			cmds = append(cmds, a.resolveArtifactsCmd())
+		}
+		if a.linkModal.IsVisible() && a.linkModal.ItemID() == msg.item.ID {
+			a.linkModal.SetItem(&msg.item)
+		}
+
+	case components.SortChangedMsg:
+		a.sortModal.SetVisible(false)
+		a.workItemsPanel.SetSortKeys(msg.Keys)
+		a.saveProfileState()
+
+	case components.GroupByChangedMsg:
+		a.saveProfileState()
+		if msg.Field == "" {
+			a.statusMsg = "Grouping off"
+		} else {
+			a.statusMsg = "Grouped by " + models.GroupByLabel(msg.Field)
+		}
+		// Group fields and the summed fields may not have been fetched
+		a.loading = true
+		return a, a.loadWorkItemsCmd()
+
+	case components.ColumnsChangedMsg:
+		a.columnModal.SetVisible(false)
+		a.workItemsPanel.SetColumns(msg.Columns)
+		a.saveProfileState()
+		// New columns may need fields that weren't fetched
+		a.loading = true
+		return a, a.loadWorkItemsCmd()
 
 	case components.FilterChangedMsg:
 		a.loading = true
 		fs := a.filterPanel.FilterState()
 
 		// Save filter selections for next startup
-		_ = config.SaveFilterState(&config.FilterState{
-			Sprint:   fs.GetSelectedSprint(),
-			State:    fs.GetSelectedState(),
-			Assigned: fs.GetSelectedAssigned(),
-			Area:     fs.GetSelectedArea(),
+		_ = config.SaveFilterState(a.cfg.ProfileName(), &config.FilterState{
+			Selections: fs.Selections(),
 		})
 
-		return a, loadWorkItemsCmd(a.client, fs)
+		return a, a.loadWorkItemsCmd()
+
+	case components.DateRangeRequestMsg:
+		a.dateModal.SetSize(a.width, a.height)
+		a.dateModal.SetVisible(true)
+		return a, nil
+
+	case components.DateRangeSelectedMsg:
+		a.dateModal.SetVisible(false)
+		a.filterPanel.FilterState().SetCustomDateRange(msg.Condition)
+		return a, func() tea.Msg { return components.FilterChangedMsg{} }
 
 	case components.OpenWorkItemMsg:
 		if err := browser.Open(msg.Item.WebURL); err != nil {
@@ -277,308 +775,2412 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case components.ViewWorkItemMsg:
+		if a.viewMode != ViewDetail {
+			a.detailFrom = a.viewMode
+		}
 		a.viewMode = ViewDetail
-		a.detailView.SetItem(&msg.Item)
+		item := msg.Item
+		if cached, ok := a.detailsCache[item.ID]; ok && cached.Rev == item.Rev {
+			item = cached
+		}
+		a.detailView.SetItem(&item)
 		a.updateSizes()
+		cmds = append(cmds, a.resolveArtifactsCmd())
+		// Items from other views only have the list fields
+		if !item.DetailsLoaded && item.ID != a.detailsPending {
+			cmds = append(cmds, loadWorkItemDetailsCmd(a.client, item.ID))
+		}
+		return a, tea.Batch(cmds...)
 
 	case components.CloseDetailViewMsg:
+		a.viewMode = a.detailFrom
+		a.detailFrom = ViewMain
+		a.navigatePending = 0
+
+	case components.CloseGraphViewMsg:
 		a.viewMode = ViewMain
 
-	case errMsg:
-		a.loading = false
-		a.err = msg.err
+	case components.GraphScopeMsg:
+		return a, a.loadGraphCmd(msg.Scope)
 
-	case components.ModalClosedMsg:
-		// Modal was closed, nothing special to do
-		a.stateModal.SetVisible(false)
-		a.branchModal.SetVisible(false)
-		a.assignModal.SetVisible(false)
+	case graphLoadedMsg:
+		if msg.seq != a.graphSeq {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.graphView.SetError(msg.err)
+			return a, nil
+		}
+		// Items outside the loaded set are only known through links
+		for _, node := range msg.graph.Nodes {
+			if node.WebURL == "" {
+				node.WebURL = a.client.WorkItemWebURL(node.ID)
+			}
+		}
+		a.graphView.SetGraph(msg.graph)
 
-	case components.StateChangeRequestMsg:
-		a.stateModal.SetVisible(false)
-		a.loading = true
-		return a, updateWorkItemStateCmd(a.client, msg.Item.ID, msg.NewState, a.filterPanel.FilterState())
+	case components.ClosePipelinesViewMsg:
+		a.viewMode = ViewMain
+		a.pipelineSeq++
 
-	case stateChangedMsg:
-		a.loading = false
-		a.statusMsg = fmt.Sprintf("State changed to %s", msg.newState)
-		// Refresh work items to show updated state
-		return a, loadWorkItemsCmd(a.client, a.filterPanel.FilterState())
+	case components.PipelineSelectedMsg:
+		return a, a.loadPipelinesCmd(msg.DefinitionID)
 
-	case components.BranchCreateRequestMsg:
-		a.branchModal.SetVisible(false)
-		return a, createBranchCmd(msg.BranchName)
+	case components.PipelinesRefreshMsg:
+		return a, a.loadPipelinesCmd(a.pipelineDefID)
 
-	case components.BranchCreatedMsg:
-		a.statusMsg = fmt.Sprintf("Branch created: %s", msg.BranchName)
+	case pipelinesLoadedMsg:
+		if msg.seq != a.pipelineSeq || a.viewMode != ViewPipelines {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.pipelinesView.SetError(msg.err)
+			return a, nil
+		}
+		a.pipelinesView.SetMine(msg.localBranches, a.currentUser.UniqueName)
+		a.pipelinesView.SetData(msg.definitions, msg.runs)
+		if !a.pipelinesView.HasRunning() {
+			return a, nil
+		}
+		// Follow runs in progress until they complete
+		seq := msg.seq
+		return a, tea.Tick(pipelineRefreshInterval, func(time.Time) tea.Msg {
+			return pipelineRefreshMsg{seq: seq}
+		})
 
-	case components.BranchCreateErrorMsg:
-		a.err = msg.Err
+	case pipelineRefreshMsg:
+		if msg.seq == a.pipelineSeq && a.viewMode == ViewPipelines {
+			return a, a.loadPipelinesCmd(a.pipelineDefID)
+		}
 
-	case components.AssignRequestMsg:
-		a.assignModal.SetVisible(false)
-		a.loading = true
-		return a, assignWorkItemCmd(a.client, msg.Item.ID, msg.UserEmail, msg.UserName, a.filterPanel.FilterState())
+	case components.QueueRunMsg:
+		a.queueModal.SetSize(a.width, a.height)
+		a.queueModal.Open(msg.DefinitionID)
+		return a, loadQueueFormCmd(a.client, msg.DefinitionID)
 
-	case assignedMsg:
-		a.loading = false
-		a.statusMsg = fmt.Sprintf("Assigned to %s", msg.userName)
-		// Refresh work items to show updated assignment
-		return a, loadWorkItemsCmd(a.client, a.filterPanel.FilterState())
-	}
+	case queueFormLoadedMsg:
+		if msg.err != nil {
+			a.queueModal.SetLoadError(msg.definitionID, msg.err)
+			return a, nil
+		}
+		a.queueModal.SetDefinition(msg.definition, "")
+		return a, loadPipelineParametersCmd(a.client, msg.definition, msg.definition.DefaultBranch)
 
-	// Update selected item in details panel
-	a.updateSelectedItem()
+	case components.QueueBranchChangedMsg:
+		return a, loadPipelineParametersCmd(a.client, msg.Definition, msg.Branch)
 
-	return a, tea.Batch(cmds...)
-}
+	case pipelineParametersLoadedMsg:
+		a.queueModal.SetParameters(msg.definitionID, msg.branch, msg.params, msg.err)
 
-// View renders the application
-func (a App) View() string {
-	if a.width == 0 || a.height == 0 {
-		return "Loading..."
-	}
+	case components.QueueRunRequestMsg:
+		return a, queueRunCmd(a.client, msg.Run)
 
-	// Render state modal if visible
-	if a.stateModal.IsVisible() {
-		return a.stateModal.View()
-	}
+	case runQueuedMsg:
+		if msg.err != nil {
+			a.queueModal.SetQueueError(msg.err)
+			return a, nil
+		}
+		a.queueModal.SetVisible(false)
+		a.pipelinesView.AddRun(msg.run)
+		a.pipelinesView.SetStatus(fmt.Sprintf("Queued %s on %s", msg.run.Title(), msg.run.Branch()))
+		// Follow the new run; an older pending refresh is dropped
+		seq := a.pipelineSeq
+		return a, tea.Tick(pipelineRefreshInterval, func(time.Time) tea.Msg {
+			return pipelineRefreshMsg{seq: seq}
+		})
 
-	// Render branch modal if visible
-	if a.branchModal.IsVisible() {
-		return a.branchModal.View()
-	}
+	case components.CancelRunRequestMsg:
+		return a, cancelRunCmd(a.client, msg.Run)
 
-	// Render assign modal if visible
-	if a.assignModal.IsVisible() {
-		return a.assignModal.View()
-	}
+	case components.RetryRunRequestMsg:
+		return a, retryStagesCmd(a.client, msg.Run, msg.Stages)
 
-	// Render help overlay if visible
-	if a.helpPanel.IsVisible() {
-		_ = a.renderMainView()
-		help := a.helpPanel.View()
-		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, help)
-	}
+	case runActionDoneMsg:
+		status := msg.status
+		if msg.err != nil {
+			status = "Error: " + msg.err.Error()
+		}
+		switch a.viewMode {
+		case ViewRun:
+			a.runView.SetStatus(status)
+			return a, a.loadRunCmd()
+		case ViewPipelines:
+			a.pipelinesView.SetStatus(status)
+			return a, a.loadPipelinesCmd(a.pipelineDefID)
+		}
 
-	// Render detail view if in detail mode
-	if a.viewMode == ViewDetail {
-		return a.detailView.View()
-	}
+	case components.OpenRunMsg:
+		a.viewMode = ViewRun
+		a.runView.SetRun(msg.Run)
+		return a, a.loadRunCmd()
 
-	return a.renderMainView()
-}
+	case components.CloseRunViewMsg:
+		a.viewMode = ViewPipelines
+		a.runSeq++
+		a.logSeq++
+		return a, a.loadPipelinesCmd(a.pipelineDefID)
 
-func (a *App) renderMainView() string {
-	// Calculate dimensions
-	// Borders add 2 chars per panel (1 left + 1 right)
-	// Two panels side by side = 4 total border overhead
+	case components.RunRefreshMsg:
+		return a, a.loadRunCmd()
+
+	case runLoadedMsg:
+		if msg.seq != a.runSeq || a.viewMode != ViewRun {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.runView.SetError(msg.err)
+			return a, nil
+		}
+		cmds = append(cmds, a.runView.SetTimeline(msg.run, msg.records))
+		// Tail the run until it completes
+		if msg.run.IsRunning() {
+			seq := msg.seq
+			cmds = append(cmds, tea.Tick(runRefreshInterval, func(time.Time) tea.Msg {
+				return runRefreshMsg{seq: seq}
+			}))
+		}
+		return a, tea.Batch(cmds...)
+
+	case runRefreshMsg:
+		if msg.seq == a.runSeq && a.viewMode == ViewRun {
+			return a, a.loadRunCmd()
+		}
+
+	case components.RunLogRequestMsg:
+		a.logSeq++
+		return a, loadBuildLogCmd(a.client, a.logSeq, a.runView.Run().ID, msg.Record, msg.StartLine)
+
+	case buildLogLoadedMsg:
+		if msg.seq != a.logSeq || a.viewMode != ViewRun {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.runView.SetLogError(msg.recordID, msg.err)
+			return a, nil
+		}
+		a.runView.SetLog(msg.recordID, msg.startLine, msg.lines)
+
+	case components.SaveLogMsg:
+		return a, saveLogCmd(a.runView.LogFileName(), msg.Lines)
+
+	case logSavedMsg:
+		if msg.err != nil {
+			a.runView.SetStatus("Error: " + msg.err.Error())
+		} else {
+			a.runView.SetStatus(fmt.Sprintf("Saved %d lines to %s", msg.lines, msg.path))
+		}
+
+	case components.ClosePullRequestsViewMsg:
+		a.viewMode = ViewMain
+		a.prSeq++
+		a.reviewSeq++
+
+	case components.PullRequestsRefreshMsg:
+		return a, a.loadPullRequestsCmd()
+
+	case pullRequestsLoadedMsg:
+		if msg.seq != a.prSeq || a.viewMode != ViewPullRequests {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.prsView.SetError(msg.err)
+			return a, nil
+		}
+		if msg.teamIDs != nil {
+			a.myTeamIDs = msg.teamIDs
+		}
+		a.prsView.SetMe(a.currentUser.UniqueName, append([]string{a.currentUser.ID}, a.myTeamIDs...))
+		return a, a.prsView.SetPullRequests(msg.prs)
+
+	case components.PullRequestSelectedMsg:
+		// Wait for the cursor to settle before loading
+		a.reviewSeq++
+		seq, pr := a.reviewSeq, msg.PullRequest
+		return a, tea.Tick(reviewLoadDelay, func(time.Time) tea.Msg {
+			return reviewLoadMsg{seq: seq, pr: pr}
+		})
+
+	case reviewLoadMsg:
+		if msg.seq == a.reviewSeq && a.viewMode == ViewPullRequests {
+			return a, loadPullRequestReviewCmd(a.client, msg.pr)
+		}
+
+	case pullRequestReviewLoadedMsg:
+		a.prsView.SetReview(msg.id, msg.review, msg.err)
+
+	case components.VoteRequestMsg:
+		if a.currentUser.ID == "" {
+			a.prsView.SetStatus("Error: cannot vote, current user is unknown")
+			return a, nil
+		}
+		a.prsView.SetStatus(fmt.Sprintf("Voting on !%d...", msg.PullRequest.ID))
+		return a, votePullRequestCmd(a.client, msg.PullRequest, a.currentUser.ID, msg.Vote)
+
+	case components.ComposeCommentMsg:
+		a.commentModal.SetSize(a.width, a.height)
+		a.commentModal.SetPeople(a.teamMembers)
+		a.commentModal.Open(msg.Target)
+		if msg.Mention != nil && msg.Mention.ID != "" && !strings.EqualFold(msg.Mention.ID, a.currentUser.ID) {
+			a.commentModal.Mention(*msg.Mention)
+		}
+
+	case components.CommentSubmitMsg:
+		return a, postCommentCmd(a.client, msg.Target, msg.Text, msg.Mentions)
+
+	case components.IdentitySearchRequestMsg:
+		// Drop searches superseded by further typing
+		if a.commentModal.IsVisible() && msg.Seq == a.commentModal.SearchSeq() {
+			return a, searchIdentitiesCmd(a.client, msg.Query, msg.Seq)
+		}
+
+	case identitySearchResultsMsg:
+		a.commentModal.SetSearchResults(msg.seq, msg.people, msg.err)
+
+	case commentPostedMsg:
+		if msg.err != nil {
+			a.commentModal.SetError(msg.err)
+			return a, nil
+		}
+		a.commentModal.SetVisible(false)
+		switch a.viewMode {
+		case ViewDiff:
+			a.diffView.SetStatus(msg.status)
+			return a, a.loadThreadsCmd()
+		case ViewDetail:
+			a.detailView.SetStatus(msg.status)
+		case ViewMentions:
+			a.mentionsView.SetStatus(msg.status)
+		default:
+			a.prsView.SetStatus(msg.status)
+		}
+
+	case components.CloseMentionsViewMsg:
+		a.viewMode = ViewMain
+		a.mentionSeq++
+
+	case components.MentionsRefreshMsg:
+		return a, a.loadMentionsCmd()
+
+	case mentionsLoadedMsg:
+		if msg.seq != a.mentionSeq || a.viewMode != ViewMentions {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.mentionsView.SetError(msg.err)
+			return a, nil
+		}
+		a.mentionsView.SetMentions(msg.mentions)
+		if len(msg.skipped) > 0 {
+			a.mentionsView.SetStatus(fmt.Sprintf("Skipped %d item(s): %v", len(msg.skipped), msg.skipped[0]))
+		}
+
+	case components.OpenDiffMsg:
+		a.viewMode = ViewDiff
+		a.diffView.SetPullRequest(msg.PullRequest)
+		return a, a.loadDiffCmd()
+
+	case components.CloseDiffViewMsg:
+		a.viewMode = ViewPullRequests
+		a.diffSeq++
+		a.fileDiffSeq++
+		a.threadSeq++
+		// Comments and resolved threads may have changed
+		return a, a.loadPullRequestsCmd()
+
+	case components.DiffRefreshMsg:
+		return a, a.loadDiffCmd()
+
+	case diffLoadedMsg:
+		if msg.seq != a.diffSeq || a.viewMode != ViewDiff {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.diffView.SetError(msg.err)
+			return a, nil
+		}
+		return a, a.diffView.SetData(msg.iteration, msg.changes, msg.threads, msg.local)
+
+	case components.FileDiffRequestMsg:
+		a.fileDiffSeq++
+		return a, loadFileDiffCmd(a.gitRepo, a.client, a.fileDiffSeq, a.diffView.PullRequest(), a.diffView.Iteration(), msg.Change, a.diffView.Local())
+
+	case fileDiffLoadedMsg:
+		if msg.seq == a.fileDiffSeq && a.viewMode == ViewDiff {
+			a.diffView.SetFileDiff(msg.path, msg.lines, msg.binary, msg.err)
+		}
+
+	case components.ThreadStatusRequestMsg:
+		return a, setThreadStatusCmd(a.client, msg.PullRequest, msg.ThreadID, msg.Status)
+
+	case threadStatusChangedMsg:
+		if msg.err != nil {
+			a.diffView.SetStatus("Error: " + msg.err.Error())
+			return a, nil
+		}
+		a.diffView.SetStatus(msg.status)
+		return a, a.loadThreadsCmd()
+
+	case threadsLoadedMsg:
+		if msg.seq != a.threadSeq || a.viewMode != ViewDiff {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.diffView.SetStatus("Error: " + msg.err.Error())
+			return a, nil
+		}
+		a.diffView.SetThreads(msg.threads)
+
+	case pullRequestActionDoneMsg:
+		if msg.err != nil {
+			a.prsView.SetStatus("Error: " + msg.err.Error())
+			return a, nil
+		}
+		a.prsView.SetStatus(msg.status)
+		// Votes show in the list
+		return a, a.loadPullRequestsCmd()
+
+	case components.NavigateWorkItemMsg:
+		if item, ok := a.detailsCache[msg.ID]; ok {
+			a.detailView.Navigate(&item)
+			return a, a.resolveArtifactsCmd()
+		}
+		a.navigatePending = msg.ID
+		a.detailView.SetStatus(fmt.Sprintf("Loading #%d...", msg.ID))
+		return a, loadWorkItemDetailsCmd(a.client, msg.ID)
+
+	case artifactsResolvedMsg:
+		if msg.seq != a.artifactSeq || a.viewMode != ViewDetail {
+			return a, nil
+		}
+		a.detailView.SetArtifacts(msg.artifacts)
+		// Keep PR votes and build results live while the item is shown
+		seq := msg.seq
+		return a, tea.Tick(artifactRefreshInterval, func(time.Time) tea.Msg {
+			return artifactRefreshMsg{seq: seq}
+		})
+
+	case artifactRefreshMsg:
+		if msg.seq == a.artifactSeq && a.viewMode == ViewDetail {
+			return a, a.resolveArtifactsCmd()
+		}
+
+	case components.OpenURLMsg:
+		if err := browser.Open(msg.URL); err != nil {
+			a.err = err
+		}
+
+	case errMsg:
+		a.loading = false
+		a.err = msg.err
+		// The detail view hides the main status bar
+		if a.navigatePending != 0 {
+			a.navigatePending = 0
+			a.detailView.SetStatus("Error: " + msg.err.Error())
+		}
+
+	case components.ModalClosedMsg:
+		// Modal was closed, nothing special to do
+		a.stateModal.SetVisible(false)
+		a.branchModal.SetVisible(false)
+		a.assignModal.SetVisible(false)
+		a.dateModal.SetVisible(false)
+		a.columnModal.SetVisible(false)
+		a.sortModal.SetVisible(false)
+		a.linkModal.SetVisible(false)
+		a.taskModal.SetVisible(false)
+		a.prModal.SetVisible(false)
+		a.workModal.SetVisible(false)
+		a.queueModal.SetVisible(false)
+		a.commentModal.SetVisible(false)
+		a.inboxModal.SetVisible(false)
+
+	case notificationPollMsg:
+		if a.currentUser.UniqueName == "" {
+			// Who I am isn't known yet; try again at the next tick
+			return a, a.scheduleNotificationPollCmd()
+		}
+		return a, pollNotificationsCmd(a.client, a.notifySince, a.notifyAfterID)
+
+	case notificationsPolledMsg:
+		a.pollErr = msg.err
+		if msg.err == nil {
+			a.lastPoll = time.Now()
+			// Items come oldest change first
+			if n := len(msg.items); n > 0 {
+				a.notifySince, a.notifyAfterID = msg.items[n-1].ChangedDate, msg.items[n-1].ID
+			}
+			var notifications []models.Notification
+			for _, item := range msg.items {
+				// Details loaded before the change are out of date
+				if cached, ok := a.detailsCache[item.ID]; ok && cached.Rev < item.Rev {
+					delete(a.detailsCache, item.ID)
+				}
+				notifications = append(notifications, models.NewNotification(item, a.currentUser.UniqueName))
+			}
+			if added := a.inbox.Add(notifications); added > 0 {
+				cmds = append(cmds, notifyTerminalCmd(a.out, a.cfg.Notifications, a.inbox.Notifications[0], added))
+			}
+		}
+		a.inboxModal.SetNotifications(a.inbox.Notifications)
+		a.inboxModal.SetPollState(true, a.lastPoll, a.pollErr)
+		cmds = append(cmds, a.scheduleNotificationPollCmd())
+		return a, tea.Batch(cmds...)
+
+	case components.OpenNotificationMsg:
+		a.inbox.MarkRead(msg.Item.ID)
+		a.inboxModal.SetVisible(false)
+		item := msg.Item
+		return a, func() tea.Msg { return components.ViewWorkItemMsg{Item: item} }
+
+	case components.MarkNotificationsReadMsg:
+		a.inbox.MarkAllRead()
+		a.inboxModal.SetNotifications(a.inbox.Notifications)
+
+	case components.CreateTasksRequestMsg:
+		if msg.AssignToMe && a.currentUser.UniqueName == "" {
+			a.err = fmt.Errorf("cannot assign tasks: current user is unknown")
+			return a, nil
+		}
+		a.taskModal.SetVisible(false)
+		a.loading = true
+		a.statusMsg = ""
+		assignee := ""
+		if msg.AssignToMe {
+			assignee = a.currentUser.UniqueName
+		}
+		return a, createTasksCmd(a.client, msg.Parent, msg.Titles, assignee)
+
+	case tasksCreatedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("Created %d task(s) under #%d", msg.count, msg.parentID)
+		delete(a.detailsCache, msg.parentID)
+		cmds = append(cmds, a.loadWorkItemsCmd())
+		if cmd := a.updateSelectedItem(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+
+	case components.WorkItemSearchRequestMsg:
+		// Drop searches superseded by further typing
+		if a.linkModal.IsVisible() && msg.Seq == a.linkModal.SearchSeq() {
+			return a, searchWorkItemsCmd(a.client, msg.Query, msg.Seq)
+		}
+
+	case workItemSearchResultsMsg:
+		a.linkModal.SetSearchResults(msg.seq, msg.items, msg.err)
+
+	case components.LinkRequestMsg:
+		a.linkModal.SetVisible(false)
+		a.loading = true
+		a.statusMsg = ""
+		return a, updateLinksCmd(a.gitRepo, a.client, msg)
+
+	case linksUpdatedMsg:
+		a.loading = false
+		a.statusMsg = msg.status
+		// Both ends of a link change, so cached details are stale
+		for _, id := range msg.itemIDs {
+			delete(a.detailsCache, id)
+		}
+		cmds = append(cmds, a.loadWorkItemsCmd())
+		if id := a.detailView.ItemID(); a.viewMode == ViewDetail && id != 0 {
+			cmds = append(cmds, loadWorkItemDetailsCmd(a.client, id))
+		} else if cmd := a.updateSelectedItem(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		return a, tea.Batch(cmds...)
+
+	case pullRequestSourceMsg:
+		if !a.prModal.IsVisible() || a.prModal.ItemID() != msg.itemID {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.prModal.SetSourceError(msg.err)
+		} else {
+			a.prModal.SetSource(msg.source)
+		}
+
+	case components.CreatePullRequestMsg:
+		if msg.AutoComplete && a.currentUser.ID == "" {
+			a.err = fmt.Errorf("cannot set auto-complete: current user is unknown")
+			return a, nil
+		}
+		a.prModal.SetVisible(false)
+		a.loading = true
+		a.statusMsg = ""
+		return a, createPullRequestCmd(a.gitRepo, a.client, msg, a.currentUser.ID)
+
+	case pullRequestCreatedMsg:
+		a.loading = false
+		a.err = msg.err
+		if msg.pr.ID != 0 {
+			a.statusMsg = fmt.Sprintf("Created pull request !%d %s", msg.pr.ID, msg.pr.Title)
+		}
+		// The work item gained a pull request link
+		delete(a.detailsCache, msg.itemID)
+		if a.viewMode == ViewDetail && a.detailView.ItemID() == msg.itemID {
+			return a, loadWorkItemDetailsCmd(a.client, msg.itemID)
+		}
+
+	case components.StateChangeRequestMsg:
+		a.stateModal.SetVisible(false)
+		a.loading = true
+		return a, updateWorkItemStateCmd(a.client, msg.Item.ID, msg.NewState, a.filterPanel.FilterState())
+
+	case stateChangedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("State changed to %s", msg.newState)
+		// Refresh work items to show updated state
+		return a, a.loadWorkItemsCmd()
+
+	case components.BranchCreateRequestMsg:
+		a.branchModal.SetVisible(false)
+		return a, createBranchCmd(a.gitRepo, a.client, msg)
+
+	case branchesLoadedMsg:
+		if a.branchModal.IsVisible() && a.branchModal.ItemID() == msg.itemID {
+			a.branchModal.SetBranches(msg.remote, msg.local, msg.remoteRefs, msg.defaultBase, msg.existing, msg.fetchErr)
+		}
+
+	case worktreesLoadedMsg:
+		if a.branchModal.IsVisible() && a.branchModal.ItemID() == msg.itemID {
+			a.branchModal.SetWorktrees(msg.path, msg.worktrees)
+		}
+
+	case components.WorktreeSwitchRequestMsg:
+		a.branchModal.SetVisible(false)
+		if _, err := os.Stat(msg.Path); err != nil {
+			a.err = err
+			return a, nil
+		}
+		a.gitRepo = git.Repo{Dir: msg.Path}
+		a.statusMsg = "Switched to worktree " + msg.Path
+		return a, detectCurrentWorkItemCmd(a.gitRepo, a.client, a.branchMatcher)
+
+	case components.BranchCreatedMsg:
+		a.statusMsg = fmt.Sprintf("Branch created: %s", msg.BranchName)
+		if msg.CheckedOut {
+			a.statusMsg = fmt.Sprintf("Checked out existing branch: %s", msg.BranchName)
+		}
+		if msg.Pushed {
+			a.statusMsg += ", pushed"
+		}
+		if msg.WorktreePath != "" {
+			// Git commands from here on run in the new worktree
+			a.gitRepo = git.Repo{Dir: msg.WorktreePath}
+			a.statusMsg += " in worktree " + msg.WorktreePath
+			cmds = append(cmds, detectCurrentWorkItemCmd(a.gitRepo, a.client, a.branchMatcher))
+		}
+		if msg.LinkErr != nil {
+			a.statusMsg += " (not linked: " + msg.LinkErr.Error() + ")"
+		} else {
+			a.statusMsg += fmt.Sprintf(" (linked to #%d)", msg.ItemID)
+			delete(a.detailsCache, msg.ItemID)
+		}
+
+	case components.BranchCreateErrorMsg:
+		a.err = msg.Err
+
+	case workPlanMsg:
+		a.workModal.SetPlan(msg.plan, msg.note)
+		a.workModal.SetSize(a.width, a.height)
+		a.workModal.SetVisible(true)
+
+	case components.WorkRequestMsg:
+		a.workModal.SetVisible(false)
+		a.loading = true
+		a.statusMsg = ""
+		return a, runWorkPlanCmd(a.gitRepo, a.client, msg.Plan, a.branchMatcher)
+
+	case workDoneMsg:
+		a.loading = false
+		delete(a.detailsCache, msg.plan.Item.ID)
+		cmds = append(cmds, a.loadWorkItemsCmd(), detectCurrentWorkItemCmd(a.gitRepo, a.client, a.branchMatcher))
+		if msg.err != nil {
+			a.err = msg.err
+			return a, tea.Batch(cmds...)
+		}
+
+		verb := "Started"
+		if msg.plan.Finish {
+			verb = "Finished"
+		}
+		a.statusMsg = fmt.Sprintf("%s work on #%d", verb, msg.plan.Item.ID)
+		if _, ok := msg.plan.Step(models.WorkStepPullRequest); ok {
+			cmds = append(cmds, a.openPullRequestModal(&msg.plan.Item))
+		}
+		return a, tea.Batch(cmds...)
+
+	case components.AssignRequestMsg:
+		a.assignModal.SetVisible(false)
+		a.loading = true
+		return a, assignWorkItemCmd(a.client, msg.Item.ID, msg.UserEmail, msg.UserName, a.filterPanel.FilterState())
+
+	case assignedMsg:
+		a.loading = false
+		a.statusMsg = fmt.Sprintf("Assigned to %s", msg.userName)
+		// Refresh work items to show updated assignment
+		return a, a.loadWorkItemsCmd()
+	}
+
+	// Update selected item in details panel
+	if cmd := a.updateSelectedItem(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	return a, tea.Batch(cmds...)
+}
+
+// View renders the application
+func (a App) View() string {
+	if a.width == 0 || a.height == 0 {
+		return "Loading..."
+	}
+
+	// Render state modal if visible
+	if a.stateModal.IsVisible() {
+		return a.stateModal.View()
+	}
+
+	// Render branch modal if visible
+	if a.branchModal.IsVisible() {
+		return a.branchModal.View()
+	}
+
+	// Render assign modal if visible
+	if a.assignModal.IsVisible() {
+		return a.assignModal.View()
+	}
+
+	// Render date range modal if visible
+	if a.dateModal.IsVisible() {
+		return a.dateModal.View()
+	}
+
+	// Render column chooser if visible
+	if a.columnModal.IsVisible() {
+		return a.columnModal.View()
+	}
+
+	// Render sort chooser if visible
+	if a.sortModal.IsVisible() {
+		return a.sortModal.View()
+	}
+
+	// Render link editor if visible
+	if a.linkModal.IsVisible() {
+		return a.linkModal.View()
+	}
+
+	// Render task breakdown prompt if visible
+	if a.taskModal.IsVisible() {
+		return a.taskModal.View()
+	}
+
+	// Render pull request form if visible
+	if a.prModal.IsVisible() {
+		return a.prModal.View()
+	}
+
+	// Render start/finish work preview if visible
+	if a.workModal.IsVisible() {
+		return a.workModal.View()
+	}
+
+	// Render pipeline run form if visible
+	if a.queueModal.IsVisible() {
+		return a.queueModal.View()
+	}
+
+	// Render comment composer if visible
+	if a.commentModal.IsVisible() {
+		return a.commentModal.View()
+	}
+
+	// Render notification inbox if visible
+	if a.inboxModal.IsVisible() {
+		return a.inboxModal.View()
+	}
+
+	// Render help overlay if visible
+	if a.helpPanel.IsVisible() {
+		_ = a.renderMainView()
+		help := a.helpPanel.View()
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, help)
+	}
+
+	// Render detail view if in detail mode
+	if a.viewMode == ViewDetail {
+		return a.detailView.View()
+	}
+
+	if a.viewMode == ViewGraph {
+		return a.graphView.View()
+	}
+
+	if a.viewMode == ViewPipelines {
+		return a.pipelinesView.View()
+	}
+
+	if a.viewMode == ViewRun {
+		return a.runView.View()
+	}
+
+	if a.viewMode == ViewPullRequests {
+		return a.prsView.View()
+	}
+
+	if a.viewMode == ViewDiff {
+		return a.diffView.View()
+	}
+
+	if a.viewMode == ViewMentions {
+		return a.mentionsView.View()
+	}
+
+	return a.renderMainView()
+}
+
+func (a *App) renderMainView() string {
+	// Calculate dimensions
+	// Borders add 2 chars per panel (1 left + 1 right)
+	// Two panels side by side = 4 total border overhead
 	filterWidth := int(float64(a.width) * 0.20)
 	if filterWidth < 20 {
 		filterWidth = 20
 	}
-	contentWidth := a.width - filterWidth - 4
+	contentWidth := a.width - filterWidth - 4
+
+	// Available height: total - title bar (1) - status bar (1) = a.height - 2
+	// Filter panel: content height + border (2) = available height
+	availableHeight := a.height - 2
+	filterContentHeight := availableHeight - 2
+
+	// Right side has two panels stacked, each with border (2 each = 4 total)
+	rightContentHeight := availableHeight - 4
+	workItemsHeight := int(float64(rightContentHeight) * 0.55)
+	if workItemsHeight < 8 {
+		workItemsHeight = 8
+	}
+	detailsHeight := rightContentHeight - workItemsHeight
+
+	// Title bar
+	title := a.styles.Title.Render("devops-tui")
+	projectInfo := a.styles.Subtitle.Render(fmt.Sprintf("%s/%s", a.client.Organization(), a.client.Project()))
+	titleBar := lipgloss.JoinHorizontal(lipgloss.Left, title, "  ", projectInfo)
+
+	// Loading indicator
+	if a.loading {
+		titleBar += "  " + a.styles.Subtitle.Render("Loading...")
+	}
+
+	// Error display
+	if a.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		titleBar += "  " + errStyle.Render(a.err.Error())
+	}
+
+	// Work item of the current git branch
+	if badge := a.renderCurrentItemBadge(); badge != "" {
+		titleBar += "  " + badge
+	}
+
+	// Unread notifications
+	if unread := a.inbox.Unread(); unread > 0 {
+		badge := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#1F2937")).
+			Background(lipgloss.Color("#F59E0B")).
+			Padding(0, 1).
+			Render(fmt.Sprintf("n: %d unread", unread))
+		titleBar += "  " + badge
+	}
+
+	// Status message
+	if a.statusMsg != "" {
+		statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+		titleBar += "  " + statusStyle.Render(a.statusMsg)
+	}
+
+	// Set panel sizes (content dimensions, borders added by styles)
+	a.filterPanel.SetSize(filterWidth, filterContentHeight)
+	a.workItemsPanel.SetSize(contentWidth, workItemsHeight)
+	a.detailsPanel.SetSize(contentWidth, detailsHeight)
+
+	// Render panels
+	filterView := a.filterPanel.View()
+	workItemsView := a.workItemsPanel.View()
+	detailsView := a.detailsPanel.View()
+
+	// Right side (work items + details)
+	rightSide := lipgloss.JoinVertical(lipgloss.Left, workItemsView, detailsView)
+
+	// Main content
+	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, filterView, rightSide)
+
+	// Status bar
+	statusBar := a.renderStatusBar()
+
+	// Combine all
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		mainContent,
+		statusBar,
+	)
+}
+
+// renderCurrentItemBadge renders the work item the checked out branch
+// belongs to
+func (a *App) renderCurrentItemBadge() string {
+	if a.currentItemID == 0 {
+		return ""
+	}
+
+	text := fmt.Sprintf("⎇ #%d", a.currentItemID)
+	if a.currentItem != nil {
+		title := []rune(a.currentItem.Title)
+		if len(title) > 30 {
+			title = append(title[:29], '…')
+		}
+		text += " " + string(title)
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#059669")).
+		Padding(0, 1).
+		Render(text)
+}
+
+func (a *App) renderStatusBar() string {
+	var parts []string
+
+	// Panel indicator
+	panelName := "Filter"
+	if a.activePanel == PanelWorkItems {
+		panelName = "Work Items"
+	}
+	parts = append(parts, a.styles.HelpKey.Render("Panel")+": "+panelName)
+
+	// Short help
+	help := components.ShortHelp(a.keys, a.styles)
+	parts = append(parts, help)
+
+	return a.styles.StatusBar.Width(a.width).Render(strings.Join(parts, "  "))
+}
+
+func (a *App) nextPanel() {
+	if a.activePanel == PanelFilter {
+		a.activePanel = PanelWorkItems
+	} else {
+		a.activePanel = PanelFilter
+	}
+}
+
+func (a *App) prevPanel() {
+	if a.activePanel == PanelWorkItems {
+		a.activePanel = PanelFilter
+	} else {
+		a.activePanel = PanelWorkItems
+	}
+}
+
+func (a *App) updateFocus() {
+	a.filterPanel.SetFocused(a.activePanel == PanelFilter)
+	a.workItemsPanel.SetFocused(a.activePanel == PanelWorkItems)
+}
+
+func (a *App) updateSizes() {
+	a.helpPanel.SetSize(a.width, a.height)
+	a.detailView.SetSize(a.width, a.height)
+	a.graphView.SetSize(a.width, a.height)
+	a.pipelinesView.SetSize(a.width, a.height)
+	a.runView.SetSize(a.width, a.height)
+	a.prsView.SetSize(a.width, a.height)
+	a.diffView.SetSize(a.width, a.height)
+	a.mentionsView.SetSize(a.width, a.height)
+	a.updateFocus()
+}
+
+// updateSelectedItem shows the selected item in the details panel and
+// loads its full details if only the list fields are known
+func (a *App) updateSelectedItem() tea.Cmd {
+	item := a.workItemsPanel.SelectedItem()
+	a.detailsPanel.SetItem(item)
+
+	if item == nil || item.DetailsLoaded || item.ID == a.detailsPending {
+		return nil
+	}
+	a.detailsPending = item.ID
+	return loadWorkItemDetailsCmd(a.client, item.ID)
+}
+
+// goToCurrentItem selects the work item of the current git branch in the
+// list, or opens it in the detail view when the filters leave it out
+func (a *App) goToCurrentItem() tea.Cmd {
+	if a.currentItemID == 0 {
+		a.statusMsg = a.noCurrentItemStatus()
+		return nil
+	}
+
+	if a.workItemsPanel.SelectItem(a.currentItemID) {
+		a.activePanel = PanelWorkItems
+		a.updateFocus()
+		return a.updateSelectedItem()
+	}
+
+	if a.currentItem == nil {
+		a.statusMsg = fmt.Sprintf("Work item #%d not found", a.currentItemID)
+		return nil
+	}
+	item := *a.currentItem
+	return func() tea.Msg { return components.ViewWorkItemMsg{Item: item} }
+}
+
+// noCurrentItemStatus explains why there is no current work item
+func (a *App) noCurrentItemStatus() string {
+	if a.currentBranch == "" {
+		return "Not on a git branch"
+	}
+	return fmt.Sprintf("No work item ID in branch %s", a.currentBranch)
+}
+
+// landOnCurrentItem selects the current branch's work item after startup
+func (a *App) landOnCurrentItem() {
+	if a.landed || !a.branchDetected || a.workItems == nil {
+		return
+	}
+	a.landed = true
+	if a.currentItemID != 0 {
+		a.workItemsPanel.SelectItem(a.currentItemID)
+	}
+}
+
+// startWorkCmd previews starting work on an item: assigning it to me,
+// moving it to its in progress state and the current sprint, and checking
+// out its branch
+func (a *App) startWorkCmd(item models.WorkItem) tea.Cmd {
+	plan := models.WorkPlan{Item: item}
+
+	me := a.currentUser
+	if me.UniqueName != "" && !strings.EqualFold(item.AssignedToAccount(), me.UniqueName) {
+		plan.Steps = append(plan.Steps, models.WorkStep{
+			Kind:    models.WorkStepAssign,
+			Value:   me.UniqueName,
+			Label:   me.DisplayName,
+			From:    item.AssignedToAccount(),
+			Enabled: true,
+		})
+	}
+
+	state := models.WorkflowState(item.Type, a.cfg.WorkStates.Start, a.statesByType, "Active", models.StateCategoryInProgress)
+	if state != string(item.State) {
+		plan.Steps = append(plan.Steps, models.WorkStep{
+			Kind:    models.WorkStepState,
+			Value:   state,
+			From:    string(item.State),
+			Enabled: true,
+		})
+	}
+
+	for _, iter := range a.iterations {
+		if !iter.IsCurrent() {
+			continue
+		}
+		if iter.Path != item.IterationPath {
+			plan.Steps = append(plan.Steps, models.WorkStep{
+				Kind:    models.WorkStepIteration,
+				Value:   iter.Path,
+				Label:   iter.Name,
+				From:    item.IterationPath,
+				Enabled: true,
+			})
+		}
+		break
+	}
+
+	return planWorkCmd(a.gitRepo, plan, a.branchNamer, me.Alias())
+}
+
+// finishWorkCmd previews finishing work on an item: resolving it and
+// optionally creating its pull request
+func (a *App) finishWorkCmd(item models.WorkItem) tea.Cmd {
+	plan := models.WorkPlan{Item: item, Finish: true}
+
+	state := models.WorkflowState(item.Type, a.cfg.WorkStates.Finish, a.statesByType, "Resolved",
+		models.StateCategoryResolved, models.StateCategoryCompleted)
+	if state != string(item.State) {
+		plan.Steps = append(plan.Steps, models.WorkStep{
+			Kind:    models.WorkStepState,
+			Value:   state,
+			From:    string(item.State),
+			Enabled: true,
+		})
+	}
+
+	return planWorkCmd(a.gitRepo, plan, a.branchNamer, "")
+}
+
+// openLinkModal opens the link editor for an item, loading its
+// relations first if only the list fields are known
+func (a *App) openLinkModal(item *models.WorkItem) tea.Cmd {
+	if item == nil {
+		return nil
+	}
+	a.linkModal.SetItem(item)
+	a.linkModal.SetCandidates(a.workItems)
+	a.linkModal.SetSize(a.width, a.height)
+	a.linkModal.SetVisible(true)
+
+	if item.DetailsLoaded || item.ID == a.detailsPending {
+		return nil
+	}
+	a.detailsPending = item.ID
+	return loadWorkItemDetailsCmd(a.client, item.ID)
+}
+
+// openPullRequestModal opens the pull request form for an item and starts
+// detecting the branch it is created from
+func (a *App) openPullRequestModal(item *models.WorkItem) tea.Cmd {
+	if item == nil {
+		return nil
+	}
+	a.prModal.SetItem(item)
+	a.prModal.SetMembers(a.teamMembers)
+	a.prModal.SetSize(a.width, a.height)
+	a.prModal.SetVisible(true)
+	return detectPullRequestSourceCmd(a.gitRepo, a.client, item.ID)
+}
+
+// loadGraphCmd starts loading the dependency graph of the graph item, or of
+// the selected sprint (the current one when the filter shows all sprints)
+func (a *App) loadGraphCmd(scope components.GraphScope) tea.Cmd {
+	a.graphSeq++
+	seq := a.graphSeq
+
+	if scope == components.GraphScopeItem {
+		a.graphView.SetLoading(fmt.Sprintf("#%d", a.graphItemID), scope)
+		return loadGraphCmd(a.client, seq, nil, []int{a.graphItemID}, 2, a.statesByType)
+	}
+
+	sprint := a.filterPanel.FilterState().GetSelectedSprint()
+	title := sprint
+	if sprint == models.AllValue {
+		sprint, title = "", ""
+		for _, it := range a.iterations {
+			if it.IsCurrent() {
+				sprint, title = it.Path, it.Name+" (current)"
+				break
+			}
+		}
+	}
+	a.graphView.SetLoading(title, scope)
+	if sprint == "" {
+		a.graphView.SetError(fmt.Errorf("no current sprint"))
+		return nil
+	}
+	query := &models.WorkItemQuery{Sprint: sprint}
+	return loadGraphCmd(a.client, seq, query, nil, 0, a.statesByType)
+}
+
+// loadPipelinesCmd starts loading the pipelines and the recent runs of one
+// of them, 0 for all
+func (a *App) loadPipelinesCmd(definitionID int) tea.Cmd {
+	a.pipelineSeq++
+	a.pipelineDefID = definitionID
+	a.pipelinesView.SetLoading(definitionID)
+	return loadPipelinesCmd(a.gitRepo, a.client, a.pipelineSeq, definitionID)
+}
+
+// loadRunCmd starts loading the shown run and its timeline
+func (a *App) loadRunCmd() tea.Cmd {
+	a.runSeq++
+	return loadRunCmd(a.client, a.runSeq, a.runView.Run().ID)
+}
+
+// loadPullRequestsCmd starts loading the active pull requests
+func (a *App) loadPullRequestsCmd() tea.Cmd {
+	a.prSeq++
+	a.prsView.SetLoading()
+	return loadPullRequestsCmd(a.client, a.prSeq, a.myTeamIDs == nil)
+}
+
+// loadDiffCmd starts loading the changes and threads of the pull request in
+// the diff view
+func (a *App) loadDiffCmd() tea.Cmd {
+	a.diffSeq++
+	return loadDiffCmd(a.gitRepo, a.client, a.diffSeq, a.diffView.PullRequest())
+}
+
+// loadMentionsCmd starts reloading the mentions view
+func (a *App) loadMentionsCmd() tea.Cmd {
+	a.mentionSeq++
+	a.mentionsView.SetLoading()
+	return loadMentionsCmd(a.client, a.mentionSeq, a.currentUser.ID)
+}
+
+// loadThreadsCmd starts reloading the comment threads in the diff view
+func (a *App) loadThreadsCmd() tea.Cmd {
+	a.threadSeq++
+	return loadThreadsCmd(a.client, a.threadSeq, a.diffView.PullRequest())
+}
+
+// resolveArtifactsCmd starts resolving the pull request, commit, branch and
+// build links of the item in the detail view
+func (a *App) resolveArtifactsCmd() tea.Cmd {
+	a.artifactSeq++
+	urls := a.detailView.ArtifactURLs()
+	if len(urls) == 0 {
+		return nil
+	}
+	return resolveArtifactsCmd(a.client, a.artifactSeq, urls)
+}
+
+// saveProfileState persists the column, sort and grouping choices of the active profile
+func (a *App) saveProfileState() {
+	state := &config.ProfileState{
+		Columns: a.workItemsPanel.Columns(),
+		Sort:    a.workItemsPanel.SortKeys(),
+		GroupBy: a.workItemsPanel.GroupBy(),
+	}
+	if err := config.SaveProfileState(a.cfg.ProfileName(), state); err != nil {
+		a.err = err
+	}
+}
+
+// listFields returns the fields needed to render, sort and group the work items list
+func (a *App) listFields() []string {
+	fields := models.ColumnFields(a.workItemsPanel.Columns())
+	for _, sk := range a.workItemsPanel.SortKeys() {
+		fields = append(fields, sk.Field)
+	}
+	if groupBy := a.workItemsPanel.GroupBy(); groupBy != "" {
+		fields = append(fields, groupBy, models.FieldStoryPoints, models.FieldRemainingWork)
+	}
+	return fields
+}
+
+// loadWorkItemsCmd reloads the work items with the current filters
+func (a *App) loadWorkItemsCmd() tea.Cmd {
+	return loadWorkItemsCmd(a.client, a.filterPanel.FilterState(), a.listFields())
+}
+
+// Message types
+
+type dataLoadedMsg struct {
+	iterations   []models.Iteration
+	areas        []models.Area
+	statesByType map[string][]models.WorkItemStateInfo
+	teamMembers  []models.TeamMember
+	tags         []string
+	fieldDefs    []models.FieldDefinition
+	currentUser  models.TeamMember
+}
+
+type workItemsLoadedMsg struct {
+	items []models.WorkItem
+}
+
+type workItemDetailsLoadedMsg struct {
+	item models.WorkItem
+}
+
+type errMsg struct {
+	err error
+}
+
+type stateChangedMsg struct {
+	newState string
+}
+
+type assignedMsg struct {
+	userName string
+}
+
+type workItemSearchResultsMsg struct {
+	seq   int
+	items []models.WorkItem
+	err   error
+}
+
+type graphLoadedMsg struct {
+	seq   int
+	graph *models.DependencyGraph
+	err   error
+}
+
+type artifactsResolvedMsg struct {
+	seq       int
+	artifacts map[string]models.Artifact
+}
+
+type artifactRefreshMsg struct {
+	seq int
+}
+
+type pipelinesLoadedMsg struct {
+	seq           int
+	definitions   []models.BuildDefinition
+	runs          []models.Build
+	localBranches []string
+	err           error
+}
+
+type pipelineRefreshMsg struct {
+	seq int
+}
+
+type queueFormLoadedMsg struct {
+	definitionID int
+	definition   models.BuildDefinition
+	err          error
+}
+
+type pipelineParametersLoadedMsg struct {
+	definitionID int
+	branch       string
+	params       []models.PipelineParameter
+	err          error
+}
+
+type runQueuedMsg struct {
+	run models.Build
+	err error
+}
+
+type runActionDoneMsg struct {
+	status string
+	err    error
+}
+
+type runLoadedMsg struct {
+	seq     int
+	run     models.Build
+	records []models.TimelineRecord
+	err     error
+}
+
+type runRefreshMsg struct {
+	seq int
+}
+
+type buildLogLoadedMsg struct {
+	seq       int
+	recordID  string
+	startLine int
+	lines     []string
+	err       error
+}
+
+type logSavedMsg struct {
+	path  string
+	lines int
+	err   error
+}
+
+type notificationPollMsg struct{}
+
+type notificationsPolledMsg struct {
+	items []models.WorkItem
+	err   error
+}
+
+type pullRequestsLoadedMsg struct {
+	seq     int
+	prs     []models.PullRequest
+	teamIDs []string // nil when not loaded
+	err     error
+}
+
+type reviewLoadMsg struct {
+	seq int
+	pr  models.PullRequest
+}
+
+type pullRequestReviewLoadedMsg struct {
+	id     int
+	review models.PullRequestReview
+	err    error
+}
+
+type pullRequestActionDoneMsg struct {
+	status string
+	err    error
+}
+
+type mentionsLoadedMsg struct {
+	seq      int
+	mentions []models.Mention
+	skipped  []error // Items whose comments couldn't be loaded
+	err      error
+}
+
+type identitySearchResultsMsg struct {
+	seq    int
+	people []models.TeamMember
+	err    error
+}
+
+type commentPostedMsg struct {
+	status string
+	err    error
+}
+
+type diffLoadedMsg struct {
+	seq       int
+	iteration models.PullRequestIteration
+	changes   []models.PullRequestChange
+	threads   []models.CommentThread
+	local     bool // Both commits are in the local repository
+	err       error
+}
+
+type fileDiffLoadedMsg struct {
+	seq    int
+	path   string
+	lines  []models.DiffLine
+	binary bool
+	err    error
+}
+
+type threadsLoadedMsg struct {
+	seq     int
+	threads []models.CommentThread
+	err     error
+}
+
+type threadStatusChangedMsg struct {
+	status string
+	err    error
+}
+
+type pullRequestSourceMsg struct {
+	itemID int
+	source components.PullRequestSource
+	err    error
+}
+
+type pullRequestCreatedMsg struct {
+	itemID int
+	pr     models.PullRequest
+	err    error // Set when the pull request was created, but a later step failed
+}
+
+type workPlanMsg struct {
+	plan models.WorkPlan
+	note string
+}
+
+type workDoneMsg struct {
+	plan models.WorkPlan
+	err  error // Set when a step failed; the steps before it were rolled back
+}
+
+type branchesLoadedMsg struct {
+	itemID      int
+	remote      string // "" when the repository has no remote to push to
+	local       []string
+	remoteRefs  []string
+	defaultBase string
+	existing    []string // The item's branches, local or remote-tracking
+	fetchErr    error
+}
+
+type worktreesLoadedMsg struct {
+	itemID    int
+	path      string // Where a new worktree for the item goes
+	worktrees []git.Worktree
+}
+
+type currentWorkItemMsg struct {
+	branch string
+	id     int              // 0 when the branch names no work item
+	item   *models.WorkItem // nil when the work item couldn't be loaded
+}
+
+type tasksCreatedMsg struct {
+	parentID int
+	count    int
+}
+
+type linksUpdatedMsg struct {
+	itemIDs []int // Items whose links changed
+	status  string
+}
+
+// Commands
+
+func loadDataCmd(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		iterations, err := client.GetIterations()
+		if err != nil {
+			return errMsg{err: err}
+		}
+		areas, err := client.GetAreas()
+		if err != nil {
+			return errMsg{err: err}
+		}
+		statesByType, err := client.GetAllWorkItemTypeStates()
+		if err != nil {
+			// Non-fatal - we can still work with hardcoded states
+			statesByType = make(map[string][]models.WorkItemStateInfo)
+		}
+		teamMembers, err := client.GetTeamMembers()
+		if err != nil {
+			// Non-fatal - we can still work without team members
+			teamMembers = []models.TeamMember{}
+		}
+		tags, err := client.GetTags()
+		if err != nil {
+			// Non-fatal - the tag filter will only offer "All"
+			tags = []string{}
+		}
+		fieldDefs, err := client.GetFields()
+		if err != nil {
+			// Non-fatal - the column chooser will only offer the known fields
+			fieldDefs = []models.FieldDefinition{}
+		}
+		currentUser, err := client.GetCurrentUser()
+		if err != nil {
+			// Non-fatal - only needed to assign items to yourself
+			currentUser = models.TeamMember{}
+		}
+		return dataLoadedMsg{iterations: iterations, areas: areas, statesByType: statesByType, teamMembers: teamMembers, tags: tags, fieldDefs: fieldDefs, currentUser: currentUser}
+	}
+}
+
+func loadWorkItemsCmd(client *api.Client, filterState *models.FilterState, fields []string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := client.QueryWorkItems(filterState.Query(), fields)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return workItemsLoadedMsg{items: items}
+	}
+}
+
+func loadWorkItemDetailsCmd(client *api.Client, itemID int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := client.GetWorkItem(itemID)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return workItemDetailsLoadedMsg{item: *item}
+	}
+}
+
+func updateWorkItemStateCmd(client *api.Client, itemID int, newState string, filterState *models.FilterState) tea.Cmd {
+	return func() tea.Msg {
+		err := client.UpdateWorkItemState(itemID, newState)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return stateChangedMsg{newState: newState}
+	}
+}
+
+func searchWorkItemsCmd(client *api.Client, query string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		items, err := client.SearchWorkItems(query, 50)
+		return workItemSearchResultsMsg{seq: seq, items: items, err: err}
+	}
+}
+
+// loadGraphCmd loads the items of a query (or the given root items) and
+// their links up to depth, and builds their dependency graph
+func loadGraphCmd(client *api.Client, seq int, query *models.WorkItemQuery, rootIDs []int, depth int, statesByType map[string][]models.WorkItemStateInfo) tea.Cmd {
+	return func() tea.Msg {
+		if query != nil {
+			ids, err := client.QueryWorkItemIDs(*query)
+			if err != nil {
+				return graphLoadedMsg{seq: seq, err: err}
+			}
+			rootIDs = ids
+		}
+
+		items, err := client.CollectLinkedItems(rootIDs, depth)
+		if err != nil {
+			return graphLoadedMsg{seq: seq, err: err}
+		}
+		return graphLoadedMsg{seq: seq, graph: models.BuildDependencyGraph(items, statesByType)}
+	}
+}
+
+// notificationsPolled is how many changed items a poll fetches per page
+const notificationsPolled = 50
+
+// notificationPagesPolled caps how many pages one poll fetches
+const notificationPagesPolled = 10
+
+// scheduleNotificationPollCmd polls for changes to my items after the
+// configured interval, or does nothing when polling is off
+func (a *App) scheduleNotificationPollCmd() tea.Cmd {
+	interval := a.cfg.NotificationInterval()
+	if interval == 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg { return notificationPollMsg{} })
+}
+
+// pollNotificationsCmd loads the items assigned to me, created by me or
+// mentioning me that others changed after since (and, at that time, after
+// the item afterID)
+func pollNotificationsCmd(client *api.Client, since time.Time, afterID int) tea.Cmd {
+	return func() tea.Msg {
+		// Pages continue after the last change seen until one isn't full or
+		// brings nothing new; the rest waits for the next poll
+		var items []models.WorkItem
+		seen := make(map[int]bool)
+		for range notificationPagesPolled {
+			page, err := client.GetMyChangedWorkItems(since, afterID, notificationsPolled)
+			if err != nil {
+				return notificationsPolledMsg{err: fmt.Errorf("polling for changes: %w", err)}
+			}
+			added := 0
+			for _, item := range page {
+				if !seen[item.ID] {
+					seen[item.ID] = true
+					items = append(items, item)
+					added++
+				}
+			}
+			if len(page) < notificationsPolled || added == 0 {
+				break
+			}
+			last := page[len(page)-1]
+			since, afterID = last.ChangedDate, last.ID
+		}
+		return notificationsPolledMsg{items: items}
+	}
+}
+
+// notifyTerminalCmd rings the terminal bell and sends an OSC 9 desktop
+// notification about new notifications, as configured. They go through the
+// program's output so they don't land inside a frame.
+func notifyTerminalCmd(out *Terminal, cfg config.Notifications, latest models.Notification, added int) tea.Cmd {
+	if !cfg.Bell && !cfg.Desktop {
+		return nil
+	}
+	return func() tea.Msg {
+		var seq string
+		if cfg.Desktop {
+			text := fmt.Sprintf("#%d %s (%s)", latest.Item.ID, latest.Item.Title, latest.Reason)
+			if added > 1 {
+				text = fmt.Sprintf("%d of your work items changed, latest %s", added, text)
+			}
+			// Control characters would end the sequence early
+			text = strings.Map(func(r rune) rune {
+				if r < 0x20 || r == 0x7f {
+					return ' '
+				}
+				return r
+			}, text)
+			seq += "\x1b]9;devops-tui: " + text + "\x07"
+		}
+		if cfg.Bell {
+			seq += "\a"
+		}
+		out.WriteString(seq)
+		return nil
+	}
+}
+
+// artifactRefreshInterval is how often the detail view's artifact links are
+// resolved again while it stays open
+const artifactRefreshInterval = 30 * time.Second
+
+// pipelineRefreshInterval is how often the pipelines view is reloaded while
+// runs are in progress
+const pipelineRefreshInterval = 10 * time.Second
+
+// pipelineRunsShown is how many recent runs the pipelines view loads
+const pipelineRunsShown = 50
+
+// loadPipelinesCmd loads the project's pipelines, the recent runs of one of
+// them (0 for all) and the local git branches for the "my branches" filter
+func loadPipelinesCmd(gitRepo git.Repo, client *api.Client, seq int, definitionID int) tea.Cmd {
+	return func() tea.Msg {
+		definitions, err := client.GetBuildDefinitions()
+		if err != nil {
+			return pipelinesLoadedMsg{seq: seq, err: fmt.Errorf("loading pipelines: %w", err)}
+		}
+		runs, err := client.GetRecentBuilds(definitionID, pipelineRunsShown)
+		if err != nil {
+			return pipelinesLoadedMsg{seq: seq, err: fmt.Errorf("loading pipeline runs: %w", err)}
+		}
+		// Outside a git repository only the runs I queued count as mine
+		local, _, _ := gitRepo.ListBranches()
+		return pipelinesLoadedMsg{seq: seq, definitions: definitions, runs: runs, localBranches: local}
+	}
+}
+
+func loadQueueFormCmd(client *api.Client, definitionID int) tea.Cmd {
+	return func() tea.Msg {
+		def, err := client.GetBuildDefinition(definitionID)
+		if err != nil {
+			return queueFormLoadedMsg{definitionID: definitionID, err: fmt.Errorf("loading pipeline: %w", err)}
+		}
+		return queueFormLoadedMsg{definitionID: definitionID, definition: def}
+	}
+}
+
+func loadPipelineParametersCmd(client *api.Client, def models.BuildDefinition, branch string) tea.Cmd {
+	return func() tea.Msg {
+		params, err := client.GetPipelineParameters(def, branch)
+		return pipelineParametersLoadedMsg{definitionID: def.ID, branch: branch, params: params, err: err}
+	}
+}
+
+func queueRunCmd(client *api.Client, run models.NewPipelineRun) tea.Cmd {
+	return func() tea.Msg {
+		build, err := client.QueuePipelineRun(run)
+		return runQueuedMsg{run: build, err: err}
+	}
+}
+
+func cancelRunCmd(client *api.Client, run models.Build) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.CancelBuild(run.ID); err != nil {
+			return runActionDoneMsg{err: err}
+		}
+		return runActionDoneMsg{status: fmt.Sprintf("Cancelling %s", run.Title())}
+	}
+}
+
+// retryStagesCmd reruns stages of a run, the failed and canceled ones when
+// none are given
+func retryStagesCmd(client *api.Client, run models.Build, stages []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(stages) == 0 {
+			records, err := client.GetBuildTimeline(run.ID)
+			if err != nil {
+				return runActionDoneMsg{err: fmt.Errorf("loading timeline: %w", err)}
+			}
+			stages = models.RetryableStages(records)
+			if len(stages) == 0 {
+				return runActionDoneMsg{err: fmt.Errorf("%s has no failed stages to retry", run.Title())}
+			}
+		}
+
+		for _, stage := range stages {
+			if err := client.RetryBuildStage(run.ID, stage); err != nil {
+				return runActionDoneMsg{err: err}
+			}
+		}
+		return runActionDoneMsg{status: fmt.Sprintf("Retrying %s of %s", strings.Join(stages, ", "), run.Title())}
+	}
+}
+
+// reviewLoadDelay is how long the cursor rests on a pull request before its
+// details are loaded
+const reviewLoadDelay = 250 * time.Millisecond
+
+// loadPullRequestsCmd loads the active pull requests, and the teams I belong
+// to when asked. Teams that can't be loaded don't count.
+func loadPullRequestsCmd(client *api.Client, seq int, loadTeams bool) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.GetActivePullRequests()
+		if err != nil {
+			return pullRequestsLoadedMsg{seq: seq, err: fmt.Errorf("loading pull requests: %w", err)}
+		}
+		msg := pullRequestsLoadedMsg{seq: seq, prs: prs}
+		if loadTeams {
+			msg.teamIDs, _ = client.GetMyTeamIDs()
+			if msg.teamIDs == nil {
+				msg.teamIDs = []string{}
+			}
+		}
+		return msg
+	}
+}
+
+// loadPullRequestReviewCmd loads the checks and changed files of a pull
+// request
+func loadPullRequestReviewCmd(client *api.Client, pr models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		checks, err := client.GetPullRequestChecks(pr)
+		if err != nil {
+			return pullRequestReviewLoadedMsg{id: pr.ID, err: err}
+		}
+		iteration, changes, err := client.GetPullRequestChanges(pr)
+		if err != nil {
+			return pullRequestReviewLoadedMsg{id: pr.ID, err: err}
+		}
+		review := models.PullRequestReview{Checks: checks, Iteration: iteration, Changes: changes}
+		return pullRequestReviewLoadedMsg{id: pr.ID, review: review}
+	}
+}
+
+func votePullRequestCmd(client *api.Client, pr models.PullRequest, reviewerID string, vote int) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.VotePullRequest(pr, reviewerID, vote); err != nil {
+			return pullRequestActionDoneMsg{err: err}
+		}
+		r := models.Reviewer{Vote: vote}
+		return pullRequestActionDoneMsg{status: fmt.Sprintf("Voted %s on !%d", r.VoteLabel(), pr.ID)}
+	}
+}
+
+// postCommentCmd replies to a thread, or starts one on a line of a file or
+// on the pull request as a whole
+func postCommentCmd(client *api.Client, target components.CommentTarget, text string, mentions []models.TeamMember) tea.Cmd {
+	return func() tea.Msg {
+		if item := target.WorkItem; item != nil {
+			if err := client.AddWorkItemComment(item.ID, models.CommentHTML(text, mentions)); err != nil {
+				return commentPostedMsg{err: err}
+			}
+			return commentPostedMsg{status: fmt.Sprintf("Commented on #%d", item.ID)}
+		}
+
+		if target.PullRequest == nil {
+			return commentPostedMsg{err: fmt.Errorf("nothing to comment on")}
+		}
+		pr := *target.PullRequest
+		text = models.CommentMarkdown(text, mentions)
+
+		if target.Thread != nil {
+			if err := client.ReplyToThread(pr, target.Thread.ID, target.Thread.LastCommentID(), text); err != nil {
+				return commentPostedMsg{err: err}
+			}
+			return commentPostedMsg{status: fmt.Sprintf("Replied on !%d", pr.ID)}
+		}
+
+		thread := models.NewCommentThread{Text: text}
+		status := fmt.Sprintf("Commented on !%d", pr.ID)
+		if change := target.Change; change != nil {
+			thread.Path = change.Path
+			thread.Line = target.Line
+			thread.LeftSide = target.LeftSide
+			thread.ChangeTrackingID = change.ChangeTrackingID
+			thread.Iteration = target.Iteration
+			status = fmt.Sprintf("Commented on %s:%d", change.Path, target.Line)
+		}
+		if err := client.AddPullRequestThread(pr, thread); err != nil {
+			return commentPostedMsg{err: err}
+		}
+		return commentPostedMsg{status: status}
+	}
+}
+
+// mentionItemsChecked is how many of the items mentioning me are looked
+// through for the comments that do
+const mentionItemsChecked = 25
+
+// loadMentionsCmd loads the recent discussion comments mentioning me
+func loadMentionsCmd(client *api.Client, seq int, identityID string) tea.Cmd {
+	return func() tea.Msg {
+		if identityID == "" {
+			return mentionsLoadedMsg{seq: seq, err: fmt.Errorf("loading mentions: current user is unknown")}
+		}
+		mentions, skipped, err := client.GetMentions(identityID, mentionItemsChecked)
+		if err != nil {
+			return mentionsLoadedMsg{seq: seq, err: fmt.Errorf("loading mentions: %w", err)}
+		}
+		return mentionsLoadedMsg{seq: seq, mentions: mentions, skipped: skipped}
+	}
+}
+
+// searchIdentitiesCmd searches people in the organization to mention
+func searchIdentitiesCmd(client *api.Client, query string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		people, err := client.SearchIdentities(query)
+		return identitySearchResultsMsg{seq: seq, people: people, err: err}
+	}
+}
+
+// loadDiffCmd loads the latest iteration of a pull request, its changed
+// files and comment threads. Diffs come from the local repository when it
+// has both ends of the iteration.
+func loadDiffCmd(gitRepo git.Repo, client *api.Client, seq int, pr models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		iteration, changes, err := client.GetPullRequestChanges(pr)
+		if err != nil {
+			return diffLoadedMsg{seq: seq, err: err}
+		}
+		threads, err := client.GetPullRequestThreads(pr)
+		if err != nil {
+			return diffLoadedMsg{seq: seq, err: err}
+		}
+		local := gitRepo.IsGitRepo() && gitRepo.HasCommit(iteration.BaseCommit) && gitRepo.HasCommit(iteration.SourceCommit)
+		return diffLoadedMsg{seq: seq, iteration: iteration, changes: changes, threads: threads, local: local}
+	}
+}
+
+// loadFileDiffCmd diffs a changed file between the merge base and the
+// iteration, with `git diff` when the commits are local and otherwise by
+// comparing both versions fetched from the repository
+func loadFileDiffCmd(gitRepo git.Repo, client *api.Client, seq int, pr models.PullRequest, iteration models.PullRequestIteration, change models.PullRequestChange, local bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := fileDiffLoadedMsg{seq: seq, path: change.Path}
+		if local {
+			diff, err := gitRepo.DiffFile(iteration.BaseCommit, iteration.SourceCommit,
+				strings.TrimPrefix(change.OldPath(), "/"), strings.TrimPrefix(change.Path, "/"))
+			if err == nil {
+				msg.lines, msg.binary = models.ParseUnifiedDiff(diff)
+				return msg
+			}
+		}
+		if iteration.BaseCommit == "" || iteration.SourceCommit == "" {
+			msg.err = fmt.Errorf("the pull request's commits are unknown")
+			return msg
+		}
+
+		var oldContent, newContent string
+		if !change.IsAdded() {
+			content, binary, err := client.GetFileAtCommit(pr, change.OldPath(), iteration.BaseCommit)
+			if err != nil || binary {
+				msg.binary, msg.err = binary, err
+				return msg
+			}
+			oldContent = content
+		}
+		if !change.IsDeleted() {
+			content, binary, err := client.GetFileAtCommit(pr, change.Path, iteration.SourceCommit)
+			if err != nil || binary {
+				msg.binary, msg.err = binary, err
+				return msg
+			}
+			newContent = content
+		}
+		msg.lines = models.DiffFiles(models.SplitLines(oldContent), models.SplitLines(newContent))
+		return msg
+	}
+}
 
-	// Available height: total - title bar (1) - status bar (1) = a.height - 2
-	// Filter panel: content height + border (2) = available height
-	availableHeight := a.height - 2
-	filterContentHeight := availableHeight - 2
+func loadThreadsCmd(client *api.Client, seq int, pr models.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		threads, err := client.GetPullRequestThreads(pr)
+		return threadsLoadedMsg{seq: seq, threads: threads, err: err}
+	}
+}
 
-	// Right side has two panels stacked, each with border (2 each = 4 total)
-	rightContentHeight := availableHeight - 4
-	workItemsHeight := int(float64(rightContentHeight) * 0.55)
-	if workItemsHeight < 8 {
-		workItemsHeight = 8
+func setThreadStatusCmd(client *api.Client, pr models.PullRequest, threadID int, status string) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.SetThreadStatus(pr, threadID, status); err != nil {
+			return threadStatusChangedMsg{err: err}
+		}
+		if status == models.ThreadActive {
+			return threadStatusChangedMsg{status: "Thread reactivated"}
+		}
+		return threadStatusChangedMsg{status: "Thread resolved"}
 	}
-	detailsHeight := rightContentHeight - workItemsHeight
+}
 
-	// Title bar
-	title := a.styles.Title.Render("devops-tui")
-	projectInfo := a.styles.Subtitle.Render(fmt.Sprintf("%s/%s", a.client.Organization(), a.client.Project()))
-	titleBar := lipgloss.JoinHorizontal(lipgloss.Left, title, "  ", projectInfo)
+// runRefreshInterval is how often a run in progress and the shown log are
+// reloaded
+const runRefreshInterval = 3 * time.Second
 
-	// Loading indicator
-	if a.loading {
-		titleBar += "  " + a.styles.Subtitle.Render("Loading...")
+func loadRunCmd(client *api.Client, seq int, buildID int) tea.Cmd {
+	return func() tea.Msg {
+		run, err := client.GetBuild("", buildID)
+		if err != nil {
+			return runLoadedMsg{seq: seq, err: fmt.Errorf("loading run: %w", err)}
+		}
+		records, err := client.GetBuildTimeline(buildID)
+		if err != nil {
+			return runLoadedMsg{seq: seq, err: fmt.Errorf("loading timeline: %w", err)}
+		}
+		return runLoadedMsg{seq: seq, run: run, records: records}
 	}
+}
 
-	// Error display
-	if a.err != nil {
-		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
-		titleBar += "  " + errStyle.Render(a.err.Error())
+// loadBuildLogCmd loads the log of a stage, job or step from startLine on.
+// Logs being written are tailed by loading only the lines added since.
+func loadBuildLogCmd(client *api.Client, seq int, buildID int, record models.TimelineRecord, startLine int) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := client.GetBuildLog(buildID, record.LogID, startLine)
+		if err != nil {
+			return buildLogLoadedMsg{seq: seq, recordID: record.ID, err: fmt.Errorf("loading log: %w", err)}
+		}
+		return buildLogLoadedMsg{seq: seq, recordID: record.ID, startLine: startLine, lines: lines}
 	}
+}
 
-	// Status message
-	if a.statusMsg != "" {
-		statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
-		titleBar += "  " + statusStyle.Render(a.statusMsg)
+// saveLogCmd writes a log to a file in the working directory
+func saveLogCmd(name string, lines []string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := filepath.Abs(name)
+		if err != nil {
+			return logSavedMsg{err: err}
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			return logSavedMsg{err: fmt.Errorf("saving log: %w", err)}
+		}
+		return logSavedMsg{path: path, lines: len(lines)}
 	}
+}
 
-	// Set panel sizes (content dimensions, borders added by styles)
-	a.filterPanel.SetSize(filterWidth, filterContentHeight)
-	a.workItemsPanel.SetSize(contentWidth, workItemsHeight)
-	a.detailsPanel.SetSize(contentWidth, detailsHeight)
+func resolveArtifactsCmd(client *api.Client, seq int, urls []string) tea.Cmd {
+	return func() tea.Msg {
+		return artifactsResolvedMsg{seq: seq, artifacts: client.ResolveArtifacts(urls)}
+	}
+}
 
-	// Render panels
-	filterView := a.filterPanel.View()
-	workItemsView := a.workItemsPanel.View()
-	detailsView := a.detailsPanel.View()
+// createTasksCmd creates one child task per title, inheriting area and
+// iteration from the parent
+func createTasksCmd(client *api.Client, parent models.WorkItem, titles []string, assignee string) tea.Cmd {
+	return func() tea.Msg {
+		// List items don't necessarily have area and iteration
+		if !parent.DetailsLoaded {
+			full, err := client.GetWorkItem(parent.ID)
+			if err != nil {
+				return errMsg{err: err}
+			}
+			parent = *full
+		}
 
-	// Right side (work items + details)
-	rightSide := lipgloss.JoinVertical(lipgloss.Left, workItemsView, detailsView)
+		for i, title := range titles {
+			fields := map[string]interface{}{
+				models.FieldTitle:         title,
+				models.FieldAreaPath:      parent.AreaPath,
+				models.FieldIterationPath: parent.IterationPath,
+			}
+			if assignee != "" {
+				fields[models.FieldAssignedTo] = assignee
+			}
+			if _, err := client.CreateWorkItem(string(models.WorkItemTypeTask), fields, parent.ID); err != nil {
+				if i > 0 {
+					err = fmt.Errorf("created %d of %d tasks: %w", i, len(titles), err)
+				}
+				return errMsg{err: err}
+			}
+		}
 
-	// Main content
-	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, filterView, rightSide)
+		return tasksCreatedMsg{parentID: parent.ID, count: len(titles)}
+	}
+}
 
-	// Status bar
-	statusBar := a.renderStatusBar()
+func updateLinksCmd(gitRepo git.Repo, client *api.Client, req components.LinkRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		id := req.Item.ID
+		var err error
+		var status string
+		changed := []int{id}
+
+		switch req.Action {
+		case components.LinkSetParent:
+			err = client.SetWorkItemParent(id, req.TargetID)
+			status = fmt.Sprintf("Parent of #%d set to #%d", id, req.TargetID)
+			changed = append(changed, req.TargetID, req.Item.ParentID)
+		case components.LinkAddChild:
+			// A child has a single parent, so adding a child reparents it
+			err = client.SetWorkItemParent(req.TargetID, id)
+			status = fmt.Sprintf("#%d added as child of #%d", req.TargetID, id)
+			changed = append(changed, req.TargetID)
+		case components.LinkRemoveParent:
+			err = client.SetWorkItemParent(id, 0)
+			status = fmt.Sprintf("Parent removed from #%d", id)
+			changed = append(changed, req.Item.ParentID)
+		case components.LinkBranch:
+			var branch string
+			var repo models.Repository
+			branch, repo, err = currentBranchRepository(gitRepo, client)
+			// A link to a branch the server doesn't have leads nowhere
+			if err == nil && !gitRepo.RemoteBranchExists(prRemote, branch) {
+				err = fmt.Errorf("branch %s is not pushed to %s", branch, prRemote)
+			}
+			if err == nil {
+				err = client.LinkBranch(id, repo, branch)
+			}
+			status = fmt.Sprintf("Branch %s linked to #%d", branch, id)
+		case components.LinkRemove:
+			err = client.RemoveWorkItemLink(id, req.Relation.Rel, req.Relation.URL)
+			status = fmt.Sprintf("%s link removed from #%d", req.Relation.TypeLabel(), id)
+			changed = append(changed, req.Relation.TargetID)
+		default:
+			err = client.AddWorkItemLink(id, req.Action.Rel(), req.TargetID)
+			status = fmt.Sprintf("#%d linked to #%d (%s)", id, req.TargetID, req.Action.Label())
+			changed = append(changed, req.TargetID)
+		}
 
-	// Combine all
-	return lipgloss.JoinVertical(lipgloss.Left,
-		titleBar,
-		mainContent,
-		statusBar,
-	)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return linksUpdatedMsg{itemIDs: changed, status: status}
+	}
 }
 
-func (a *App) renderStatusBar() string {
-	var parts []string
+// prRemote is the remote pull requests are pushed to and created in
+const prRemote = "origin"
 
-	// Panel indicator
-	panelName := "Filter"
-	if a.activePanel == PanelWorkItems {
-		panelName = "Work Items"
+// detectPullRequestSourceCmd finds the current branch, its Azure Repos
+// repository and whether it still needs to be pushed
+func detectPullRequestSourceCmd(gitRepo git.Repo, client *api.Client, itemID int) tea.Cmd {
+	return func() tea.Msg {
+		source, err := detectPullRequestSource(gitRepo, client)
+		return pullRequestSourceMsg{itemID: itemID, source: source, err: err}
 	}
-	parts = append(parts, a.styles.HelpKey.Render("Panel")+": "+panelName)
+}
 
-	// Short help
-	help := components.ShortHelp(a.keys, a.styles)
-	parts = append(parts, help)
+// currentBranchRepository returns the current branch and the Azure Repos
+// repository of its remote
+func currentBranchRepository(gitRepo git.Repo, client *api.Client) (string, models.Repository, error) {
+	if !gitRepo.IsGitRepo() {
+		return "", models.Repository{}, fmt.Errorf("not in a git repository")
+	}
+	branch, err := gitRepo.GetCurrentBranch()
+	if err != nil {
+		return "", models.Repository{}, err
+	}
+	if branch == "" {
+		return "", models.Repository{}, fmt.Errorf("not on a branch (detached HEAD)")
+	}
 
-	return a.styles.StatusBar.Width(a.width).Render(strings.Join(parts, "  "))
+	repo, err := remoteRepository(gitRepo, client, prRemote)
+	return branch, repo, err
 }
 
-func (a *App) nextPanel() {
-	if a.activePanel == PanelFilter {
-		a.activePanel = PanelWorkItems
-	} else {
-		a.activePanel = PanelFilter
+// remoteRepository looks up the Azure Repos repository of a remote
+func remoteRepository(gitRepo git.Repo, client *api.Client, name string) (models.Repository, error) {
+	remoteURL, err := gitRepo.GetRemoteURL(name)
+	if err != nil {
+		return models.Repository{}, err
+	}
+	remote, ok := git.ParseAzureRemote(remoteURL)
+	if !ok {
+		return models.Repository{}, fmt.Errorf("remote '%s' is not an Azure Repos repository: %s", name, remoteURL)
 	}
+	if !strings.EqualFold(remote.Organization, client.Organization()) {
+		return models.Repository{}, fmt.Errorf("remote '%s' is in organization %s, but the profile uses %s",
+			name, remote.Organization, client.Organization())
+	}
+
+	return client.GetRepository(remote.Project, remote.Repository)
 }
 
-func (a *App) prevPanel() {
-	if a.activePanel == PanelWorkItems {
-		a.activePanel = PanelFilter
-	} else {
-		a.activePanel = PanelWorkItems
+func detectPullRequestSource(gitRepo git.Repo, client *api.Client) (components.PullRequestSource, error) {
+	source := components.PullRequestSource{Remote: prRemote}
+
+	branch, repo, err := currentBranchRepository(gitRepo, client)
+	if err != nil {
+		return source, err
+	}
+	source.Branch = branch
+	source.Repository = repo
+	if branch == repo.DefaultBranch {
+		return source, fmt.Errorf("on the default branch %s, create a branch for the work item first", branch)
 	}
-}
 
-func (a *App) updateFocus() {
-	a.filterPanel.SetFocused(a.activePanel == PanelFilter)
-	a.workItemsPanel.SetFocused(a.activePanel == PanelWorkItems)
+	source.Pushed, source.Ahead, err = gitRepo.UpstreamStatus(branch)
+	if err != nil {
+		return source, err
+	}
+	return source, nil
 }
 
-func (a *App) updateSizes() {
-	a.helpPanel.SetSize(a.width, a.height)
-	a.detailView.SetSize(a.width, a.height)
-	a.updateFocus()
-}
+// createPullRequestCmd pushes the source branch if needed and opens a pull
+// request linked to the work item
+func createPullRequestCmd(gitRepo git.Repo, client *api.Client, req components.CreatePullRequestMsg, userID string) tea.Cmd {
+	return func() tea.Msg {
+		if req.Source.Branch == req.TargetBranch {
+			return errMsg{err: fmt.Errorf("source and target branch are both %s", req.TargetBranch)}
+		}
 
-func (a *App) updateSelectedItem() {
-	item := a.workItemsPanel.SelectedItem()
-	a.detailsPanel.SetItem(item)
-}
+		if req.Source.NeedsPush() {
+			if err := gitRepo.Push(req.Source.Remote, req.Source.Branch); err != nil {
+				return errMsg{err: err}
+			}
+		}
 
-// Message types
+		newPR := models.NewPullRequest{
+			SourceBranch: req.Source.Branch,
+			TargetBranch: req.TargetBranch,
+			Title:        req.Title,
+			Description:  req.Description,
+			WorkItemIDs:  []int{req.Item.ID},
+		}
+		for _, r := range req.Reviewers {
+			newPR.ReviewerIDs = append(newPR.ReviewerIDs, r.ID)
+		}
 
-type dataLoadedMsg struct {
-	iterations   []models.Iteration
-	areas        []models.Area
-	statesByType map[string][]models.WorkItemStateInfo
-	teamMembers  []models.TeamMember
-}
+		pr, err := client.CreatePullRequest(req.Source.Repository, newPR)
+		if err != nil {
+			return errMsg{err: err}
+		}
 
-type workItemsLoadedMsg struct {
-	items []models.WorkItem
+		msg := pullRequestCreatedMsg{itemID: req.Item.ID, pr: pr}
+		if req.AutoComplete {
+			msg.err = client.SetPullRequestAutoComplete(req.Source.Repository, pr.ID, userID)
+		}
+		return msg
+	}
 }
 
-type errMsg struct {
-	err error
-}
+// detectCurrentWorkItemCmd finds the work item being worked on in the
+// current branch, from the started item or the ID in the branch name
+func detectCurrentWorkItemCmd(gitRepo git.Repo, client *api.Client, matcher *models.BranchMatcher) tea.Cmd {
+	return func() tea.Msg {
+		if !gitRepo.IsGitRepo() {
+			return currentWorkItemMsg{}
+		}
+		branch, err := gitRepo.GetCurrentBranch()
+		if err != nil {
+			return currentWorkItemMsg{}
+		}
 
-type stateChangedMsg struct {
-	newState string
+		// A work item started on the branch wins over the branch name
+		msg := currentWorkItemMsg{branch: branch, id: gitRepo.StartedWorkItem(branch)}
+		if msg.id == 0 {
+			msg.id = matcher.WorkItemID(branch)
+		}
+		if msg.id == 0 {
+			return msg
+		}
+		if item, err := client.GetWorkItem(msg.id); err == nil {
+			msg.item = item
+		}
+		return msg
+	}
 }
 
-type assignedMsg struct {
-	userName string
-}
+// planWorkCmd adds the git steps to a work plan: checking out the item's
+// branch when starting, creating a pull request when finishing
+func planWorkCmd(gitRepo git.Repo, plan models.WorkPlan, namer *models.BranchNamer, alias string) tea.Cmd {
+	return func() tea.Msg {
+		if !gitRepo.IsGitRepo() {
+			return workPlanMsg{plan: plan, note: "Not in a git repository: no branch or pull request"}
+		}
 
-// Commands
+		if plan.Finish {
+			plan.Steps = append(plan.Steps, models.WorkStep{Kind: models.WorkStepPullRequest, Enabled: true})
+			return workPlanMsg{plan: plan}
+		}
 
-func loadDataCmd(client *api.Client) tea.Cmd {
-	return func() tea.Msg {
-		iterations, err := client.GetIterations()
+		name, err := namer.Name(&plan.Item, alias)
 		if err != nil {
-			return errMsg{err: err}
+			return workPlanMsg{plan: plan, note: err.Error()}
 		}
-		areas, err := client.GetAreas()
-		if err != nil {
-			return errMsg{err: err}
+		step := models.WorkStep{
+			Kind:    models.WorkStepBranch,
+			Value:   name,
+			Label:   "Create and check out branch " + name,
+			Enabled: true,
 		}
-		statesByType, err := client.GetAllWorkItemTypeStates()
-		if err != nil {
-			// Non-fatal - we can still work with hardcoded states
-			statesByType = make(map[string][]models.WorkItemStateInfo)
+		if current, _ := gitRepo.GetCurrentBranch(); current == name {
+			return workPlanMsg{plan: plan}
 		}
-		teamMembers, err := client.GetTeamMembers()
-		if err != nil {
-			// Non-fatal - we can still work without team members
-			teamMembers = []models.TeamMember{}
+		if gitRepo.BranchExists(name) {
+			step.Label = "Check out existing branch " + name
+		}
+		plan.Steps = append(plan.Steps, step)
+
+		var note string
+		if gitRepo.HasUncommittedChanges() {
+			note = "Uncommitted changes exist: commit or stash them, or skip the branch"
 		}
-		return dataLoadedMsg{iterations: iterations, areas: areas, statesByType: statesByType, teamMembers: teamMembers}
+		return workPlanMsg{plan: plan, note: note}
 	}
 }
 
-func loadWorkItemsCmd(client *api.Client, filterState *models.FilterState) tea.Cmd {
+// runWorkPlanCmd runs the enabled steps of a work plan in order. When a step
+// fails, the changes made before it are undone.
+func runWorkPlanCmd(gitRepo git.Repo, client *api.Client, plan models.WorkPlan, matcher *models.BranchMatcher) tea.Cmd {
 	return func() tea.Msg {
-		sprint := filterState.GetSelectedSprint()
-		state := filterState.GetSelectedState()
-		assigned := filterState.GetSelectedAssigned()
-		area := filterState.GetSelectedArea()
+		id := plan.Item.ID
+		branch, hasBranch := plan.Step(models.WorkStepBranch)
+		if hasBranch && gitRepo.HasUncommittedChanges() {
+			return workDoneMsg{plan: plan, err: fmt.Errorf("uncommitted changes exist")}
+		}
 
-		items, err := client.QueryWorkItems(sprint, state, assigned, area)
-		if err != nil {
-			return errMsg{err: err}
+		var undo []func() error
+		fail := func(step models.WorkStep, err error) tea.Msg {
+			err = fmt.Errorf("%s: %w", step.Description(), err)
+			for i := len(undo) - 1; i >= 0; i-- {
+				if undoErr := undo[i](); undoErr != nil {
+					return workDoneMsg{plan: plan, err: fmt.Errorf("%w; rollback failed: %v", err, undoErr)}
+				}
+			}
+			if len(undo) > 0 {
+				err = fmt.Errorf("%w (%d change(s) rolled back)", err, len(undo))
+			}
+			return workDoneMsg{plan: plan, err: err}
 		}
-		return workItemsLoadedMsg{items: items}
+
+		for _, step := range plan.Steps {
+			if !step.Enabled {
+				continue
+			}
+			var do, revert func() error
+			switch step.Kind {
+			case models.WorkStepAssign:
+				do = func() error { return client.AssignWorkItem(id, step.Value) }
+				revert = func() error { return client.AssignWorkItem(id, step.From) }
+			case models.WorkStepState:
+				do = func() error { return client.UpdateWorkItemState(id, step.Value) }
+				revert = func() error { return client.UpdateWorkItemState(id, step.From) }
+			case models.WorkStepIteration:
+				do = func() error { return client.SetWorkItemIteration(id, step.Value) }
+				revert = func() error { return client.SetWorkItemIteration(id, step.From) }
+			default:
+				// Git steps run last, once the item is updated
+				continue
+			}
+			if err := do(); err != nil {
+				return fail(step, err)
+			}
+			undo = append(undo, revert)
+		}
+
+		msg := workDoneMsg{plan: plan}
+		if hasBranch {
+			created := !gitRepo.BranchExists(branch.Value)
+			var err error
+			if created {
+				err = gitRepo.CreateBranch(branch.Value, true)
+			} else {
+				err = gitRepo.CheckoutBranch(branch.Value)
+			}
+			if err != nil {
+				return fail(branch, err)
+			}
+
+			// Remember the started item when the branch doesn't name it
+			if matcher.WorkItemID(branch.Value) != id {
+				_ = gitRepo.SetStartedWorkItem(branch.Value, id)
+			} else {
+				_ = gitRepo.ClearStartedWorkItem(branch.Value)
+			}
+		}
+
+		if current, _ := gitRepo.GetCurrentBranch(); plan.Finish && gitRepo.StartedWorkItem(current) == id {
+			_ = gitRepo.ClearStartedWorkItem(current)
+		}
+		return msg
 	}
 }
 
-func updateWorkItemStateCmd(client *api.Client, itemID int, newState string, filterState *models.FilterState) tea.Cmd {
+// loadBranchesCmd fetches the remote and lists the branches to base a new
+// branch on, along with the item's existing branches
+func loadBranchesCmd(gitRepo git.Repo, itemID int, matcher *models.BranchMatcher) tea.Cmd {
 	return func() tea.Msg {
-		err := client.UpdateWorkItemState(itemID, newState)
+		msg := branchesLoadedMsg{itemID: itemID}
+		if !gitRepo.IsGitRepo() {
+			msg.fetchErr = fmt.Errorf("not a git repository")
+			return msg
+		}
+
+		if _, err := gitRepo.GetRemoteURL(prRemote); err == nil {
+			msg.remote = prRemote
+			msg.fetchErr = gitRepo.Fetch(prRemote)
+			msg.defaultBase = gitRepo.DefaultBranch(prRemote)
+		}
+
+		local, remoteRefs, err := gitRepo.ListBranches()
 		if err != nil {
-			return errMsg{err: err}
+			msg.fetchErr = err
+			return msg
 		}
-		return stateChangedMsg{newState: newState}
+		msg.local, msg.remoteRefs = local, remoteRefs
+
+		isLocal := make(map[string]bool)
+		for _, branch := range local {
+			isLocal[branch] = true
+			if matcher.WorkItemID(branch) == itemID {
+				msg.existing = append(msg.existing, branch)
+			}
+		}
+		for _, ref := range remoteRefs {
+			name := strings.TrimPrefix(ref, msg.remote+"/")
+			if !isLocal[name] && matcher.WorkItemID(name) == itemID {
+				msg.existing = append(msg.existing, ref)
+			}
+		}
+		return msg
+	}
+}
+
+// listWorktreesCmd finds where a new worktree for an item goes, and the
+// item's existing worktrees
+func listWorktreesCmd(gitRepo git.Repo, itemID int, pattern string, matcher *models.BranchMatcher) tea.Cmd {
+	return func() tea.Msg {
+		if !gitRepo.IsGitRepo() {
+			return nil
+		}
+		worktrees, err := gitRepo.ListWorktrees()
+		if err != nil || len(worktrees) == 0 {
+			return nil
+		}
+
+		// Relative patterns start from the main working tree
+		mainPath := worktrees[0].Path
+		path := models.ExpandWorktreePath(pattern, itemID, filepath.Base(mainPath))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(mainPath, path)
+		}
+
+		msg := worktreesLoadedMsg{itemID: itemID, path: filepath.Clean(path)}
+		for _, wt := range worktrees {
+			if wt.Main {
+				continue
+			}
+			if filepath.Clean(wt.Path) == msg.path || matcher.WorkItemID(wt.Branch) == itemID {
+				msg.worktrees = append(msg.worktrees, wt)
+			}
+		}
+		return msg
 	}
 }
 
-func createBranchCmd(branchName string) tea.Cmd {
+// createBranchCmd creates a branch for a work item, or checks it out when it
+// exists locally or on the remote, then pushes it and links it to the item
+// so it shows up in the item's Development section. Branches the remote
+// doesn't have aren't linked.
+func createBranchCmd(gitRepo git.Repo, client *api.Client, req components.BranchCreateRequestMsg) tea.Cmd {
 	return func() tea.Msg {
-		if !git.IsGitRepo() {
+		if !gitRepo.IsGitRepo() {
 			return components.BranchCreateErrorMsg{Err: fmt.Errorf("not a git repository")}
 		}
-		if git.HasUncommittedChanges() {
-			return components.BranchCreateErrorMsg{Err: fmt.Errorf("uncommitted changes exist")}
+		if req.Push && req.Remote == "" {
+			return components.BranchCreateErrorMsg{Err: fmt.Errorf("no remote to push to")}
 		}
-		err := git.CreateBranch(branchName, true)
-		if err != nil {
-			return components.BranchCreateErrorMsg{Err: err}
+
+		name := req.BranchName
+		msg := components.BranchCreatedMsg{ItemID: req.Item.ID, BranchName: name, WorktreePath: req.WorktreePath}
+
+		// A branch only on the remote is checked out tracking it
+		base := req.Base
+		remoteOnly := !gitRepo.BranchExists(name) && req.Remote != "" && gitRepo.RemoteBranchExists(req.Remote, name)
+		if remoteOnly {
+			base = req.Remote + "/" + name
+		}
+		msg.CheckedOut = remoteOnly || gitRepo.BranchExists(name)
+
+		if req.WorktreePath != "" {
+			// The current checkout is left as it is, changes and all
+			if err := gitRepo.AddWorktree(req.WorktreePath, name, base); err != nil {
+				return components.BranchCreateErrorMsg{Err: err}
+			}
+		} else {
+			if gitRepo.HasUncommittedChanges() {
+				return components.BranchCreateErrorMsg{Err: fmt.Errorf("uncommitted changes exist")}
+			}
+			var err error
+			if msg.CheckedOut {
+				err = gitRepo.CheckoutBranch(name)
+			} else {
+				err = gitRepo.CreateBranchFrom(name, base)
+			}
+			if err != nil {
+				return components.BranchCreateErrorMsg{Err: err}
+			}
+		}
+
+		if req.Push {
+			if err := gitRepo.Push(req.Remote, name); err != nil {
+				return components.BranchCreateErrorMsg{Err: fmt.Errorf("%s is checked out, but %w", name, err)}
+			}
+			msg.Pushed = true
+		}
+
+		// Links are only made to branches the server has
+		if !msg.Pushed && (req.Remote == "" || !gitRepo.RemoteBranchExists(req.Remote, name)) {
+			msg.LinkErr = fmt.Errorf("branch not pushed")
+			return msg
+		}
+		repo, err := remoteRepository(gitRepo, client, req.Remote)
+		if err == nil {
+			err = client.LinkBranch(req.Item.ID, repo, name)
 		}
-		return components.BranchCreatedMsg{BranchName: branchName}
+		msg.LinkErr = err
+		return msg
 	}
 }
 
@@ -11,29 +11,72 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/samuelenocsson/devops-tui/internal/models"
 	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+	"github.com/samuelenocsson/devops-tui/pkg/git"
 )
 
+// Branch modal fields, in focus order; the item's existing branches and
+// worktrees follow
+const (
+	branchFocusName = iota
+	branchFocusBase
+	branchFocusPush
+	branchFocusWorktree
+	branchFocusLists
+)
+
+// maxBaseSuggestions is how many matching branches the base picker shows
+const maxBaseSuggestions = 5
+
 // BranchModal is a modal for creating a branch linked to a work item
 type BranchModal struct {
 	visible   bool
 	item      *models.WorkItem
+	namer     *models.BranchNamer
+	alias     string // Current user's alias for branch templates
 	textInput textinput.Model
+	focus     int
 	styles    theme.Styles
 	keys      theme.KeyMap
 	width     int
 	height    int
 	err       error
+
+	// Base branch picker over the local and remote-tracking branches
+	baseInput   textinput.Model
+	remote      string
+	local       []string
+	remoteRefs  []string // Remote-tracking branches, e.g. "origin/main"
+	suggestions []string
+	suggestion  int
+	fetching    bool
+	fetchErr    error
+
+	// The item's branches, checked out instead of created
+	existing []string
+
+	push bool
+
+	// Worktree option: where a new worktree goes, and the item's existing ones
+	worktree     bool
+	worktreePath string
+	worktrees    []git.Worktree
 }
 
 // NewBranchModal creates a new branch modal
 func NewBranchModal(styles theme.Styles, keys theme.KeyMap) BranchModal {
 	ti := textinput.New()
 	ti.Placeholder = "feature/123-task-name"
-	ti.CharLimit = 100
+	ti.CharLimit = 250
 	ti.Width = 35
 
+	base := textinput.New()
+	base.Placeholder = "HEAD"
+	base.CharLimit = 250
+	base.Width = 35
+
 	return BranchModal{
 		textInput: ti,
+		baseInput: base,
 		styles:    styles,
 		keys:      keys,
 	}
@@ -54,11 +97,54 @@ func (m BranchModal) Update(msg tea.Msg) (BranchModal, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The base picker's suggestions take the arrow keys and Enter
+		if m.focus == branchFocusBase && len(m.suggestions) > 0 {
+			switch msg.Type {
+			case tea.KeyUp:
+				if m.suggestion > 0 {
+					m.suggestion--
+				}
+				return m, nil
+			case tea.KeyDown:
+				if m.suggestion < len(m.suggestions)-1 {
+					m.suggestion++
+				}
+				return m, nil
+			case tea.KeyEnter:
+				m.baseInput.SetValue(m.suggestions[m.suggestion])
+				m.baseInput.CursorEnd()
+				m.suggestions = nil
+				m.setFocus(m.focus + 1)
+				return m, nil
+			}
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Back):
 			m.visible = false
 			m.err = nil
 			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case msg.Type == tea.KeyTab || msg.Type == tea.KeyDown:
+			m.setFocus(m.focus + 1)
+			return m, nil
+		case msg.Type == tea.KeyShiftTab || msg.Type == tea.KeyUp:
+			m.setFocus(m.focus - 1)
+			return m, nil
+		case m.focus == branchFocusPush && msg.String() == " ":
+			m.push = !m.push
+			return m, nil
+		case m.focus == branchFocusWorktree && msg.String() == " ":
+			if m.worktreePath != "" {
+				m.worktree = !m.worktree
+			}
+			return m, nil
+		case m.focus >= branchFocusLists+len(m.existing) && msg.Type == tea.KeyEnter:
+			path := m.worktrees[m.focus-branchFocusLists-len(m.existing)].Path
+			return m, func() tea.Msg { return WorktreeSwitchRequestMsg{Path: path} }
+		case m.focus >= branchFocusLists && msg.Type == tea.KeyEnter:
+			// Remote-tracking branches are checked out under their own name
+			name := strings.TrimPrefix(m.existing[m.focus-branchFocusLists], m.remote+"/")
+			return m, m.request(name, "")
 		case msg.Type == tea.KeyEnter:
 			branchName := strings.TrimSpace(m.textInput.Value())
 			if branchName == "" {
@@ -69,23 +155,97 @@ func (m BranchModal) Update(msg tea.Msg) (BranchModal, tea.Cmd) {
 				m.err = fmt.Errorf("invalid branch name")
 				return m, nil
 			}
-			m.err = nil
-			return m, func() tea.Msg {
-				return BranchCreateRequestMsg{
-					Item:       *m.item,
-					BranchName: branchName,
-				}
+			base := strings.TrimSpace(m.baseInput.Value())
+			if base != "" && !m.fetching && !m.isBranch(base) {
+				m.err = fmt.Errorf("base branch %s not found", base)
+				return m, nil
 			}
-		default:
+			m.err = nil
+			return m, m.request(branchName, base)
+		case m.focus == branchFocusName:
 			m.textInput, cmd = m.textInput.Update(msg)
 			return m, cmd
+		case m.focus == branchFocusBase:
+			m.baseInput, cmd = m.baseInput.Update(msg)
+			m.updateSuggestions()
+			return m, cmd
 		}
+		return m, nil
 	}
 
 	m.textInput, cmd = m.textInput.Update(msg)
 	return m, cmd
 }
 
+// request returns the command asking for a branch to be created, or checked
+// out when it exists
+func (m BranchModal) request(name, base string) tea.Cmd {
+	req := BranchCreateRequestMsg{
+		Item:       *m.item,
+		BranchName: name,
+		Base:       base,
+		Remote:     m.remote,
+		Push:       m.push,
+	}
+	if m.worktree {
+		req.WorktreePath = m.worktreePath
+	}
+	return func() tea.Msg { return req }
+}
+
+// setFocus moves the focus to a field, wrapping around
+func (m *BranchModal) setFocus(focus int) {
+	count := branchFocusLists + len(m.existing) + len(m.worktrees)
+	m.focus = (focus + count) % count
+	m.suggestions = nil
+
+	m.textInput.Blur()
+	m.baseInput.Blur()
+	switch m.focus {
+	case branchFocusName:
+		m.textInput.Focus()
+	case branchFocusBase:
+		m.baseInput.Focus()
+		m.updateSuggestions()
+	}
+}
+
+// updateSuggestions lists the branches matching the base input
+func (m *BranchModal) updateSuggestions() {
+	m.suggestions = nil
+	m.suggestion = 0
+
+	query := strings.ToLower(strings.TrimSpace(m.baseInput.Value()))
+	if query == "" {
+		return
+	}
+	for _, branch := range append(append([]string{}, m.local...), m.remoteRefs...) {
+		if branch == m.baseInput.Value() {
+			// Already picked
+			m.suggestions = nil
+			return
+		}
+		if strings.Contains(strings.ToLower(branch), query) && len(m.suggestions) < maxBaseSuggestions {
+			m.suggestions = append(m.suggestions, branch)
+		}
+	}
+}
+
+// isBranch reports whether a name is a local or remote-tracking branch
+func (m BranchModal) isBranch(name string) bool {
+	for _, branch := range append(append([]string{}, m.local...), m.remoteRefs...) {
+		if branch == name {
+			return true
+		}
+	}
+	return false
+}
+
+// branchExists reports whether a branch exists locally or on the remote
+func (m BranchModal) branchExists(name string) bool {
+	return m.isBranch(name) || (m.remote != "" && m.isBranch(m.remote+"/"+name))
+}
+
 // View renders the modal
 func (m BranchModal) View() string {
 	if !m.visible {
@@ -93,8 +253,7 @@ func (m BranchModal) View() string {
 	}
 
 	// Modal dimensions
-	modalWidth := 50
-	modalHeight := 10
+	modalWidth := 60
 
 	// Build content
 	var b strings.Builder
@@ -117,6 +276,65 @@ func (m BranchModal) View() string {
 
 	// Text input
 	b.WriteString(m.textInput.View() + "\n")
+	noteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	if m.branchExists(strings.TrimSpace(m.textInput.Value())) {
+		b.WriteString(noteStyle.Render("Branch exists: Enter checks it out") + "\n")
+	}
+
+	// Base branch
+	b.WriteString(labelStyle.Render("Base branch:") + "\n")
+	b.WriteString(m.baseInput.View() + "\n")
+	switch {
+	case m.fetching:
+		b.WriteString(noteStyle.Render("Fetching branches...") + "\n")
+	case m.fetchErr != nil:
+		b.WriteString(noteStyle.Render(truncateStr("Fetch failed: "+m.fetchErr.Error(), modalWidth-6)) + "\n")
+	}
+	for i, branch := range m.suggestions {
+		line := "  " + branch
+		if i == m.suggestion {
+			line = "▸ " + branch
+		}
+		b.WriteString(m.focusStyle(branchFocusBase).Render(line) + "\n")
+	}
+
+	// Push option
+	check := "[ ] "
+	if m.push {
+		check = "[x] "
+	}
+	remote := m.remote
+	if remote == "" {
+		remote = "remote"
+	}
+	b.WriteString(m.focusStyle(branchFocusPush).Render(check+"Push to "+remote+" and track it") + "\n")
+
+	// Worktree option
+	check = "[ ] "
+	if m.worktree {
+		check = "[x] "
+	}
+	option := "Create in new worktree"
+	if m.worktreePath != "" {
+		option += ": " + truncateStr(m.worktreePath, modalWidth-34)
+	}
+	b.WriteString(m.focusStyle(branchFocusWorktree).Render(check+option) + "\n")
+
+	// Existing branches and worktrees of the item
+	if len(m.existing) > 0 {
+		b.WriteString("\n" + labelStyle.Render("Existing branches (Enter checks out):") + "\n")
+		for i, branch := range m.existing {
+			b.WriteString(m.focusStyle(branchFocusLists+i).Render(truncateStr(branch, modalWidth-6)) + "\n")
+		}
+	}
+	if len(m.worktrees) > 0 {
+		b.WriteString("\n" + labelStyle.Render("Existing worktrees (Enter switches):") + "\n")
+		for i, wt := range m.worktrees {
+			line := truncateStr(wt.Path, 30) + "  " + truncateStr(wt.Branch, modalWidth-40)
+			b.WriteString(m.focusStyle(branchFocusLists+len(m.existing)+i).Render(line) + "\n")
+		}
+	}
+	b.WriteString("\n")
 
 	// Error message
 	if m.err != nil {
@@ -128,7 +346,7 @@ func (m BranchModal) View() string {
 
 	// Help text
 	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-	b.WriteString(helpStyle.Render("Enter: create  Esc: cancel"))
+	b.WriteString(helpStyle.Render("Enter: create  Tab/↓: next  Space: toggle  Esc: cancel"))
 
 	// Modal style
 	modalStyle := lipgloss.NewStyle().
@@ -136,7 +354,6 @@ func (m BranchModal) View() string {
 		BorderForeground(lipgloss.Color("#7C3AED")).
 		Padding(1, 2).
 		Width(modalWidth).
-		Height(modalHeight).
 		Background(lipgloss.Color("#1F2937"))
 
 	modal := modalStyle.Render(b.String())
@@ -145,15 +362,35 @@ func (m BranchModal) View() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
 }
 
+// focusStyle styles a field by whether it has the focus
+func (m BranchModal) focusStyle(focus int) lipgloss.Style {
+	if m.focus == focus {
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+}
+
 // SetVisible sets the visibility
 func (m *BranchModal) SetVisible(visible bool) {
 	m.visible = visible
 	m.err = nil
 	if visible {
+		m.focus = branchFocusName
+		m.baseInput.SetValue("")
+		m.baseInput.Blur()
+		m.suggestions = nil
+		m.local, m.remoteRefs, m.existing = nil, nil, nil
+		m.fetching = true
+		m.fetchErr = nil
+		m.push = false
+		m.worktree = false
+		m.worktreePath = ""
+		m.worktrees = nil
 		m.textInput.Focus()
-		// Generate suggested branch name from work item
-		if m.item != nil {
-			suggested := generateBranchName(m.item)
+		// Suggest a branch name from the work item
+		if m.item != nil && m.namer != nil {
+			suggested, err := m.namer.Name(m.item, m.alias)
+			m.err = err
 			m.textInput.SetValue(suggested)
 			m.textInput.CursorEnd()
 		}
@@ -178,38 +415,45 @@ func (m *BranchModal) SetSize(width, height int) {
 	m.height = height
 }
 
-// generateBranchName generates a suggested branch name from work item
-func generateBranchName(item *models.WorkItem) string {
-	// Get work item type prefix
-	prefix := "feature"
-	switch item.Type {
-	case models.WorkItemTypeBug:
-		prefix = "bugfix"
-	case models.WorkItemTypeTask:
-		prefix = "task"
-	case models.WorkItemTypeStory:
-		prefix = "feature"
-	case models.WorkItemTypeEpic:
-		prefix = "epic"
-	}
-
-	// Sanitize title for branch name
-	title := strings.ToLower(item.Title)
-	// Replace spaces and special chars with hyphens
-	re := regexp.MustCompile(`[^a-z0-9]+`)
-	title = re.ReplaceAllString(title, "-")
-	// Remove leading/trailing hyphens
-	title = strings.Trim(title, "-")
-	// Truncate to reasonable length
-	if len(title) > 40 {
-		title = title[:40]
-		// Don't cut off in middle of a word if possible
-		if lastHyphen := strings.LastIndex(title, "-"); lastHyphen > 20 {
-			title = title[:lastHyphen]
-		}
+// SetWorktrees sets where a new worktree for the item goes, and the item's
+// existing worktrees
+func (m *BranchModal) SetWorktrees(path string, worktrees []git.Worktree) {
+	m.worktreePath = path
+	m.worktrees = worktrees
+}
+
+// SetBranches sets the branches to pick the base from, the default base,
+// and the item's existing branches. fetchErr is set when the remote
+// couldn't be fetched; the branches are then as of the last fetch.
+func (m *BranchModal) SetBranches(remote string, local, remoteRefs []string, defaultBase string, existing []string, fetchErr error) {
+	m.fetching = false
+	m.remote = remote
+	m.local = local
+	m.remoteRefs = remoteRefs
+	m.existing = existing
+	m.fetchErr = fetchErr
+	if m.baseInput.Value() == "" {
+		m.baseInput.SetValue(defaultBase)
+		m.baseInput.CursorEnd()
 	}
+}
+
+// ItemID returns the ID of the item the branch is for
+func (m *BranchModal) ItemID() int {
+	if m.item == nil {
+		return 0
+	}
+	return m.item.ID
+}
 
-	return fmt.Sprintf("%s/%d-%s", prefix, item.ID, title)
+// SetNamer sets how branch names are suggested
+func (m *BranchModal) SetNamer(namer *models.BranchNamer) {
+	m.namer = namer
+}
+
+// SetAlias sets the current user's alias used in branch templates
+func (m *BranchModal) SetAlias(alias string) {
+	m.alias = alias
 }
 
 // isValidBranchName validates branch name
@@ -236,13 +480,27 @@ func isValidBranchName(name string) bool {
 
 // BranchCreateRequestMsg is sent when user confirms branch creation
 type BranchCreateRequestMsg struct {
-	Item       models.WorkItem
-	BranchName string
+	Item         models.WorkItem
+	BranchName   string
+	Base         string // Branch to start from, HEAD when empty
+	Remote       string
+	Push         bool   // Push to the remote and track it
+	WorktreePath string // Check the branch out in a new worktree here, if set
 }
 
 // BranchCreatedMsg is sent when branch creation is complete
 type BranchCreatedMsg struct {
-	BranchName string
+	ItemID       int
+	BranchName   string
+	CheckedOut   bool // The branch existed and was checked out
+	Pushed       bool
+	WorktreePath string
+	LinkErr      error // Set when the branch couldn't be linked to the work item
+}
+
+// WorktreeSwitchRequestMsg is sent when an existing worktree is chosen
+type WorktreeSwitchRequestMsg struct {
+	Path string
 }
 
 // BranchCreateErrorMsg is sent when branch creation fails
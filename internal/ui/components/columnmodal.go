@@ -0,0 +1,367 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// ColumnModal is a modal for choosing, ordering and sizing the work item columns
+type ColumnModal struct {
+	visible bool
+	columns []models.Column
+	fields  []models.FieldDefinition
+	cursor  int
+	styles  theme.Styles
+	keys    theme.KeyMap
+	width   int
+	height  int
+
+	// Add mode: pick a field to add as a new column
+	adding     bool
+	addInput   textinput.Model
+	candidates []models.FieldDefinition
+	addCursor  int
+	err        error
+}
+
+// NewColumnModal creates a new column modal
+func NewColumnModal(styles theme.Styles, keys theme.KeyMap) ColumnModal {
+	ti := textinput.New()
+	ti.Placeholder = "Field name or reference name..."
+	ti.CharLimit = 100
+	ti.Width = 40
+
+	return ColumnModal{
+		styles:   styles,
+		keys:     keys,
+		addInput: ti,
+	}
+}
+
+// Init initializes the modal
+func (m ColumnModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m ColumnModal) Update(msg tea.Msg) (ColumnModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.adding {
+		return m.updateAdding(keyMsg)
+	}
+
+	m.err = nil
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case keyMsg.Type == tea.KeyEnter:
+		columns := append([]models.Column{}, m.columns...)
+		return m, func() tea.Msg { return ColumnsChangedMsg{Columns: columns} }
+	case keyMsg.String() == "K" || keyMsg.String() == "shift+up":
+		if m.cursor > 0 {
+			m.columns[m.cursor], m.columns[m.cursor-1] = m.columns[m.cursor-1], m.columns[m.cursor]
+			m.cursor--
+		}
+	case keyMsg.String() == "J" || keyMsg.String() == "shift+down":
+		if m.cursor < len(m.columns)-1 {
+			m.columns[m.cursor], m.columns[m.cursor+1] = m.columns[m.cursor+1], m.columns[m.cursor]
+			m.cursor++
+		}
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.columns)-1 {
+			m.cursor++
+		}
+	case keyMsg.String() == "+" || keyMsg.String() == "=":
+		m.resize(1)
+	case keyMsg.String() == "-":
+		m.resize(-1)
+	case keyMsg.String() == "f":
+		if len(m.columns) > 0 {
+			col := &m.columns[m.cursor]
+			if col.IsFlex() {
+				col.Flex = false
+				col.Width = col.ColumnWidth()
+			} else {
+				col.Flex = true
+				col.Width = 0
+			}
+		}
+	case keyMsg.String() == "d" || keyMsg.String() == "x":
+		if len(m.columns) <= 1 {
+			m.err = fmt.Errorf("at least one column is required")
+			return m, nil
+		}
+		m.columns = append(m.columns[:m.cursor], m.columns[m.cursor+1:]...)
+		if m.cursor >= len(m.columns) {
+			m.cursor = len(m.columns) - 1
+		}
+	case keyMsg.String() == "a":
+		m.adding = true
+		m.addInput.SetValue("")
+		m.addInput.Focus()
+		m.applyAddFilter()
+		return m, textinput.Blink
+	}
+
+	return m, nil
+}
+
+func (m ColumnModal) updateAdding(msg tea.KeyMsg) (ColumnModal, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.adding = false
+		m.addInput.Blur()
+		return m, nil
+	case "up":
+		if m.addCursor > 0 {
+			m.addCursor--
+		}
+		return m, nil
+	case "down":
+		if m.addCursor < len(m.candidates)-1 {
+			m.addCursor++
+		}
+		return m, nil
+	case "enter":
+		var field string
+		if len(m.candidates) > 0 {
+			field = m.candidates[m.addCursor].ReferenceName
+		} else if value := strings.TrimSpace(m.addInput.Value()); strings.Contains(value, ".") {
+			// Allow any reference name, even if it wasn't in the field list
+			field = value
+		}
+		if field == "" {
+			m.err = fmt.Errorf("no matching field")
+			return m, nil
+		}
+		for _, col := range m.columns {
+			if col.Field == field {
+				m.err = fmt.Errorf("column already shown")
+				return m, nil
+			}
+		}
+
+		// Insert after the cursor so the new column is easy to move
+		col := models.Column{Field: field}
+		if known, ok := models.KnownColumn(field); ok {
+			col = known
+		}
+		insertAt := m.cursor + 1
+		if insertAt > len(m.columns) {
+			insertAt = len(m.columns)
+		}
+		m.columns = append(m.columns[:insertAt], append([]models.Column{col}, m.columns[insertAt:]...)...)
+		m.cursor = insertAt
+		m.adding = false
+		m.err = nil
+		m.addInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.addInput, cmd = m.addInput.Update(msg)
+	m.applyAddFilter()
+	return m, cmd
+}
+
+// resize changes the width of the column under the cursor
+func (m *ColumnModal) resize(delta int) {
+	if len(m.columns) == 0 {
+		return
+	}
+	col := &m.columns[m.cursor]
+	width := col.ColumnWidth() + delta
+	if width < 3 {
+		width = 3
+	}
+	col.Flex = false
+	col.Width = width
+}
+
+// availableFields returns the known columns followed by the project's fields
+func (m *ColumnModal) availableFields() []models.FieldDefinition {
+	seen := make(map[string]bool)
+	var fields []models.FieldDefinition
+	for _, col := range models.KnownColumns {
+		seen[col.Field] = true
+		fields = append(fields, models.FieldDefinition{Name: col.HeaderTitle(), ReferenceName: col.Field})
+	}
+	for _, f := range m.fields {
+		if !seen[f.ReferenceName] {
+			seen[f.ReferenceName] = true
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func (m *ColumnModal) applyAddFilter() {
+	filter := strings.ToLower(strings.TrimSpace(m.addInput.Value()))
+	shown := make(map[string]bool)
+	for _, col := range m.columns {
+		shown[col.Field] = true
+	}
+
+	m.candidates = m.candidates[:0]
+	for _, f := range m.availableFields() {
+		if shown[f.ReferenceName] {
+			continue
+		}
+		if filter == "" ||
+			strings.Contains(strings.ToLower(f.Name), filter) ||
+			strings.Contains(strings.ToLower(f.ReferenceName), filter) {
+			m.candidates = append(m.candidates, f)
+		}
+	}
+	if m.addCursor >= len(m.candidates) {
+		m.addCursor = 0
+	}
+}
+
+// View renders the modal
+func (m ColumnModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	// Modal dimensions
+	modalWidth := 60
+	visibleItems := 10
+	modalHeight := visibleItems + 9
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Columns")
+	b.WriteString(title + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+
+	if m.adding {
+		b.WriteString(m.addInput.View() + "\n\n")
+
+		offset := 0
+		if m.addCursor >= visibleItems {
+			offset = m.addCursor - visibleItems + 1
+		}
+		end := offset + visibleItems
+		if end > len(m.candidates) {
+			end = len(m.candidates)
+		}
+		if len(m.candidates) == 0 {
+			b.WriteString(mutedStyle.Render("  No matching fields (Enter adds a reference name as typed)") + "\n")
+		}
+		for i := offset; i < end; i++ {
+			f := m.candidates[i]
+			name := padRight(truncateStr(f.Name, 24), 25)
+			ref := mutedStyle.Render(truncateStr(f.ReferenceName, modalWidth-34))
+			if i == m.addCursor {
+				b.WriteString("▸ " + cursorStyle.Render(name) + ref + "\n")
+			} else {
+				b.WriteString("  " + name + ref + "\n")
+			}
+		}
+	} else {
+		for i, col := range m.columns {
+			cursor := "  "
+			style := lipgloss.NewStyle()
+			if i == m.cursor {
+				cursor = "▸ "
+				style = cursorStyle
+			}
+			width := fmt.Sprintf("%3d", col.ColumnWidth())
+			if col.IsFlex() {
+				width = "flex"
+			}
+			name := padRight(truncateStr(col.HeaderTitle(), 16), 17)
+			b.WriteString(cursor + style.Render(name) + padRight(width, 6) + mutedStyle.Render(truncateStr(col.Field, modalWidth-32)) + "\n")
+		}
+	}
+
+	// Error message
+	b.WriteString("\n")
+	if m.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString(errStyle.Render(m.err.Error()) + "\n")
+	}
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	if m.adding {
+		b.WriteString(helpStyle.Render("Enter: add  ↑/↓: choose  Esc: back"))
+	} else {
+		b.WriteString(helpStyle.Render("a: add  d: remove  J/K: move  +/-: width  f: flex") + "\n")
+		b.WriteString(helpStyle.Render("Enter: apply  Esc: cancel"))
+	}
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Height(modalHeight).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetVisible sets the visibility
+func (m *ColumnModal) SetVisible(visible bool) {
+	m.visible = visible
+	m.adding = false
+	m.err = nil
+	m.addInput.Blur()
+	if visible {
+		m.cursor = 0
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *ColumnModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetColumns sets the columns to edit (the slice is copied)
+func (m *ColumnModal) SetColumns(columns []models.Column) {
+	m.columns = append([]models.Column{}, columns...)
+}
+
+// SetFields sets the field definitions offered when adding a column
+func (m *ColumnModal) SetFields(fields []models.FieldDefinition) {
+	m.fields = fields
+}
+
+// SetSize sets the modal container size
+func (m *ColumnModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// ColumnsChangedMsg is sent when the user applies a new column layout
+type ColumnsChangedMsg struct {
+	Columns []models.Column
+}
@@ -0,0 +1,426 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// CommentTarget is what a comment is posted on: the discussion of a work
+// item, a pull request as a whole, a line of one of its files, or a thread
+// to reply to
+type CommentTarget struct {
+	WorkItem *models.WorkItem
+
+	PullRequest *models.PullRequest
+
+	// Thread replied to
+	Thread *models.CommentThread
+
+	// Line a new thread is started on, in the latest iteration
+	Change    *models.PullRequestChange
+	Line      int
+	LeftSide  bool // Line is in the old version of the file
+	Iteration int
+}
+
+// Title returns the heading of the comment modal for the target
+func (t CommentTarget) Title() string {
+	switch {
+	case t.WorkItem != nil:
+		return fmt.Sprintf("Comment on #%d %s", t.WorkItem.ID, t.WorkItem.Title)
+	case t.PullRequest == nil:
+		return "Comment"
+	case t.Thread != nil && t.Thread.Path != "":
+		return fmt.Sprintf("Reply on !%d %s:%d", t.PullRequest.ID, t.Thread.Path, t.Thread.Line)
+	case t.Thread != nil:
+		return fmt.Sprintf("Reply on !%d", t.PullRequest.ID)
+	case t.Change != nil:
+		return fmt.Sprintf("Comment on !%d %s:%d", t.PullRequest.ID, t.Change.Path, t.Line)
+	}
+	return fmt.Sprintf("Comment on !%d %s", t.PullRequest.ID, t.PullRequest.Title)
+}
+
+// mentionSearchDelay is how long typing a mention must pause before people
+// are searched on the server
+const mentionSearchDelay = 300 * time.Millisecond
+
+// maxMentionQuery is how many characters after an "@" are still completed
+const maxMentionQuery = 30
+
+// mentionSuggestions is how many people are suggested for a mention
+const mentionSuggestions = 5
+
+// CommentModal is a modal for writing a comment. Typing "@" suggests people
+// to mention, from the team and the organization.
+type CommentModal struct {
+	visible    bool
+	target     CommentTarget
+	text       textarea.Model
+	submitting bool
+	err        error
+
+	// Mentions: the team, people found on the server for the query being
+	// typed, and the people mentioned so far
+	people      []models.TeamMember
+	remote      []models.TeamMember
+	mentioned   []models.TeamMember
+	query       string
+	mentioning  bool
+	dismissed   bool
+	suggestions []models.TeamMember
+	suggestion  int
+	searchSeq   int
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewCommentModal creates a new comment modal
+func NewCommentModal(styles theme.Styles, keys theme.KeyMap) CommentModal {
+	text := textarea.New()
+	text.Placeholder = "Write a comment (Markdown)..."
+	text.ShowLineNumbers = false
+	text.SetWidth(60)
+	text.SetHeight(8)
+	text.CharLimit = 0
+
+	return CommentModal{
+		styles: styles,
+		keys:   keys,
+		text:   text,
+	}
+}
+
+// Init initializes the modal
+func (m CommentModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m CommentModal) Update(msg tea.Msg) (CommentModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		// Choosing a person to mention
+		if m.suggesting() {
+			switch keyMsg.String() {
+			case "esc":
+				m.dismissed = true
+				return m, nil
+			case "up", "ctrl+p":
+				if m.suggestion > 0 {
+					m.suggestion--
+				}
+				return m, nil
+			case "down", "ctrl+n":
+				if m.suggestion < len(m.suggestions)-1 {
+					m.suggestion++
+				}
+				return m, nil
+			case "tab", "enter":
+				m.completeMention(m.suggestions[m.suggestion])
+				return m, nil
+			}
+		}
+
+		switch keyMsg.String() {
+		case "esc":
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case "ctrl+s":
+			text := strings.TrimSpace(m.text.Value())
+			if text == "" || m.submitting {
+				return m, nil
+			}
+			m.submitting = true
+			m.err = nil
+			req := CommentSubmitMsg{Target: m.target, Text: text, Mentions: m.mentionedIn(text)}
+			return m, func() tea.Msg { return req }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.text, cmd = m.text.Update(msg)
+	return m, tea.Batch(cmd, m.updateMention())
+}
+
+// updateMention follows the mention being typed at the cursor, suggesting
+// people for it and searching the server once typing pauses
+func (m *CommentModal) updateMention() tea.Cmd {
+	query, mentioning := m.mentionQuery()
+	if query == m.query && mentioning == m.mentioning {
+		return nil
+	}
+	m.query, m.mentioning = query, mentioning
+	m.dismissed = false
+	m.remote = nil
+	m.suggestion = 0
+	m.searchSeq++
+	m.applySuggestions()
+
+	if !mentioning || utf8.RuneCountInString(strings.TrimSpace(query)) < 2 {
+		return nil
+	}
+	seq := m.searchSeq
+	query = strings.TrimSpace(query)
+	return tea.Tick(mentionSearchDelay, func(time.Time) tea.Msg {
+		return IdentitySearchRequestMsg{Query: query, Seq: seq}
+	})
+}
+
+// mentionQuery returns what is typed after an "@" before the cursor, and
+// whether the cursor is in a mention still being typed
+func (m CommentModal) mentionQuery() (string, bool) {
+	lines := strings.Split(m.text.Value(), "\n")
+	row := m.text.Line()
+	if row >= len(lines) {
+		return "", false
+	}
+	line := []rune(lines[row])
+	info := m.text.LineInfo()
+	before := string(line[:min(info.StartColumn+info.ColumnOffset, len(line))])
+
+	at := strings.LastIndex(before, "@")
+	if at < 0 {
+		return "", false
+	}
+	// "@" inside a word, like an e-mail address, isn't a mention
+	if r, _ := utf8.DecodeLastRuneInString(before[:at]); at > 0 && !unicode.IsSpace(r) && r != '(' {
+		return "", false
+	}
+	query := before[at+1:]
+	if utf8.RuneCountInString(query) > maxMentionQuery || strings.Count(query, " ") > 1 {
+		return "", false
+	}
+	for _, p := range m.mentioned {
+		if strings.HasPrefix(query, p.DisplayName) {
+			return "", false
+		}
+	}
+	return query, true
+}
+
+// applySuggestions suggests the team members and the people found on the
+// server matching the mention being typed
+func (m *CommentModal) applySuggestions() {
+	m.suggestions = nil
+	if !m.mentioning {
+		return
+	}
+	query := strings.ToLower(strings.TrimSpace(m.query))
+	seen := make(map[string]bool)
+	for _, p := range append(append([]models.TeamMember{}, m.people...), m.remote...) {
+		if p.ID == "" || seen[strings.ToLower(p.ID)] {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(p.DisplayName), query) &&
+			!strings.Contains(strings.ToLower(p.UniqueName), query) {
+			continue
+		}
+		seen[strings.ToLower(p.ID)] = true
+		m.suggestions = append(m.suggestions, p)
+		if len(m.suggestions) == mentionSuggestions {
+			break
+		}
+	}
+	if m.suggestion >= len(m.suggestions) {
+		m.suggestion = max(len(m.suggestions)-1, 0)
+	}
+}
+
+// suggesting reports whether people are suggested for a mention
+func (m CommentModal) suggesting() bool {
+	return m.mentioning && !m.dismissed && len(m.suggestions) > 0
+}
+
+// completeMention replaces the mention being typed with the person's name
+func (m *CommentModal) completeMention(person models.TeamMember) {
+	for i := 0; i < utf8.RuneCountInString(m.query)+1; i++ {
+		m.text, _ = m.text.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.Mention(person)
+}
+
+// Mention writes "@Name " at the cursor; the name is posted as a mention
+// that notifies the person
+func (m *CommentModal) Mention(person models.TeamMember) {
+	m.text.InsertString("@" + person.DisplayName + " ")
+	known := false
+	for _, p := range m.mentioned {
+		known = known || p.ID == person.ID
+	}
+	if !known {
+		m.mentioned = append(m.mentioned, person)
+	}
+	m.updateMention()
+}
+
+// mentionedIn returns the people mentioned whose names are still in text
+func (m CommentModal) mentionedIn(text string) []models.TeamMember {
+	var people []models.TeamMember
+	for _, p := range m.mentioned {
+		if strings.Contains(text, "@"+p.DisplayName) {
+			people = append(people, p)
+		}
+	}
+	return people
+}
+
+// View renders the modal
+func (m CommentModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 70
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render(truncateStr(m.target.Title(), modalWidth-6))
+	b.WriteString(title + "\n\n")
+
+	// The comment replied to
+	if t := m.target.Thread; t != nil && len(t.Comments) > 0 {
+		last := t.Comments[len(t.Comments)-1]
+		quote := last.Author + ": " + strings.Join(strings.Fields(last.Content), " ")
+		quoteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		b.WriteString(quoteStyle.Render(truncateStr(quote, modalWidth-6)) + "\n\n")
+	}
+
+	b.WriteString(m.text.View() + "\n\n")
+
+	// People to mention
+	if m.suggesting() {
+		mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		for i, p := range m.suggestions {
+			cursor := "  "
+			style := lipgloss.NewStyle()
+			if i == m.suggestion {
+				cursor = "▸ "
+				style = style.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+			}
+			name := truncateStr(p.DisplayName, 30)
+			account := truncateStr(p.UniqueName, modalWidth-6-len(cursor)-len(name)-2)
+			b.WriteString(cursor + style.Render(name) + "  " + mutedStyle.Render(account) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	switch {
+	case m.err != nil:
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString(errStyle.Render(wordWrap("Error: "+m.err.Error(), modalWidth-6)) + "\n\n")
+	case m.submitting:
+		mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		b.WriteString(mutedStyle.Render("Posting...") + "\n\n")
+	}
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	if m.suggesting() {
+		b.WriteString(helpStyle.Render("Tab/Enter: mention  ↑/↓: choose  Esc: dismiss"))
+	} else {
+		b.WriteString(helpStyle.Render("Ctrl+s: post  @: mention  Enter: new line  Esc: cancel"))
+	}
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// Open shows the modal with an empty comment for a target
+func (m *CommentModal) Open(target CommentTarget) {
+	m.target = target
+	m.submitting = false
+	m.err = nil
+	m.text.Reset()
+	m.text.Focus()
+	m.mentioned = nil
+	m.query, m.mentioning = "", false
+	m.remote = nil
+	m.applySuggestions()
+	m.visible = true
+}
+
+// SetPeople sets the team members suggested for mentions
+func (m *CommentModal) SetPeople(people []models.TeamMember) {
+	m.people = people
+}
+
+// SearchSeq returns the sequence number of the latest people search, so
+// stale search requests and results can be dropped
+func (m *CommentModal) SearchSeq() int {
+	return m.searchSeq
+}
+
+// SetSearchResults adds the people found on the server for a mention.
+// Failed searches leave the team members suggested.
+func (m *CommentModal) SetSearchResults(seq int, people []models.TeamMember, err error) {
+	if seq != m.searchSeq || err != nil {
+		return
+	}
+	m.remote = people
+	m.applySuggestions()
+}
+
+// SetError shows why posting failed, keeping the text to try again
+func (m *CommentModal) SetError(err error) {
+	m.submitting = false
+	m.err = err
+}
+
+// SetVisible sets the visibility
+func (m *CommentModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.text.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *CommentModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal size
+func (m *CommentModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// CommentSubmitMsg is sent when a comment should be posted. Mentions are
+// the people whose "@Name" in the text should notify them.
+type CommentSubmitMsg struct {
+	Target   CommentTarget
+	Text     string
+	Mentions []models.TeamMember
+}
+
+// IdentitySearchRequestMsg is sent when people should be searched on the
+// server for a mention
+type IdentitySearchRequestMsg struct {
+	Query string
+	Seq   int
+}
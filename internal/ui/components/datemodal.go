@@ -0,0 +1,245 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// Focusable fields of the date range modal
+const (
+	dateFocusField = iota
+	dateFocusFrom
+	dateFocusTo
+	dateFocusCount
+)
+
+// DateRangeModal is a modal for entering a custom date range filter
+type DateRangeModal struct {
+	visible   bool
+	field     string // models.DateFieldChanged or models.DateFieldCreated
+	fromInput textinput.Model
+	toInput   textinput.Model
+	focus     int
+	styles    theme.Styles
+	keys      theme.KeyMap
+	width     int
+	height    int
+	err       error
+}
+
+// NewDateRangeModal creates a new date range modal
+func NewDateRangeModal(styles theme.Styles, keys theme.KeyMap) DateRangeModal {
+	from := textinput.New()
+	from.Placeholder = "YYYY-MM-DD"
+	from.CharLimit = 10
+	from.Width = 12
+
+	to := textinput.New()
+	to.Placeholder = "YYYY-MM-DD"
+	to.CharLimit = 10
+	to.Width = 12
+
+	return DateRangeModal{
+		field:     models.DateFieldCreated,
+		fromInput: from,
+		toInput:   to,
+		styles:    styles,
+		keys:      keys,
+	}
+}
+
+// Init initializes the modal
+func (m DateRangeModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m DateRangeModal) Update(msg tea.Msg) (DateRangeModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Back):
+			m.visible = false
+			m.err = nil
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case key.Matches(msg, m.keys.NextPanel), msg.Type == tea.KeyDown:
+			m.setFocus((m.focus + 1) % dateFocusCount)
+			return m, nil
+		case key.Matches(msg, m.keys.PrevPanel), msg.Type == tea.KeyUp:
+			m.setFocus((m.focus + dateFocusCount - 1) % dateFocusCount)
+			return m, nil
+		case msg.Type == tea.KeyEnter:
+			cond, err := m.condition()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			return m, func() tea.Msg { return DateRangeSelectedMsg{Condition: cond} }
+		case m.focus == dateFocusField:
+			// Any of space/left/right toggles between changed and created
+			switch msg.String() {
+			case " ", "left", "right", "h", "l":
+				if m.field == models.DateFieldCreated {
+					m.field = models.DateFieldChanged
+				} else {
+					m.field = models.DateFieldCreated
+				}
+			}
+			return m, nil
+		case m.focus == dateFocusFrom:
+			m.fromInput, cmd = m.fromInput.Update(msg)
+			return m, cmd
+		case m.focus == dateFocusTo:
+			m.toInput, cmd = m.toInput.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// condition validates the inputs and builds the date condition
+func (m *DateRangeModal) condition() (models.DateCondition, error) {
+	cond := models.DateCondition{Field: m.field}
+
+	from := strings.TrimSpace(m.fromInput.Value())
+	to := strings.TrimSpace(m.toInput.Value())
+	if from == "" && to == "" {
+		return cond, fmt.Errorf("enter a start and/or end date")
+	}
+
+	var err error
+	if from != "" {
+		if cond.From, err = time.Parse("2006-01-02", from); err != nil {
+			return cond, fmt.Errorf("invalid start date: %s", from)
+		}
+	}
+	if to != "" {
+		if cond.To, err = time.Parse("2006-01-02", to); err != nil {
+			return cond, fmt.Errorf("invalid end date: %s", to)
+		}
+	}
+	if !cond.From.IsZero() && !cond.To.IsZero() && cond.To.Before(cond.From) {
+		return cond, fmt.Errorf("end date is before start date")
+	}
+
+	return cond, nil
+}
+
+func (m *DateRangeModal) setFocus(focus int) {
+	m.focus = focus
+	m.fromInput.Blur()
+	m.toInput.Blur()
+	switch focus {
+	case dateFocusFrom:
+		m.fromInput.Focus()
+	case dateFocusTo:
+		m.toInput.Focus()
+	}
+}
+
+// View renders the modal
+func (m DateRangeModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	// Modal dimensions
+	modalWidth := 44
+	modalHeight := 12
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Custom Date Range")
+	b.WriteString(title + "\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Width(8)
+	focusStyle := labelStyle.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	label := func(focus int, s string) string {
+		if m.focus == focus {
+			return focusStyle.Render(s)
+		}
+		return labelStyle.Render(s)
+	}
+
+	// Field toggle
+	changed, created := "○ Changed", "○ Created"
+	if m.field == models.DateFieldChanged {
+		changed = "● Changed"
+	} else {
+		created = "● Created"
+	}
+	b.WriteString(label(dateFocusField, "Field:") + changed + "  " + created + "\n\n")
+
+	b.WriteString(label(dateFocusFrom, "From:") + m.fromInput.View() + "\n")
+	b.WriteString(label(dateFocusTo, "To:") + m.toInput.View() + "\n")
+
+	// Error message
+	if m.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+		b.WriteString("\n" + errStyle.Render(m.err.Error()) + "\n")
+	} else {
+		b.WriteString("\n\n")
+	}
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Tab: next  Space: toggle  Enter: apply  Esc: cancel"))
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Height(modalHeight).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetVisible sets the visibility
+func (m *DateRangeModal) SetVisible(visible bool) {
+	m.visible = visible
+	m.err = nil
+	if visible {
+		m.setFocus(dateFocusField)
+	} else {
+		m.fromInput.Blur()
+		m.toInput.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *DateRangeModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *DateRangeModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// DateRangeSelectedMsg is sent when user confirms a custom date range
+type DateRangeSelectedMsg struct {
+	Condition models.DateCondition
+}
@@ -20,13 +20,24 @@ type DetailView struct {
 	height       int
 	scrollOffset int
 	maxScroll    int
+
+	// Links
+	linkCursor int                        // Index into links(), -1 when no link is selected
+	artifacts  map[string]models.Artifact // Resolved artifact links by URL
+
+	// Browser-style history of navigated items
+	back    []models.WorkItem
+	forward []models.WorkItem
+	status  string
 }
 
 // NewDetailView creates a new detail view
 func NewDetailView(styles theme.Styles, keys theme.KeyMap) DetailView {
 	return DetailView{
-		styles: styles,
-		keys:   keys,
+		styles:     styles,
+		keys:       keys,
+		linkCursor: -1,
+		artifacts:  make(map[string]models.Artifact),
 	}
 }
 
@@ -45,9 +56,23 @@ func (d DetailView) Update(msg tea.Msg) (DetailView, tea.Cmd) {
 		case key.Matches(msg, d.keys.Quit) && msg.String() == "q":
 			return d, func() tea.Msg { return CloseDetailViewMsg{} }
 		case key.Matches(msg, d.keys.Open):
+			if link := d.selectedLink(); link != nil {
+				if _, ok := models.ParseArtifactURL(link.URL); ok {
+					return d, d.openArtifactCmd(link.URL)
+				}
+				return d, openLinkCmd(*link)
+			}
 			if d.item != nil {
 				return d, func() tea.Msg { return OpenWorkItemMsg{Item: *d.item} }
 			}
+		case key.Matches(msg, d.keys.NextPanel):
+			d.moveLinkCursor(1)
+		case key.Matches(msg, d.keys.PrevPanel):
+			d.moveLinkCursor(-1)
+		case key.Matches(msg, d.keys.Left) || msg.String() == "backspace":
+			d.goBack()
+		case key.Matches(msg, d.keys.Right):
+			d.goForward()
 		case key.Matches(msg, d.keys.Up):
 			if d.scrollOffset > 0 {
 				d.scrollOffset--
@@ -88,8 +113,13 @@ func (d DetailView) View() string {
 		Render("METADATA\n" + metadataContent)
 	sections = append(sections, metadataSection)
 
-	// Parent section (if exists)
-	if d.item.ParentID > 0 {
+	// Links section, or just the parent until relations are loaded
+	if len(d.item.Relations) > 0 {
+		linksSection := d.styles.DetailSection.
+			Width(d.width - 6).
+			Render("LINKS\n" + d.renderLinks())
+		sections = append(sections, linksSection)
+	} else if d.item.ParentID > 0 {
 		parentContent := fmt.Sprintf("#%d", d.item.ParentID)
 		if d.item.ParentTitle != "" {
 			parentContent += " " + d.item.ParentTitle
@@ -188,17 +218,260 @@ func (d *DetailView) renderMetadata() string {
 	return strings.Join(rows, "\n")
 }
 
+// links returns the relations in display order (grouped by link type)
+func (d *DetailView) links() []models.WorkItemRelation {
+	if d.item == nil {
+		return nil
+	}
+	var links []models.WorkItemRelation
+	for _, group := range models.GroupRelations(d.item.Relations) {
+		links = append(links, group.Relations...)
+	}
+	return links
+}
+
+// selectedLink returns the selected relation, or nil
+func (d *DetailView) selectedLink() *models.WorkItemRelation {
+	links := d.links()
+	if d.linkCursor < 0 || d.linkCursor >= len(links) {
+		return nil
+	}
+	return &links[d.linkCursor]
+}
+
+// moveLinkCursor selects the next or previous link, wrapping through
+// "no link selected" so Enter opens the item itself again
+func (d *DetailView) moveLinkCursor(delta int) {
+	count := len(d.links())
+	if count == 0 {
+		d.linkCursor = -1
+		return
+	}
+	// Positions -1..count-1, shifted to 0..count for the modulo
+	d.linkCursor = (d.linkCursor+1+delta+count+1)%(count+1) - 1
+}
+
+func (d *DetailView) renderLinks() string {
+	groupStyle := d.styles.DetailLabel
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED"))
+	linkStyle := d.styles.DetailValue
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	var lines []string
+	index := 0
+	for _, group := range models.GroupRelations(d.item.Relations) {
+		lines = append(lines, groupStyle.Render(fmt.Sprintf("%s (%d)", group.Label, len(group.Relations))))
+		for _, rel := range group.Relations {
+			artifact, resolved := d.artifacts[rel.URL]
+			label := rel.Label()
+			if resolved {
+				label = artifact.Title()
+			}
+			label = truncateStr(label, d.width-30)
+
+			if index == d.linkCursor {
+				lines = append(lines, selectedStyle.Render("▸ "+label))
+			} else {
+				line := "  " + linkStyle.Render(label)
+				if rel.TargetState != "" {
+					line += " " + d.styles.StateBadge(string(rel.TargetState)).Render(string(rel.TargetState))
+				}
+				if resolved {
+					line += d.renderArtifactStatus(&artifact)
+				}
+				if rel.Comment != "" {
+					line += " " + mutedStyle.Render(truncateStr(rel.Comment, 40))
+				}
+				lines = append(lines, line)
+			}
+			if resolved {
+				lines = append(lines, d.renderArtifactDetails(&artifact)...)
+			}
+			index++
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderArtifactStatus renders the status badges following an artifact link:
+// the pull request status and the result of the latest build
+func (d *DetailView) renderArtifactStatus(a *models.Artifact) string {
+	var s string
+	if a.PullRequest != nil {
+		status := a.PullRequest.StatusLabel()
+		s += " " + d.styles.StatusBadge(status).Render(status)
+	}
+	if a.Build != nil {
+		status := a.Build.StatusLabel()
+		label := "Build " + status
+		if a.Ref.Kind == models.ArtifactBuild {
+			label = status
+		}
+		s += " " + d.styles.StatusBadge(status).Render(label)
+	}
+	if a.Err != nil {
+		s += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("unavailable")
+	}
+	return s
+}
+
+// renderArtifactDetails renders the indented lines below an artifact link:
+// branches and reviewer votes of pull requests, authors of commits
+func (d *DetailView) renderArtifactDetails(a *models.Artifact) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	indent := "    "
+	maxWidth := d.width - 16
+
+	var lines []string
+	switch {
+	case a.PullRequest != nil:
+		pr := a.PullRequest
+		branches := fmt.Sprintf("%s: %s → %s", pr.Repository, pr.SourceBranch, pr.TargetBranch)
+		lines = append(lines, indent+mutedStyle.Render(truncateStr(branches, maxWidth)))
+
+		if len(pr.Reviewers) > 0 {
+			var votes []string
+			for _, r := range pr.Reviewers {
+				style := d.styles.StatusBadge(voteStatus(r.Vote))
+				vote := style.Render(r.VoteSymbol()) + " " + r.Name
+				if r.Required {
+					vote += mutedStyle.Render(" (required)")
+				}
+				votes = append(votes, vote)
+			}
+			lines = append(lines, indent+strings.Join(votes, "  "))
+		}
+
+	case a.Commit != nil:
+		info := a.Commit.Author
+		if a.Repository != "" {
+			info = a.Repository + " · " + info
+		}
+		lines = append(lines, indent+mutedStyle.Render(truncateStr(info, maxWidth)))
+
+	case a.Err != nil:
+		lines = append(lines, indent+mutedStyle.Render(truncateStr(a.Err.Error(), maxWidth)))
+	}
+	return lines
+}
+
+// voteStatus maps a reviewer vote to a status with a badge color
+func voteStatus(vote int) string {
+	switch {
+	case vote >= models.VoteApprovedWithSuggestions:
+		return "succeeded"
+	case vote <= models.VoteRejected:
+		return "failed"
+	case vote <= models.VoteWaitingForAuthor:
+		return "partiallySucceeded"
+	}
+	return ""
+}
+
 func (d *DetailView) renderStatusBar() string {
-	help := "Esc Back  Enter Open in browser  j/k Scroll"
+	help := "Esc Back  Enter Open  Tab Next link  L Edit links  P Pull request  c Comment  j/k Scroll"
+	if len(d.back) > 0 || len(d.forward) > 0 {
+		help += fmt.Sprintf("  h/l History (%d/%d)", len(d.back), len(d.forward))
+	}
+	if d.status != "" {
+		help = d.status + "  " + help
+	}
 	return d.styles.StatusBar.
 		Width(d.width).
 		Render(help)
 }
 
-// SetItem sets the work item to display
-func (d *DetailView) SetItem(item *models.WorkItem) {
+// show displays an item with the scroll position and link selection reset
+func (d *DetailView) show(item *models.WorkItem) {
 	d.item = item
 	d.scrollOffset = 0
+	d.linkCursor = -1
+	d.status = ""
+}
+
+func (d *DetailView) goBack() {
+	if len(d.back) == 0 || d.item == nil {
+		return
+	}
+	d.forward = append(d.forward, *d.item)
+	prev := d.back[len(d.back)-1]
+	d.back = d.back[:len(d.back)-1]
+	d.show(&prev)
+}
+
+func (d *DetailView) goForward() {
+	if len(d.forward) == 0 || d.item == nil {
+		return
+	}
+	d.back = append(d.back, *d.item)
+	next := d.forward[len(d.forward)-1]
+	d.forward = d.forward[:len(d.forward)-1]
+	d.show(&next)
+}
+
+// SetItem sets the work item to display and clears the history
+func (d *DetailView) SetItem(item *models.WorkItem) {
+	d.show(item)
+	d.back = nil
+	d.forward = nil
+}
+
+// Navigate displays a linked item, pushing the current one on the back stack
+func (d *DetailView) Navigate(item *models.WorkItem) {
+	if d.item != nil {
+		d.back = append(d.back, *d.item)
+	}
+	d.forward = nil
+	d.show(item)
+}
+
+// SetStatus sets a message shown in the status bar, e.g. while loading a linked item
+func (d *DetailView) SetStatus(status string) {
+	d.status = status
+}
+
+// ArtifactURLs returns the artifact links (pull requests, commits, branches,
+// builds) of the displayed item
+func (d *DetailView) ArtifactURLs() []string {
+	if d.item == nil {
+		return nil
+	}
+	var urls []string
+	for _, rel := range d.item.Relations {
+		if _, ok := models.ParseArtifactURL(rel.URL); ok {
+			urls = append(urls, rel.URL)
+		}
+	}
+	return urls
+}
+
+// SetArtifacts sets resolved artifact links, keyed by link URL
+func (d *DetailView) SetArtifacts(artifacts map[string]models.Artifact) {
+	for u, a := range artifacts {
+		d.artifacts[u] = a
+	}
+}
+
+// Item returns the displayed item, or nil
+func (d *DetailView) Item() *models.WorkItem {
+	return d.item
+}
+
+// ItemID returns the ID of the displayed item, or 0
+func (d *DetailView) ItemID() int {
+	if d.item == nil {
+		return 0
+	}
+	return d.item.ID
+}
+
+// UpdateItem replaces the displayed item without resetting the scroll position
+func (d *DetailView) UpdateItem(item *models.WorkItem) {
+	d.item = item
 }
 
 // SetSize sets the size of the detail view
@@ -207,5 +480,36 @@ func (d *DetailView) SetSize(width, height int) {
 	d.height = height
 }
 
+// openLinkCmd navigates to a linked work item or opens a web link in the browser
+func openLinkCmd(link models.WorkItemRelation) tea.Cmd {
+	if link.IsWorkItem() {
+		return func() tea.Msg { return NavigateWorkItemMsg{ID: link.TargetID} }
+	}
+	if strings.HasPrefix(link.URL, "http://") || strings.HasPrefix(link.URL, "https://") {
+		return func() tea.Msg { return OpenURLMsg{URL: link.URL} }
+	}
+	return nil
+}
+
+// openArtifactCmd opens a resolved artifact link in the browser
+func (d *DetailView) openArtifactCmd(linkURL string) tea.Cmd {
+	artifact, ok := d.artifacts[linkURL]
+	if !ok || artifact.WebURL == "" {
+		d.status = "Link details not loaded yet"
+		return nil
+	}
+	return func() tea.Msg { return OpenURLMsg{URL: artifact.WebURL} }
+}
+
 // CloseDetailViewMsg is sent when the detail view should be closed
 type CloseDetailViewMsg struct{}
+
+// NavigateWorkItemMsg is sent when a linked work item should be shown
+type NavigateWorkItemMsg struct {
+	ID int
+}
+
+// OpenURLMsg is sent when a URL should be opened in the browser
+type OpenURLMsg struct {
+	URL string
+}
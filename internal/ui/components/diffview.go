@@ -0,0 +1,834 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// diffFilesWidth is the width of the changed files list, borders included
+const diffFilesWidth = 36
+
+// diffRow is a row of the diff: a line of a unified diff, or the old and new
+// lines side by side, with the comment threads on it
+type diffRow struct {
+	line        *models.DiffLine // Unified lines and hunk headers
+	left, right *models.DiffLine // Side by side; nil where a side has no line
+	outside     bool             // Threads on lines the diff doesn't show
+	threads     []int            // Indexes into the threads
+}
+
+// DiffView is the fullscreen view of a pull request's changes, file by file,
+// with its comment threads inline
+type DiffView struct {
+	pr        models.PullRequest
+	iteration models.PullRequestIteration
+	changes   []models.PullRequestChange
+	threads   []models.CommentThread
+	local     bool // Diffs come from the local repository
+	loading   bool
+	err       error
+	updated   time.Time
+	status    string
+
+	// Diff of the selected file
+	file        int
+	lines       []models.DiffLine
+	binary      bool
+	diffLoading bool
+	diffErr     error
+	sideBySide  bool
+
+	rows    []diffRow
+	cursor  int
+	offset  int
+	xOffset int
+	thread  int // ID of the selected thread on the cursor row, 0 for the first
+
+	focusDiff  bool
+	fileCursor int
+	fileOffset int
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewDiffView creates a new diff view
+func NewDiffView(styles theme.Styles, keys theme.KeyMap) DiffView {
+	return DiffView{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the diff view
+func (d DiffView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the diff view
+func (d DiffView) Update(msg tea.Msg) (DiffView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return d, nil
+	}
+	d.status = ""
+
+	switch {
+	case key.Matches(keyMsg, d.keys.Back) || keyMsg.String() == "q":
+		return d, func() tea.Msg { return CloseDiffViewMsg{} }
+	case key.Matches(keyMsg, d.keys.NextPanel), key.Matches(keyMsg, d.keys.PrevPanel):
+		d.focusDiff = !d.focusDiff
+		d.fileCursor = d.file
+		d.scrollToCursors()
+		return d, nil
+	case key.Matches(keyMsg, d.keys.Refresh):
+		return d, func() tea.Msg { return DiffRefreshMsg{} }
+	case keyMsg.String() == "o":
+		url := d.pr.WebURL
+		return d, func() tea.Msg { return OpenURLMsg{URL: url} }
+	case keyMsg.String() == "]":
+		return d, d.selectFile(d.file + 1)
+	case keyMsg.String() == "[":
+		return d, d.selectFile(d.file - 1)
+	case keyMsg.String() == "s":
+		// Stay on the same line of code
+		line, left := 0, false
+		if d.cursor < len(d.rows) {
+			line, left = d.rows[d.cursor].anchor()
+		}
+		d.sideBySide = !d.sideBySide
+		d.buildRows()
+		for i, row := range d.rows {
+			if row.hasLine(line, left) {
+				d.cursor = i
+				break
+			}
+		}
+		d.scrollToCursors()
+		return d, nil
+	}
+
+	if !d.focusDiff {
+		switch {
+		case key.Matches(keyMsg, d.keys.Up):
+			if d.fileCursor > 0 {
+				d.fileCursor--
+			}
+		case key.Matches(keyMsg, d.keys.Down):
+			if d.fileCursor < len(d.changes)-1 {
+				d.fileCursor++
+			}
+		case key.Matches(keyMsg, d.keys.Top):
+			d.fileCursor = 0
+		case key.Matches(keyMsg, d.keys.Bottom):
+			d.fileCursor = max(len(d.changes)-1, 0)
+		case keyMsg.Type == tea.KeyEnter:
+			d.focusDiff = true
+			return d, d.selectFile(d.fileCursor)
+		}
+		d.scrollToCursors()
+		return d, nil
+	}
+
+	cursor := d.cursor
+	switch {
+	case key.Matches(keyMsg, d.keys.Up):
+		d.cursor--
+	case key.Matches(keyMsg, d.keys.Down):
+		d.cursor++
+	case keyMsg.String() == "ctrl+u", keyMsg.String() == "pgup":
+		d.cursor -= d.visibleLines() / 2
+	case keyMsg.String() == "ctrl+d", keyMsg.String() == "pgdown", keyMsg.String() == " ":
+		d.cursor += d.visibleLines() / 2
+	case key.Matches(keyMsg, d.keys.Top):
+		d.cursor = 0
+	case key.Matches(keyMsg, d.keys.Bottom):
+		d.cursor = len(d.rows) - 1
+	case key.Matches(keyMsg, d.keys.Left):
+		d.xOffset = max(d.xOffset-8, 0)
+	case key.Matches(keyMsg, d.keys.Right):
+		d.xOffset += 8
+	case keyMsg.String() == "0":
+		d.xOffset = 0
+	case keyMsg.String() == "n":
+		d.nextThread(1)
+	case keyMsg.String() == "N":
+		d.nextThread(-1)
+	case keyMsg.String() == "c":
+		return d, d.commentCmd()
+	case keyMsg.String() == "r":
+		thread := d.SelectedThread()
+		if thread == nil {
+			d.status = "No thread on this line (n/N go to threads)"
+			return d, nil
+		}
+		pr, t := d.pr, *thread
+		return d, func() tea.Msg { return ComposeCommentMsg{Target: CommentTarget{PullRequest: &pr, Thread: &t}} }
+	case keyMsg.String() == "x":
+		thread := d.SelectedThread()
+		if thread == nil {
+			d.status = "No thread on this line (n/N go to threads)"
+			return d, nil
+		}
+		status := models.ThreadFixed
+		if thread.IsResolved() {
+			status = models.ThreadActive
+		}
+		req := ThreadStatusRequestMsg{PullRequest: d.pr, ThreadID: thread.ID, Status: status}
+		return d, func() tea.Msg { return req }
+	}
+	d.cursor = max(min(d.cursor, len(d.rows)-1), 0)
+	if d.cursor != cursor {
+		d.thread = 0
+	}
+	d.scrollToCursors()
+
+	return d, nil
+}
+
+// commentCmd opens the composer for a new thread on the line under the
+// cursor
+func (d *DiffView) commentCmd() tea.Cmd {
+	if d.file >= len(d.changes) || d.cursor >= len(d.rows) {
+		return nil
+	}
+	line, left := d.rows[d.cursor].anchor()
+	if line == 0 {
+		d.status = "Select a line of code to comment on"
+		return nil
+	}
+	pr, change := d.pr, d.changes[d.file]
+	target := CommentTarget{
+		PullRequest: &pr,
+		Change:      &change,
+		Line:        line,
+		LeftSide:    left,
+		Iteration:   d.iteration.ID,
+	}
+	return func() tea.Msg { return ComposeCommentMsg{Target: target} }
+}
+
+// anchor returns the line a new thread on the row goes on: the new version
+// of the file, unless the row only has a removed line
+func (r diffRow) anchor() (line int, leftSide bool) {
+	switch {
+	case r.line != nil && r.line.Kind == models.DiffRemoved:
+		return r.line.OldLine, true
+	case r.line != nil:
+		return r.line.NewLine, false
+	case r.right != nil:
+		return r.right.NewLine, false
+	case r.left != nil:
+		return r.left.OldLine, true
+	}
+	return 0, false
+}
+
+// selectFile shows the diff of another changed file
+func (d *DiffView) selectFile(file int) tea.Cmd {
+	if file < 0 || file >= len(d.changes) {
+		return nil
+	}
+	d.file, d.fileCursor = file, file
+	d.lines, d.binary, d.diffErr = nil, false, nil
+	d.diffLoading = true
+	d.cursor, d.offset, d.xOffset, d.thread = 0, 0, 0, 0
+	d.buildRows()
+	d.scrollToCursors()
+	change := d.changes[file]
+	return func() tea.Msg { return FileDiffRequestMsg{Change: change} }
+}
+
+// nextThread selects the next (dir 1) or previous (dir -1) thread of the
+// file, wrapping around
+func (d *DiffView) nextThread(dir int) {
+	type position struct{ row, thread int }
+	var positions []position
+	current := -1
+	selected := d.SelectedThread()
+	for i, row := range d.rows {
+		for _, t := range row.threads {
+			if i == d.cursor && selected != nil && d.threads[t].ID == selected.ID {
+				current = len(positions)
+			}
+			positions = append(positions, position{i, t})
+		}
+	}
+	if len(positions) == 0 {
+		d.status = "No comments on this file"
+		return
+	}
+
+	next := 0
+	switch {
+	case current >= 0:
+		next = (current + dir + len(positions)) % len(positions)
+	case dir > 0:
+		// First thread below the cursor
+		next = 0
+		for i, p := range positions {
+			if p.row > d.cursor {
+				next = i
+				break
+			}
+		}
+	default:
+		next = len(positions) - 1
+		for i := len(positions) - 1; i >= 0; i-- {
+			if positions[i].row < d.cursor {
+				next = i
+				break
+			}
+		}
+	}
+	d.cursor = positions[next].row
+	d.thread = d.threads[positions[next].thread].ID
+}
+
+// SelectedThread returns the selected thread on the cursor row, or nil
+func (d DiffView) SelectedThread() *models.CommentThread {
+	if d.cursor >= len(d.rows) {
+		return nil
+	}
+	row := d.rows[d.cursor]
+	if len(row.threads) == 0 {
+		return nil
+	}
+	for _, t := range row.threads {
+		if d.threads[t].ID == d.thread {
+			return &d.threads[t]
+		}
+	}
+	return &d.threads[row.threads[0]]
+}
+
+// buildRows lays out the diff of the selected file in rows, unified or side
+// by side, and places the file's threads on them
+func (d *DiffView) buildRows() {
+	d.rows = nil
+	for i := 0; i < len(d.lines); i++ {
+		line := &d.lines[i]
+		switch {
+		case !d.sideBySide || line.Kind == models.DiffHunk:
+			d.rows = append(d.rows, diffRow{line: line})
+		case line.Kind == models.DiffContext:
+			d.rows = append(d.rows, diffRow{left: line, right: line})
+		default:
+			// A run of removed lines pairs up with the added lines after it
+			var removed, added []*models.DiffLine
+			for ; i < len(d.lines) && d.lines[i].Kind == models.DiffRemoved; i++ {
+				removed = append(removed, &d.lines[i])
+			}
+			for ; i < len(d.lines) && d.lines[i].Kind == models.DiffAdded; i++ {
+				added = append(added, &d.lines[i])
+			}
+			i--
+			for j := 0; j < max(len(removed), len(added)); j++ {
+				var row diffRow
+				if j < len(removed) {
+					row.left = removed[j]
+				}
+				if j < len(added) {
+					row.right = added[j]
+				}
+				d.rows = append(d.rows, row)
+			}
+		}
+	}
+
+	if d.file >= len(d.changes) {
+		return
+	}
+	path := d.changes[d.file].Path
+	var outside []int
+	for t, thread := range d.threads {
+		if thread.Path != path {
+			continue
+		}
+		placed := false
+		for i := range d.rows {
+			if d.rows[i].hasLine(thread.Line, thread.LeftSide) {
+				d.rows[i].threads = append(d.rows[i].threads, t)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			outside = append(outside, t)
+		}
+	}
+	if len(outside) > 0 {
+		d.rows = append([]diffRow{{outside: true, threads: outside}}, d.rows...)
+	}
+	d.cursor = max(min(d.cursor, len(d.rows)-1), 0)
+}
+
+// changed reports whether the row shows an added or removed line
+func (r diffRow) changed() bool {
+	for _, line := range []*models.DiffLine{r.line, r.left, r.right} {
+		if line != nil && (line.Kind == models.DiffAdded || line.Kind == models.DiffRemoved) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLine reports whether the row shows a line of the old (left) or new
+// version of the file
+func (r diffRow) hasLine(number int, left bool) bool {
+	if number == 0 {
+		return false
+	}
+	for _, line := range []*models.DiffLine{r.line, r.left, r.right} {
+		switch {
+		case line == nil:
+		case left && line.Kind != models.DiffAdded && line.Kind != models.DiffHunk && line.OldLine == number:
+			return true
+		case !left && line.Kind != models.DiffRemoved && line.Kind != models.DiffHunk && line.NewLine == number:
+			return true
+		}
+	}
+	return false
+}
+
+// visibleLines is how many lines fit in a panel
+func (d *DiffView) visibleLines() int {
+	visible := d.height - 6 // title, borders, status bar
+	if visible < 1 {
+		visible = 1
+	}
+	return visible
+}
+
+func (d *DiffView) scrollToCursors() {
+	visible := d.visibleLines()
+	if d.fileCursor < d.fileOffset {
+		d.fileOffset = d.fileCursor
+	}
+	if d.fileCursor >= d.fileOffset+visible {
+		d.fileOffset = d.fileCursor - visible + 1
+	}
+
+	// Rows with threads take more than a line
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	}
+	width := d.diffWidth()
+	for d.offset < d.cursor {
+		height := 0
+		for i := d.offset; i <= d.cursor; i++ {
+			height += d.rowHeight(i, width)
+		}
+		if height <= visible {
+			break
+		}
+		d.offset++
+	}
+}
+
+// diffWidth is the width of the diff pane's content
+func (d *DiffView) diffWidth() int {
+	return max(d.width-diffFilesWidth-6, 20)
+}
+
+// rowHeight is how many lines a row takes, with its threads
+func (d *DiffView) rowHeight(i, width int) int {
+	height := 1
+	for _, t := range d.rows[i].threads {
+		height += len(d.renderThread(&d.threads[t], width, false))
+	}
+	return height
+}
+
+// View renders the diff view
+func (d DiffView) View() string {
+	title := fmt.Sprintf("!%d %s", d.pr.ID, d.pr.Title)
+	if d.iteration.ID != 0 {
+		title += fmt.Sprintf(" (update %d)", d.iteration.ID)
+	}
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(d.width - 2).
+		Render(truncateStr(title, d.width-4))
+
+	panelHeight := d.height - 4
+	diffWidth := d.diffWidth()
+
+	filesStyle, diffStyle := d.styles.PanelInactive, d.styles.PanelActive
+	if !d.focusDiff {
+		filesStyle, diffStyle = d.styles.PanelActive, d.styles.PanelInactive
+	}
+	files := filesStyle.Width(diffFilesWidth - 2).Height(panelHeight).Render(d.renderFiles())
+	diff := diffStyle.Width(diffWidth + 2).Height(panelHeight).Render(d.renderDiff(diffWidth))
+
+	help := "Esc Back  Tab Files/diff  ]/[ File  c Comment  r Reply  x Resolve  n/N Thread  s Side by side  o Open  Ctrl+r Refresh"
+	if !d.updated.IsZero() {
+		help += "  Updated " + d.updated.Format("15:04:05")
+	}
+	if d.status != "" {
+		help = d.status
+	}
+	statusBar := d.styles.StatusBar.Width(d.width).Render(help)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		lipgloss.JoinHorizontal(lipgloss.Top, files, diff),
+		statusBar,
+	)
+}
+
+// renderFiles renders the changed files list with their active threads
+func (d DiffView) renderFiles() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+
+	switch {
+	case d.err != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(wordWrap("Error: "+d.err.Error(), diffFilesWidth-4))
+	case d.loading && len(d.changes) == 0:
+		return mutedStyle.Render("Loading changes...")
+	case len(d.changes) == 0:
+		return mutedStyle.Render("No changes")
+	}
+
+	active := make(map[string]int)
+	for _, t := range d.threads {
+		if t.Path != "" && !t.IsResolved() {
+			active[t.Path]++
+		}
+	}
+
+	var lines []string
+	end := d.fileOffset + d.visibleLines()
+	for i := d.fileOffset; i < len(d.changes) && i < end; i++ {
+		change := d.changes[i]
+		count := ""
+		if n := active[change.Path]; n > 0 {
+			count = fmt.Sprintf(" %d", n)
+		}
+		// The file name matters more than its folder
+		name := change.Label()
+		if maxLen := diffFilesWidth - 8 - len(count); len(name) > maxLen {
+			name = name[:2] + "…" + name[len(name)-maxLen+3:]
+		}
+
+		cursor := "  "
+		style := lipgloss.NewStyle()
+		if i == d.file {
+			style = selectedStyle
+		}
+		if i == d.fileCursor && !d.focusDiff {
+			cursor = "▸ "
+			style = cursorStyle
+		}
+		lines = append(lines, cursor+style.Render(name)+mutedStyle.Render(count))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDiff renders the rows of the selected file's diff from the scroll
+// offset, with the threads below their lines
+func (d DiffView) renderDiff(width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	switch {
+	case d.err != nil || len(d.changes) == 0:
+		return ""
+	case d.diffErr != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(wordWrap("Error: "+d.diffErr.Error(), width))
+	case d.diffLoading:
+		return mutedStyle.Render("Loading diff...")
+	case d.binary:
+		return mutedStyle.Render("Binary file not shown")
+	case len(d.rows) == 0:
+		return mutedStyle.Render("No changes to show")
+	}
+
+	selected := d.SelectedThread()
+	visible := d.visibleLines()
+	var lines []string
+	for i := d.offset; i < len(d.rows) && len(lines) < visible; i++ {
+		row := d.rows[i]
+		lines = append(lines, d.renderRow(row, width, i == d.cursor && d.focusDiff))
+		for _, t := range row.threads {
+			thread := &d.threads[t]
+			isSelected := i == d.cursor && selected != nil && selected.ID == thread.ID
+			lines = append(lines, d.renderThread(thread, width, isSelected)...)
+		}
+	}
+	if len(lines) > visible {
+		lines = lines[:visible]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff colors
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#A78BFA"))
+	diffGutterStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	diffMutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	diffCursorStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F9FAFB")).Background(lipgloss.Color("#7C3AED"))
+)
+
+// renderRow renders a row of the diff. The cursor highlights the line
+// numbers, keeping the code's colors.
+func (d DiffView) renderRow(row diffRow, width int, cursor bool) string {
+	gutter := diffGutterStyle
+	if cursor {
+		gutter = diffCursorStyle
+	}
+
+	switch {
+	case row.outside:
+		return gutter.Render("  ") + diffMutedStyle.Render(" Comments on lines the diff doesn't show")
+	case row.line != nil && row.line.Kind == models.DiffHunk:
+		return gutter.Render("  ") + " " + diffHunkStyle.Render(d.cut(row.line.Text, width-3))
+	case row.line != nil:
+		numbers := fmt.Sprintf("%4s %4s ", lineNumber(row.line.OldLine), lineNumber(row.line.NewLine))
+		return gutter.Render(numbers) + d.renderCode(row.line, width-len(numbers))
+	}
+
+	// Side by side: each half has its own line numbers
+	half := (width - 1) / 2
+	side := func(line *models.DiffLine, number int) string {
+		if line == nil {
+			return gutter.Render("     ") + strings.Repeat(" ", half-5)
+		}
+		code := d.renderCode(line, half-5)
+		return gutter.Render(fmt.Sprintf("%4s ", lineNumber(number))) + code + strings.Repeat(" ", max(half-5-lipgloss.Width(code), 0))
+	}
+	var oldNumber, newNumber int
+	if row.left != nil {
+		oldNumber = row.left.OldLine
+	}
+	if row.right != nil {
+		newNumber = row.right.NewLine
+	}
+	return side(row.left, oldNumber) + diffGutterStyle.Render("│") + side(row.right, newNumber)
+}
+
+// renderCode renders a line of code with its +/- marker, scrolled sideways
+func (d DiffView) renderCode(line *models.DiffLine, width int) string {
+	text := d.cut(strings.ReplaceAll(line.Text, "\t", "    "), width-1)
+	switch line.Kind {
+	case models.DiffAdded:
+		return diffAddedStyle.Render("+" + text)
+	case models.DiffRemoved:
+		return diffRemovedStyle.Render("-" + text)
+	}
+	return " " + text
+}
+
+// cut returns the part of a line shown at the horizontal scroll offset
+func (d DiffView) cut(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Cut(ansi.Strip(text), d.xOffset, d.xOffset+width)
+}
+
+func lineNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return itoa(n)
+}
+
+// renderThread renders a comment thread below its line. Resolved threads
+// take a single line.
+func (d DiffView) renderThread(thread *models.CommentThread, width int, selected bool) []string {
+	barColor := lipgloss.Color("#F59E0B")
+	if thread.IsResolved() {
+		barColor = lipgloss.Color("#6B7280")
+	}
+	if selected {
+		barColor = lipgloss.Color("#7C3AED")
+	}
+	bar := lipgloss.NewStyle().Foreground(barColor).Render("      ┃ ")
+	textWidth := max(width-10, 10)
+	authorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#D1D5DB"))
+
+	header := thread.StatusLabel()
+	if thread.Line != 0 && thread.LeftSide {
+		header += fmt.Sprintf(" · old line %d", thread.Line)
+	}
+	if thread.IsResolved() {
+		first := thread.Comments[0]
+		summary := fmt.Sprintf("%s · %s: %s", header, first.Author, strings.Join(strings.Fields(first.Content), " "))
+		if len(thread.Comments) > 1 {
+			summary += fmt.Sprintf(" (+%d)", len(thread.Comments)-1)
+		}
+		return []string{bar + diffMutedStyle.Render(truncateStr(summary, textWidth))}
+	}
+
+	lines := []string{bar + diffMutedStyle.Render(header)}
+	now := time.Now()
+	for _, comment := range thread.Comments {
+		indent := ""
+		if comment.ParentID != 0 {
+			indent = "  "
+		}
+		author := authorStyle.Render(comment.Author)
+		if !comment.Published.IsZero() {
+			author += diffMutedStyle.Render(" · " + models.FormatAge(comment.Published, now))
+		}
+		lines = append(lines, bar+indent+author)
+		for _, paragraph := range strings.Split(strings.TrimSpace(comment.Content), "\n") {
+			for _, line := range strings.Split(wordWrap(paragraph, textWidth-len(indent)), "\n") {
+				lines = append(lines, bar+indent+line)
+			}
+		}
+	}
+	return lines
+}
+
+// SetPullRequest starts showing the changes of a pull request
+func (d *DiffView) SetPullRequest(pr models.PullRequest) {
+	*d = DiffView{
+		pr:         pr,
+		loading:    true,
+		focusDiff:  true,
+		sideBySide: d.sideBySide,
+		styles:     d.styles,
+		keys:       d.keys,
+		width:      d.width,
+		height:     d.height,
+	}
+}
+
+// PullRequest returns the pull request shown
+func (d DiffView) PullRequest() models.PullRequest {
+	return d.pr
+}
+
+// Iteration returns the iteration whose changes are shown
+func (d DiffView) Iteration() models.PullRequestIteration {
+	return d.iteration
+}
+
+// Local reports whether diffs come from the local repository
+func (d DiffView) Local() bool {
+	return d.local
+}
+
+// SetData sets the pull request's latest iteration, its changed files and
+// comment threads. Refreshes keep the selected file; the returned command
+// asks for its diff when there is none yet or the pull request was updated.
+func (d *DiffView) SetData(iteration models.PullRequestIteration, changes []models.PullRequestChange, threads []models.CommentThread, local bool) tea.Cmd {
+	path := ""
+	if d.file < len(d.changes) {
+		path = d.changes[d.file].Path
+	}
+	updated := iteration != d.iteration
+
+	d.loading = false
+	d.err = nil
+	d.iteration = iteration
+	d.changes = changes
+	d.threads = threads
+	d.local = local
+	d.updated = time.Now()
+
+	file := 0
+	for i, change := range changes {
+		if change.Path == path {
+			file = i
+			break
+		}
+	}
+	if updated || d.lines == nil || file != d.file {
+		return d.selectFile(file)
+	}
+	d.buildRows()
+	d.scrollToCursors()
+	return nil
+}
+
+// SetThreads replaces the comment threads
+func (d *DiffView) SetThreads(threads []models.CommentThread) {
+	d.threads = threads
+	d.buildRows()
+	d.scrollToCursors()
+}
+
+// SetFileDiff sets the diff of a changed file
+func (d *DiffView) SetFileDiff(path string, lines []models.DiffLine, binary bool, err error) {
+	if d.file >= len(d.changes) || d.changes[d.file].Path != path {
+		return
+	}
+	d.diffLoading = false
+	d.lines, d.binary, d.diffErr = lines, binary, err
+	if d.lines == nil {
+		d.lines = []models.DiffLine{}
+	}
+	d.buildRows()
+
+	// Land on the first changed line
+	d.cursor = 0
+	for i, row := range d.rows {
+		if row.changed() {
+			d.cursor = i
+			break
+		}
+	}
+	d.scrollToCursors()
+}
+
+// SetError shows an error instead of the changes
+func (d *DiffView) SetError(err error) {
+	d.loading = false
+	d.err = err
+}
+
+// SetStatus shows a message in the status bar until the next key press
+func (d *DiffView) SetStatus(status string) {
+	d.status = status
+}
+
+// SetSize sets the size of the diff view
+func (d *DiffView) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+	d.scrollToCursors()
+}
+
+// CloseDiffViewMsg is sent when the diff view should be closed
+type CloseDiffViewMsg struct{}
+
+// DiffRefreshMsg is sent when the changes and threads should be reloaded
+type DiffRefreshMsg struct{}
+
+// FileDiffRequestMsg is sent when the diff of a changed file should be
+// loaded
+type FileDiffRequestMsg struct {
+	Change models.PullRequestChange
+}
+
+// ThreadStatusRequestMsg is sent when a comment thread should be resolved
+// or reactivated
+type ThreadStatusRequestMsg struct {
+	PullRequest models.PullRequest
+	ThreadID    int
+	Status      string
+}
+
+// OpenDiffMsg is sent when the changes of a pull request should be shown
+type OpenDiffMsg struct {
+	PullRequest models.PullRequest
+}
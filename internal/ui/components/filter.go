@@ -67,6 +67,10 @@ func (f FilterPanel) Update(msg tea.Msg) (FilterPanel, tea.Cmd) {
 			}
 		case key.Matches(msg, f.keys.Select):
 			if group := f.filterState.ActiveFilterGroup(); group != nil {
+				// The custom date range needs input before it can be selected
+				if group.Type == models.FilterTypeDate && group.Options[group.Cursor].Value == models.CustomDateValue {
+					return f, func() tea.Msg { return DateRangeRequestMsg{} }
+				}
 				group.SelectCurrent()
 			}
 			return f, func() tea.Msg { return FilterChangedMsg{} }
@@ -94,85 +98,26 @@ func (f *FilterPanel) adjustOffset(group *models.FilterGroup) {
 func (f FilterPanel) View() string {
 	var b strings.Builder
 
+	// Render every group expanded first; if that doesn't fit, collapse the
+	// groups that aren't active into a one-line summary
+	rendered := make([]string, len(f.filterState.Groups))
+	totalLines := 0
 	for i, group := range f.filterState.Groups {
-		isActiveGroup := i == f.filterState.ActiveGroup && f.focused
-
-		// Group title with count if scrollable
-		titleStyle := f.styles.FilterGroupTitle
-		if isActiveGroup {
-			titleStyle = titleStyle.Foreground(lipgloss.Color("#7C3AED"))
-		}
-		title := group.Title
-		if len(group.Options) > maxVisibleOptions {
-			countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-			title += countStyle.Render(" (" + itoa(len(group.Options)) + ")")
-		}
-		b.WriteString(titleStyle.Render(title))
-		b.WriteString("\n")
-
-		// Separator
-		sep := strings.Repeat("─", min(f.width-4, 15))
-		b.WriteString(f.styles.Subtitle.Render(sep))
-		b.WriteString("\n")
-
-		// Scroll up indicator
-		if group.Offset > 0 {
-			scrollStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-			b.WriteString(scrollStyle.Render("  ▲ more"))
-			b.WriteString("\n")
-		}
-
-		// Calculate visible range
-		startIdx := group.Offset
-		endIdx := startIdx + maxVisibleOptions
-		if endIdx > len(group.Options) {
-			endIdx = len(group.Options)
-		}
-
-		// Options (only visible ones)
-		for j := startIdx; j < endIdx; j++ {
-			opt := group.Options[j]
-			isCursor := j == group.Cursor && isActiveGroup
-
-			// Selection indicator
-			var indicator string
-			if opt.Selected {
-				indicator = "●"
-			} else {
-				indicator = "○"
-			}
-
-			// Cursor indicator
-			var cursor string
-			if isCursor {
-				cursor = "▸"
-			} else {
-				cursor = " "
-			}
-
-			// Style the option
-			optionStyle := f.styles.FilterOption
-			if opt.Selected {
-				optionStyle = f.styles.FilterSelected
-			}
-			if isCursor {
-				optionStyle = optionStyle.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		rendered[i] = f.renderGroup(i, group, false)
+		totalLines += strings.Count(rendered[i], "\n") + 1
+	}
+	if f.height > 0 && totalLines > f.height {
+		for i, group := range f.filterState.Groups {
+			if i != f.filterState.ActiveGroup {
+				rendered[i] = f.renderGroup(i, group, true)
 			}
-
-			line := cursor + " " + indicator + " " + opt.Label
-			b.WriteString(optionStyle.Render(line))
-			b.WriteString("\n")
-		}
-
-		// Scroll down indicator
-		if endIdx < len(group.Options) {
-			scrollStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-			b.WriteString(scrollStyle.Render("  ▼ more"))
-			b.WriteString("\n")
 		}
+	}
 
+	for i := range rendered {
+		b.WriteString(rendered[i])
 		// Add spacing between groups
-		if i < len(f.filterState.Groups)-1 {
+		if i < len(rendered)-1 {
 			b.WriteString("\n")
 		}
 	}
@@ -191,6 +136,102 @@ func (f FilterPanel) View() string {
 		Render(content)
 }
 
+// renderGroup renders a single filter group. A compact group only shows its
+// title and a summary of the selection.
+func (f *FilterPanel) renderGroup(index int, group *models.FilterGroup, compact bool) string {
+	var b strings.Builder
+
+	isActiveGroup := index == f.filterState.ActiveGroup && f.focused
+
+	// Group title with count if scrollable
+	titleStyle := f.styles.FilterGroupTitle
+	if isActiveGroup {
+		titleStyle = titleStyle.Foreground(lipgloss.Color("#7C3AED"))
+	}
+	title := group.Title
+	if len(group.Options) > maxVisibleOptions {
+		countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		title += countStyle.Render(" (" + itoa(len(group.Options)) + ")")
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+
+	if compact {
+		summary := truncateStr(group.Summary(), f.width-6)
+		b.WriteString(f.styles.FilterSelected.Render("  " + summary))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Separator
+	sep := strings.Repeat("─", min(f.width-4, 15))
+	b.WriteString(f.styles.Subtitle.Render(sep))
+	b.WriteString("\n")
+
+	// Scroll up indicator
+	if group.Offset > 0 {
+		scrollStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		b.WriteString(scrollStyle.Render("  ▲ more"))
+		b.WriteString("\n")
+	}
+
+	// Calculate visible range
+	startIdx := group.Offset
+	endIdx := startIdx + maxVisibleOptions
+	if endIdx > len(group.Options) {
+		endIdx = len(group.Options)
+	}
+
+	// Options (only visible ones)
+	for j := startIdx; j < endIdx; j++ {
+		opt := group.Options[j]
+		isCursor := j == group.Cursor && isActiveGroup
+
+		// Selection indicator: radio buttons for single-select, checkboxes for multi-select
+		var indicator string
+		switch {
+		case group.Multi && opt.Selected:
+			indicator = "[x]"
+		case group.Multi:
+			indicator = "[ ]"
+		case opt.Selected:
+			indicator = "●"
+		default:
+			indicator = "○"
+		}
+
+		// Cursor indicator
+		var cursor string
+		if isCursor {
+			cursor = "▸"
+		} else {
+			cursor = " "
+		}
+
+		// Style the option
+		optionStyle := f.styles.FilterOption
+		if opt.Selected {
+			optionStyle = f.styles.FilterSelected
+		}
+		if isCursor {
+			optionStyle = optionStyle.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		}
+
+		line := cursor + " " + indicator + " " + truncateStr(opt.Label, f.width-10)
+		b.WriteString(optionStyle.Render(line))
+		b.WriteString("\n")
+	}
+
+	// Scroll down indicator
+	if endIdx < len(group.Options) {
+		scrollStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		b.WriteString(scrollStyle.Render("  ▼ more"))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
 // SetSize sets the size of the filter panel
 func (f *FilterPanel) SetSize(width, height int) {
 	f.width = width
@@ -215,6 +256,9 @@ func (f *FilterPanel) SetFilterState(state *models.FilterState) {
 // FilterChangedMsg is sent when a filter selection changes
 type FilterChangedMsg struct{}
 
+// DateRangeRequestMsg is sent when the user picks the custom date range option
+type DateRangeRequestMsg struct{}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -0,0 +1,240 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// GraphScope is what a dependency graph is drawn for
+type GraphScope int
+
+const (
+	GraphScopeItem GraphScope = iota
+	GraphScopeSprint
+)
+
+// GraphView is the fullscreen dependency graph view
+type GraphView struct {
+	title   string
+	scope   GraphScope
+	lines   []models.GraphLine
+	loading bool
+	err     error
+	cursor  int
+	offset  int
+	styles  theme.Styles
+	keys    theme.KeyMap
+	width   int
+	height  int
+}
+
+// NewGraphView creates a new graph view
+func NewGraphView(styles theme.Styles, keys theme.KeyMap) GraphView {
+	return GraphView{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the graph view
+func (g GraphView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the graph view
+func (g GraphView) Update(msg tea.Msg) (GraphView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, g.keys.Back):
+			return g, func() tea.Msg { return CloseGraphViewMsg{} }
+		case key.Matches(msg, g.keys.Quit) && msg.String() == "q":
+			return g, func() tea.Msg { return CloseGraphViewMsg{} }
+		case key.Matches(msg, g.keys.Up):
+			if g.cursor > 0 {
+				g.cursor--
+			}
+		case key.Matches(msg, g.keys.Down):
+			if g.cursor < len(g.lines)-1 {
+				g.cursor++
+			}
+		case key.Matches(msg, g.keys.Top):
+			g.cursor = 0
+		case key.Matches(msg, g.keys.Bottom):
+			if len(g.lines) > 0 {
+				g.cursor = len(g.lines) - 1
+			}
+		case key.Matches(msg, g.keys.Open):
+			if g.cursor < len(g.lines) {
+				node := g.lines[g.cursor].Node
+				item := models.WorkItem{ID: node.ID, Title: node.Title, WebURL: node.WebURL}
+				return g, func() tea.Msg { return OpenWorkItemMsg{Item: item} }
+			}
+		case msg.String() == "s":
+			scope := GraphScopeSprint
+			if g.scope == GraphScopeSprint {
+				scope = GraphScopeItem
+			}
+			return g, func() tea.Msg { return GraphScopeMsg{Scope: scope} }
+		}
+		g.scrollToCursor()
+	}
+
+	return g, nil
+}
+
+func (g *GraphView) visibleLines() int {
+	visible := g.height - 6 // title, legend, borders, status bar
+	if visible < 1 {
+		visible = 1
+	}
+	return visible
+}
+
+func (g *GraphView) scrollToCursor() {
+	visible := g.visibleLines()
+	if g.cursor < g.offset {
+		g.offset = g.cursor
+	}
+	if g.cursor >= g.offset+visible {
+		g.offset = g.cursor - visible + 1
+	}
+}
+
+// View renders the graph view
+func (g GraphView) View() string {
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(g.width - 2).
+		Render("Dependencies: " + g.title)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	treeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4B5563"))
+	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA"))
+	blockedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#EF4444"))
+	cursorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED"))
+
+	var body []string
+	switch {
+	case g.loading:
+		body = append(body, mutedStyle.Render("Loading links..."))
+	case g.err != nil:
+		body = append(body, blockedStyle.Render("Error: "+g.err.Error()))
+	case len(g.lines) == 0:
+		body = append(body, mutedStyle.Render("No work items"))
+	}
+
+	if !g.loading && g.err == nil {
+		end := g.offset + g.visibleLines()
+		for i := g.offset; i < len(g.lines) && i < end; i++ {
+			line := g.lines[i]
+			node := line.Node
+
+			title := node.Title
+			if node.External {
+				title += " (outside scope)"
+			}
+			label := truncateStr(title, g.width-len([]rune(line.Prefix))-30)
+
+			if i == g.cursor {
+				text := line.Prefix + node.Label()
+				if line.Repeat {
+					text += " (see above)"
+				} else if node.Blocked {
+					text += " BLOCKED"
+				}
+				body = append(body, cursorStyle.Render(truncateStr(text, g.width-6)))
+				continue
+			}
+
+			var b strings.Builder
+			b.WriteString(treeStyle.Render(line.Prefix))
+			b.WriteString(idStyle.Render("#"+itoa(node.ID)) + " ")
+			switch {
+			case line.Repeat:
+				b.WriteString(mutedStyle.Render(label + " (see above)"))
+			case node.Closed:
+				b.WriteString(mutedStyle.Render(label))
+			case node.Blocked:
+				b.WriteString(blockedStyle.Render(label))
+			default:
+				b.WriteString(label)
+			}
+			if node.State != "" && !line.Repeat {
+				b.WriteString(" " + g.styles.StateBadge(string(node.State)).Render(string(node.State)))
+			}
+			if node.Blocked && !line.Repeat {
+				b.WriteString(" " + blockedStyle.Render("BLOCKED"))
+			}
+			body = append(body, b.String())
+		}
+	}
+
+	legend := mutedStyle.Render("─ child   → successor   ") + blockedStyle.Render("BLOCKED") +
+		mutedStyle.Render(" waits on an unfinished predecessor")
+
+	content := strings.Join(append([]string{legend, ""}, body...), "\n")
+	mainContent := g.styles.PanelActive.
+		Width(g.width).
+		Height(g.height - 3).
+		Render(content)
+
+	scopeHelp := "s Sprint"
+	if g.scope == GraphScopeSprint {
+		scopeHelp = "s Selected item"
+	}
+	statusBar := g.styles.StatusBar.
+		Width(g.width).
+		Render("Esc Back  j/k Move  Enter Open in browser  " + scopeHelp)
+
+	return lipgloss.JoinVertical(lipgloss.Left, titleBar, mainContent, statusBar)
+}
+
+// SetLoading shows the loading state for a new graph
+func (g *GraphView) SetLoading(title string, scope GraphScope) {
+	g.title = title
+	g.scope = scope
+	g.loading = true
+	g.err = nil
+	g.lines = nil
+	g.cursor = 0
+	g.offset = 0
+}
+
+// SetGraph sets the graph to display
+func (g *GraphView) SetGraph(graph *models.DependencyGraph) {
+	g.loading = false
+	g.lines = graph.Lines()
+}
+
+// SetError shows an error instead of the graph
+func (g *GraphView) SetError(err error) {
+	g.loading = false
+	g.err = err
+}
+
+// SetSize sets the size of the graph view
+func (g *GraphView) SetSize(width, height int) {
+	g.width = width
+	g.height = height
+	g.scrollToCursor()
+}
+
+// CloseGraphViewMsg is sent when the graph view should be closed
+type CloseGraphViewMsg struct{}
+
+// GraphScopeMsg is sent when the graph should be redrawn for another scope
+type GraphScopeMsg struct {
+	Scope GraphScope
+}
@@ -0,0 +1,546 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// LinkAction is a change to the links of a work item
+type LinkAction int
+
+const (
+	LinkSetParent LinkAction = iota
+	LinkAddChild
+	LinkRelated
+	LinkDuplicateOf
+	LinkPredecessor
+	LinkSuccessor
+	LinkBranch
+	LinkRemoveParent
+	LinkRemove
+)
+
+// linkActions are the actions in the order they are listed
+var linkActions = []LinkAction{
+	LinkSetParent,
+	LinkAddChild,
+	LinkRelated,
+	LinkDuplicateOf,
+	LinkPredecessor,
+	LinkSuccessor,
+	LinkBranch,
+	LinkRemoveParent,
+	LinkRemove,
+}
+
+// Label returns the display name of the action
+func (a LinkAction) Label() string {
+	switch a {
+	case LinkSetParent:
+		return "Set parent"
+	case LinkAddChild:
+		return "Add child"
+	case LinkRelated:
+		return "Add related item"
+	case LinkDuplicateOf:
+		return "Mark as duplicate of"
+	case LinkPredecessor:
+		return "Add predecessor"
+	case LinkSuccessor:
+		return "Add successor"
+	case LinkBranch:
+		return "Link current git branch"
+	case LinkRemoveParent:
+		return "Remove parent"
+	case LinkRemove:
+		return "Remove link"
+	}
+	return ""
+}
+
+// Rel returns the link type added from the item to the target,
+// or "" for actions that don't add a plain link
+func (a LinkAction) Rel() string {
+	switch a {
+	case LinkRelated:
+		return models.RelRelated
+	case LinkDuplicateOf:
+		return models.RelDuplicateReverse
+	case LinkPredecessor:
+		return models.RelDependencyReverse
+	case LinkSuccessor:
+		return models.RelDependencyForward
+	}
+	return ""
+}
+
+// needsTarget reports whether the action picks another work item
+func (a LinkAction) needsTarget() bool {
+	return a != LinkRemove && a != LinkRemoveParent && a != LinkBranch
+}
+
+type linkModalMode int
+
+const (
+	linkModeAction linkModalMode = iota
+	linkModePicker
+	linkModeRemove
+)
+
+// linkSearchDelay is how long typing must pause before searching the server
+const linkSearchDelay = 300 * time.Millisecond
+
+// LinkModal is a modal for adding and removing links of a work item
+type LinkModal struct {
+	visible bool
+	item    *models.WorkItem
+	mode    linkModalMode
+	action  LinkAction
+	cursor  int
+	styles  theme.Styles
+	keys    theme.KeyMap
+	width   int
+	height  int
+
+	// Picker
+	input      textinput.Model
+	candidates []models.WorkItem // Loaded items, searched locally
+	remote     []models.WorkItem // Results of the last server search
+	results    []models.WorkItem
+	searchSeq  int
+	searching  bool
+	searchErr  error
+
+	// Remove
+	confirm bool
+}
+
+// NewLinkModal creates a new link modal
+func NewLinkModal(styles theme.Styles, keys theme.KeyMap) LinkModal {
+	ti := textinput.New()
+	ti.Placeholder = "ID or title..."
+	ti.CharLimit = 100
+	ti.Width = 50
+
+	return LinkModal{
+		styles: styles,
+		keys:   keys,
+		input:  ti,
+	}
+}
+
+// Init initializes the modal
+func (m LinkModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m LinkModal) Update(msg tea.Msg) (LinkModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.mode {
+	case linkModePicker:
+		return m.updatePicker(keyMsg)
+	case linkModeRemove:
+		return m.updateRemove(keyMsg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(linkActions)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, m.keys.Select):
+		if m.item == nil {
+			return m, nil
+		}
+		m.action = linkActions[m.cursor]
+		m.cursor = 0
+		switch m.action {
+		case LinkRemoveParent:
+			if m.item.ParentID == 0 {
+				return m, nil
+			}
+			return m, m.requestCmd(0, models.WorkItemRelation{})
+		case LinkBranch:
+			return m, m.requestCmd(0, models.WorkItemRelation{})
+		case LinkRemove:
+			m.mode = linkModeRemove
+			m.confirm = false
+		default:
+			m.mode = linkModePicker
+			m.input.SetValue("")
+			m.remote = nil
+			m.searchErr = nil
+			m.applyFilter()
+			m.input.Focus()
+			return m, textinput.Blink
+		}
+	}
+
+	return m, nil
+}
+
+func (m LinkModal) updatePicker(msg tea.KeyMsg) (LinkModal, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.mode = linkModeAction
+		m.input.Blur()
+		m.cursor = m.actionIndex()
+		return m, nil
+	case tea.KeyEnter:
+		if m.cursor < len(m.results) {
+			return m, m.requestCmd(m.results[m.cursor].ID, models.WorkItemRelation{})
+		}
+		return m, nil
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.cursor < len(m.results)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() == before {
+		return m, cmd
+	}
+
+	// Filter loaded items right away, search the server once typing pauses
+	m.remote = nil
+	m.searchErr = nil
+	m.cursor = 0
+	m.applyFilter()
+	m.searchSeq++
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.searching = false
+		return m, cmd
+	}
+	m.searching = true
+	seq := m.searchSeq
+	search := tea.Tick(linkSearchDelay, func(time.Time) tea.Msg {
+		return WorkItemSearchRequestMsg{Query: query, Seq: seq}
+	})
+	return m, tea.Batch(cmd, search)
+}
+
+func (m LinkModal) updateRemove(msg tea.KeyMsg) (LinkModal, tea.Cmd) {
+	links := m.links()
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		if m.confirm {
+			m.confirm = false
+			return m, nil
+		}
+		m.mode = linkModeAction
+		m.cursor = m.actionIndex()
+	case key.Matches(msg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+			m.confirm = false
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.cursor < len(links)-1 {
+			m.cursor++
+			m.confirm = false
+		}
+	case key.Matches(msg, m.keys.Select):
+		if m.cursor >= len(links) {
+			return m, nil
+		}
+		// Ask once before removing
+		if !m.confirm {
+			m.confirm = true
+			return m, nil
+		}
+		return m, m.requestCmd(0, links[m.cursor])
+	}
+	return m, nil
+}
+
+func (m *LinkModal) requestCmd(targetID int, relation models.WorkItemRelation) tea.Cmd {
+	req := LinkRequestMsg{
+		Item:     *m.item,
+		Action:   m.action,
+		TargetID: targetID,
+		Relation: relation,
+	}
+	return func() tea.Msg { return req }
+}
+
+// actionIndex returns the position of the current action in the action list
+func (m *LinkModal) actionIndex() int {
+	for i, a := range linkActions {
+		if a == m.action {
+			return i
+		}
+	}
+	return 0
+}
+
+// links returns the relations that can be removed, in display order
+func (m *LinkModal) links() []models.WorkItemRelation {
+	if m.item == nil {
+		return nil
+	}
+	var links []models.WorkItemRelation
+	for _, group := range models.GroupRelations(m.item.Relations) {
+		links = append(links, group.Relations...)
+	}
+	return links
+}
+
+// applyFilter rebuilds the results from matching loaded items followed by
+// server results that aren't already listed
+func (m *LinkModal) applyFilter() {
+	query := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(m.input.Value()), "#"))
+
+	m.results = nil
+	seen := make(map[int]bool)
+	if m.item != nil {
+		seen[m.item.ID] = true // Can't link an item to itself
+	}
+
+	add := func(item models.WorkItem) {
+		if !seen[item.ID] {
+			seen[item.ID] = true
+			m.results = append(m.results, item)
+		}
+	}
+
+	for _, item := range m.candidates {
+		if query == "" ||
+			strings.HasPrefix(strconv.Itoa(item.ID), query) ||
+			strings.Contains(strings.ToLower(item.Title), query) {
+			add(item)
+		}
+	}
+	for _, item := range m.remote {
+		add(item)
+	}
+
+	if m.cursor >= len(m.results) {
+		m.cursor = 0
+	}
+}
+
+// View renders the modal
+func (m LinkModal) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 70
+	listHeight := 12
+
+	var b strings.Builder
+
+	title := fmt.Sprintf("Links of #%d", m.item.ID)
+	if m.mode != linkModeAction {
+		title = fmt.Sprintf("%s #%d", m.action.Label(), m.item.ID)
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(title) + "\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(mutedStyle.Render(truncateStr(m.item.Title, modalWidth-6)) + "\n\n")
+
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	var help string
+
+	switch m.mode {
+	case linkModeAction:
+		for i, action := range linkActions {
+			label := action.Label()
+			if action == LinkRemoveParent && m.item.ParentID == 0 {
+				label = mutedStyle.Render(label + " (no parent)")
+			}
+			if i == m.cursor {
+				b.WriteString("▸ " + cursorStyle.Render(label) + "\n")
+			} else {
+				b.WriteString("  " + label + "\n")
+			}
+		}
+		help = "↑/↓: navigate  Enter: select  Esc: close"
+
+	case linkModePicker:
+		b.WriteString(m.input.View() + "\n\n")
+
+		start := 0
+		if m.cursor >= listHeight {
+			start = m.cursor - listHeight + 1
+		}
+		for i := start; i < len(m.results) && i < start+listHeight; i++ {
+			item := m.results[i]
+			line := truncateStr(fmt.Sprintf("#%d %s", item.ID, item.Title), modalWidth-22)
+			state := m.styles.StateBadge(string(item.State)).Render(string(item.State))
+			if i == m.cursor {
+				b.WriteString("▸ " + cursorStyle.Render(line) + " " + state + "\n")
+			} else {
+				b.WriteString("  " + line + " " + state + "\n")
+			}
+		}
+
+		switch {
+		case m.searchErr != nil:
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).
+				Render(truncateStr("Search failed: "+m.searchErr.Error(), modalWidth-6)) + "\n")
+		case m.searching:
+			b.WriteString(mutedStyle.Render("Searching...") + "\n")
+		case len(m.results) == 0:
+			b.WriteString(mutedStyle.Render("No matching work items") + "\n")
+		}
+		help = "↑/↓: navigate  Enter: link  Esc: back"
+
+	case linkModeRemove:
+		links := m.links()
+		if len(links) == 0 {
+			if m.item.DetailsLoaded {
+				b.WriteString(mutedStyle.Render("No links") + "\n")
+			} else {
+				b.WriteString(mutedStyle.Render("Loading links...") + "\n")
+			}
+		}
+
+		start := 0
+		if m.cursor >= listHeight {
+			start = m.cursor - listHeight + 1
+		}
+		for i := start; i < len(links) && i < start+listHeight; i++ {
+			link := links[i]
+			line := truncateStr(link.TypeLabel()+": "+link.Label(), modalWidth-8)
+			if i == m.cursor {
+				style := cursorStyle
+				if m.confirm {
+					style = style.Foreground(lipgloss.Color("#EF4444"))
+				}
+				b.WriteString("▸ " + style.Render(line) + "\n")
+			} else {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+
+		help = "↑/↓: navigate  Enter: remove  Esc: back"
+		if m.confirm {
+			help = "Enter: confirm removal  Esc: cancel"
+		}
+	}
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString("\n" + helpStyle.Render(help))
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetVisible sets the visibility
+func (m *LinkModal) SetVisible(visible bool) {
+	m.visible = visible
+	if visible {
+		m.mode = linkModeAction
+		m.cursor = 0
+		m.confirm = false
+		m.searching = false
+		m.input.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *LinkModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetItem sets the work item whose links are edited
+func (m *LinkModal) SetItem(item *models.WorkItem) {
+	m.item = item
+}
+
+// ItemID returns the ID of the edited item, or 0
+func (m *LinkModal) ItemID() int {
+	if m.item == nil {
+		return 0
+	}
+	return m.item.ID
+}
+
+// SetCandidates sets the loaded work items searched while typing
+func (m *LinkModal) SetCandidates(items []models.WorkItem) {
+	m.candidates = items
+}
+
+// SearchSeq returns the sequence number of the latest search, so stale
+// search requests and results can be dropped
+func (m *LinkModal) SearchSeq() int {
+	return m.searchSeq
+}
+
+// SetSearchResults adds the results of a server search
+func (m *LinkModal) SetSearchResults(seq int, items []models.WorkItem, err error) {
+	if seq != m.searchSeq || m.mode != linkModePicker {
+		return
+	}
+	m.searching = false
+	m.searchErr = err
+	m.remote = items
+	m.applyFilter()
+}
+
+// SetSize sets the modal size
+func (m *LinkModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// LinkRequestMsg is sent when the links of a work item should change
+type LinkRequestMsg struct {
+	Item     models.WorkItem
+	Action   LinkAction
+	TargetID int                     // Picked item, for actions that add a link
+	Relation models.WorkItemRelation // Link to remove, for LinkRemove
+}
+
+// WorkItemSearchRequestMsg is sent when work items should be searched on the server
+type WorkItemSearchRequestMsg struct {
+	Query string
+	Seq   int
+}
@@ -0,0 +1,509 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// logLineKind is how a log line is highlighted, from its ##[...] marker
+type logLineKind int
+
+const (
+	logPlain logLineKind = iota
+	logError
+	logWarning
+	logCommand
+	logSection
+	logGroup
+	logDebug
+)
+
+// logMarkers are the pipeline logging command prefixes, replaced by colors
+var logMarkers = []struct {
+	prefix string
+	kind   logLineKind
+}{
+	{"##[error]", logError},
+	{"##[warning]", logWarning},
+	{"##[command]", logCommand},
+	{"##[section]", logSection},
+	{"##[group]", logGroup},
+	{"##[debug]", logDebug},
+}
+
+// logLine is a log line prepared for display
+type logLine struct {
+	number int    // 1-based line in the full log
+	time   string // hh:mm:ss, "" when the line has no timestamp
+	text   string // With ANSI colors
+	plain  string // Without ANSI colors, for search
+	kind   logLineKind
+}
+
+// LogView shows a pipeline log with ANSI colors, search and tailing
+type LogView struct {
+	raw   []string // Lines as received, for saving
+	lines []logLine
+
+	offset     int
+	xOffset    int
+	follow     bool // Stay at the end as lines are added
+	timestamps bool
+
+	searchInput textinput.Model
+	searching   bool
+	query       string
+	matches     []int // Indices into lines
+	match       int
+
+	message string // Shown instead of the log, e.g. while loading
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewLogView creates a new log view
+func NewLogView(styles theme.Styles, keys theme.KeyMap) LogView {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "Search log..."
+	ti.CharLimit = 100
+
+	return LogView{
+		searchInput: ti,
+		styles:      styles,
+		keys:        keys,
+	}
+}
+
+// Update handles messages for the log view
+func (l LogView) Update(msg tea.Msg) (LogView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return l, nil
+	}
+
+	if l.searching {
+		switch keyMsg.Type {
+		case tea.KeyEsc:
+			l.searching = false
+			l.searchInput.Blur()
+			return l, nil
+		case tea.KeyEnter:
+			l.searching = false
+			l.searchInput.Blur()
+			l.query = strings.TrimSpace(l.searchInput.Value())
+			l.findMatches()
+			l.jumpToMatch(l.offset, 1)
+			return l, nil
+		}
+		var cmd tea.Cmd
+		l.searchInput, cmd = l.searchInput.Update(msg)
+		return l, cmd
+	}
+
+	page := l.visibleLines()
+	switch {
+	case keyMsg.String() == "/":
+		l.searching = true
+		l.searchInput.SetValue(l.query)
+		l.searchInput.CursorEnd()
+		l.searchInput.Focus()
+		return l, textinput.Blink
+	case keyMsg.String() == "n":
+		if len(l.matches) > 0 {
+			l.match = (l.match + 1) % len(l.matches)
+			l.scrollTo(l.matches[l.match])
+		}
+	case keyMsg.String() == "N":
+		if len(l.matches) > 0 {
+			l.match = (l.match - 1 + len(l.matches)) % len(l.matches)
+			l.scrollTo(l.matches[l.match])
+		}
+	case keyMsg.String() == "e":
+		l.jumpToKind(logError, 1)
+	case keyMsg.String() == "E":
+		l.jumpToKind(logError, -1)
+	case keyMsg.String() == "t":
+		l.timestamps = !l.timestamps
+	case keyMsg.String() == "s":
+		if len(l.raw) > 0 {
+			lines := l.raw
+			return l, func() tea.Msg { return SaveLogMsg{Lines: lines} }
+		}
+	case key.Matches(keyMsg, l.keys.Up):
+		l.scroll(-1)
+	case key.Matches(keyMsg, l.keys.Down):
+		l.scroll(1)
+	case keyMsg.String() == "ctrl+u", keyMsg.Type == tea.KeyPgUp:
+		l.scroll(-page)
+	case keyMsg.String() == "ctrl+d", keyMsg.Type == tea.KeyPgDown, keyMsg.Type == tea.KeySpace:
+		l.scroll(page)
+	case key.Matches(keyMsg, l.keys.Top):
+		l.offset = 0
+		l.follow = false
+	case key.Matches(keyMsg, l.keys.Bottom):
+		l.offset = l.maxOffset()
+		l.follow = true
+	case key.Matches(keyMsg, l.keys.Left):
+		l.xOffset = max(l.xOffset-8, 0)
+	case key.Matches(keyMsg, l.keys.Right):
+		l.xOffset += 8
+	case keyMsg.String() == "0":
+		l.xOffset = 0
+	}
+
+	return l, nil
+}
+
+// visibleLines is how many log lines fit above the footer
+func (l *LogView) visibleLines() int {
+	return max(l.height-1, 1)
+}
+
+func (l *LogView) maxOffset() int {
+	return max(len(l.lines)-l.visibleLines(), 0)
+}
+
+// scroll moves the view by n lines; following stops unless it ends at the
+// last line
+func (l *LogView) scroll(n int) {
+	l.offset = min(max(l.offset+n, 0), l.maxOffset())
+	l.follow = l.offset == l.maxOffset()
+}
+
+// scrollTo brings a line into view, a few lines below the top
+func (l *LogView) scrollTo(index int) {
+	l.offset = min(max(index-3, 0), l.maxOffset())
+	l.follow = false
+}
+
+// findMatches collects the lines containing the query, ignoring case
+func (l *LogView) findMatches() {
+	l.matches = nil
+	l.match = 0
+	if l.query == "" {
+		return
+	}
+	query := strings.ToLower(l.query)
+	for i, line := range l.lines {
+		if strings.Contains(strings.ToLower(line.plain), query) {
+			l.matches = append(l.matches, i)
+		}
+	}
+}
+
+// jumpToMatch selects the first match from a line on, in a direction,
+// wrapping around
+func (l *LogView) jumpToMatch(from, dir int) {
+	if len(l.matches) == 0 {
+		return
+	}
+	l.match = 0
+	if dir < 0 {
+		l.match = len(l.matches) - 1
+	}
+	for i := range l.matches {
+		if dir > 0 && l.matches[i] >= from {
+			l.match = i
+			break
+		}
+		if dir < 0 && l.matches[len(l.matches)-1-i] <= from {
+			l.match = len(l.matches) - 1 - i
+			break
+		}
+	}
+	l.scrollTo(l.matches[l.match])
+}
+
+// jumpToKind scrolls to the next line of a kind below (or above) the top line
+func (l *LogView) jumpToKind(kind logLineKind, dir int) {
+	top := min(l.offset+3, len(l.lines))
+	for i := top + dir; i >= 0 && i < len(l.lines); i += dir {
+		if l.lines[i].kind == kind {
+			l.scrollTo(i)
+			return
+		}
+	}
+}
+
+// View renders the log view
+func (l LogView) View() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	var content string
+	switch {
+	case l.message != "":
+		content = mutedStyle.Render(l.message)
+	case len(l.lines) == 0:
+		content = mutedStyle.Render("The log is empty")
+	default:
+		content = l.renderLines()
+	}
+	content = lipgloss.NewStyle().Height(l.visibleLines()).MaxHeight(l.visibleLines()).Render(content)
+
+	return content + "\n" + l.renderFooter()
+}
+
+// renderLines renders the visible part of the log
+func (l LogView) renderLines() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#111827")).Background(lipgloss.Color("#F59E0B"))
+	currentStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F9FAFB")).Background(lipgloss.Color("#7C3AED"))
+
+	digits := len(fmt.Sprint(len(l.raw)))
+	current := -1
+	if len(l.matches) > 0 {
+		current = l.matches[l.match]
+	}
+	matching := make(map[int]bool, len(l.matches))
+	for _, m := range l.matches {
+		matching[m] = true
+	}
+
+	var rows []string
+	end := min(l.offset+l.visibleLines(), len(l.lines))
+	for i := l.offset; i < end; i++ {
+		line := l.lines[i]
+
+		gutter := mutedStyle.Render(fmt.Sprintf("%*d ", digits, line.number))
+		if l.timestamps && line.time != "" {
+			gutter += mutedStyle.Render(line.time + " ")
+		}
+		width := max(l.width-lipgloss.Width(gutter), 1)
+
+		var text string
+		switch {
+		case matching[i]:
+			style := matchStyle
+			if i == current {
+				style = currentStyle
+			}
+			text = highlightMatches(ansi.Cut(line.plain, l.xOffset, l.xOffset+width), l.query, style)
+		case line.kind != logPlain && line.text == line.plain:
+			text = logKindStyle(line.kind).Render(ansi.Cut(line.plain, l.xOffset, l.xOffset+width))
+		case line.text == line.plain:
+			text = ansi.Cut(line.text, l.xOffset, l.xOffset+width)
+		default:
+			// Colors from the tools themselves; reset so they don't leak
+			text = ansi.Cut(line.text, l.xOffset, l.xOffset+width) + "\x1b[0m"
+		}
+		rows = append(rows, gutter+text)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderFooter renders the search input, or the position in the log
+func (l LogView) renderFooter() string {
+	if l.searching {
+		return l.searchInput.View()
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	var parts []string
+	if len(l.lines) > 0 {
+		end := min(l.offset+l.visibleLines(), len(l.lines))
+		parts = append(parts, fmt.Sprintf("Lines %d-%d of %d", l.lines[l.offset].number, l.lines[end-1].number, len(l.raw)))
+	}
+	if l.follow {
+		parts = append(parts, "following")
+	}
+	if l.query != "" {
+		if len(l.matches) == 0 {
+			parts = append(parts, fmt.Sprintf("no match for %q", l.query))
+		} else {
+			parts = append(parts, fmt.Sprintf("match %d/%d for %q", l.match+1, len(l.matches), l.query))
+		}
+	}
+	return mutedStyle.Render(truncateStr(strings.Join(parts, " · "), l.width))
+}
+
+// highlightMatches renders the occurrences of query in text, ignoring case
+func highlightMatches(text, query string, style lipgloss.Style) string {
+	if query == "" {
+		return text
+	}
+	lower := strings.ToLower(text)
+	query = strings.ToLower(query)
+	// Lowercasing can change byte lengths; fall back to the whole line
+	if len(lower) != len(text) {
+		return style.Render(text)
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:i])
+		b.WriteString(style.Render(text[i : i+len(query)]))
+		text, lower = text[i+len(query):], lower[i+len(query):]
+	}
+}
+
+// logKindStyle returns the color of a marked log line
+func logKindStyle(kind logLineKind) lipgloss.Style {
+	switch kind {
+	case logError:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	case logWarning:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	case logCommand:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#3B82F6"))
+	case logSection:
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#10B981"))
+	case logGroup:
+		return lipgloss.NewStyle().Bold(true)
+	case logDebug:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	}
+	return lipgloss.NewStyle()
+}
+
+// parseLogLine splits the timestamp and ##[...] marker off a log line
+func parseLogLine(number int, raw string) (logLine, bool) {
+	line := logLine{number: number}
+
+	// "2024-05-02T10:11:12.1234567Z text"
+	text := raw
+	if sp := strings.IndexByte(raw, ' '); sp >= 20 && raw[10] == 'T' && raw[sp-1] == 'Z' {
+		line.time = raw[11:19]
+		text = raw[sp+1:]
+	}
+
+	// Progress output redraws the line; only its last state is kept
+	if i := strings.LastIndexByte(text, '\r'); i >= 0 {
+		text = text[i+1:]
+	}
+	text = strings.ReplaceAll(text, "\t", "    ")
+
+	if strings.HasPrefix(text, "##[endgroup]") {
+		return line, false
+	}
+	for _, m := range logMarkers {
+		if strings.HasPrefix(text, m.prefix) {
+			line.kind = m.kind
+			text = strings.TrimPrefix(text, m.prefix)
+			if m.kind == logGroup {
+				text = "▸ " + text
+			}
+			break
+		}
+	}
+
+	line.text = text
+	line.plain = ansi.Strip(text)
+	return line, true
+}
+
+// SetLines replaces the log with the full log as it is now. A log that grew
+// stays in place, or at its end while following.
+func (l *LogView) SetLines(raw []string) {
+	grew := len(l.raw) > 0 && len(raw) >= len(l.raw)
+
+	l.raw = raw
+	l.lines = l.lines[:0]
+	for i, r := range raw {
+		if line, ok := parseLogLine(i+1, r); ok {
+			l.lines = append(l.lines, line)
+		}
+	}
+	l.message = ""
+	l.findMatches()
+
+	switch {
+	case l.follow:
+		l.offset = l.maxOffset()
+	case !grew:
+		l.offset, l.xOffset = 0, 0
+	default:
+		l.offset = min(l.offset, l.maxOffset())
+	}
+}
+
+// AppendLines adds the lines written to the log since it was last set
+func (l *LogView) AppendLines(raw []string) {
+	for _, r := range raw {
+		if line, ok := parseLogLine(len(l.raw)+1, r); ok {
+			l.lines = append(l.lines, line)
+		}
+		l.raw = append(l.raw, r)
+	}
+	l.message = ""
+	l.findMatches()
+
+	if l.follow {
+		l.offset = l.maxOffset()
+	}
+}
+
+// LineCount returns how many lines of the log have been received
+func (l LogView) LineCount() int {
+	return len(l.raw)
+}
+
+// Reset clears the log for another one, showing a message until it is set.
+// Logs that are still being written are followed.
+func (l *LogView) Reset(message string, follow bool) {
+	l.raw = nil
+	l.lines = nil
+	l.offset, l.xOffset = 0, 0
+	l.matches = nil
+	l.follow = follow
+	l.message = message
+}
+
+// SetMessage shows a message, e.g. an error, instead of the log
+func (l *LogView) SetMessage(message string) {
+	l.message = message
+}
+
+// Empty reports whether no lines are shown
+func (l LogView) Empty() bool {
+	return len(l.raw) == 0
+}
+
+// Searching reports whether the search input has focus
+func (l LogView) Searching() bool {
+	return l.searching
+}
+
+// HasSearch reports whether search matches are highlighted
+func (l LogView) HasSearch() bool {
+	return l.query != ""
+}
+
+// ClearSearch removes the search highlighting
+func (l *LogView) ClearSearch() {
+	l.query = ""
+	l.matches = nil
+	l.match = 0
+}
+
+// SetSize sets the size of the log view
+func (l *LogView) SetSize(width, height int) {
+	l.width = width
+	l.height = height
+	l.searchInput.Width = max(width-4, 10)
+	if l.follow {
+		l.offset = l.maxOffset()
+	} else {
+		l.offset = min(l.offset, l.maxOffset())
+	}
+}
+
+// SaveLogMsg is sent when the shown log should be saved to a file
+type SaveLogMsg struct {
+	Lines []string
+}
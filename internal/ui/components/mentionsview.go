@@ -0,0 +1,281 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// MentionsView is the fullscreen view of the recent discussion comments
+// that mention me, with the selected comment in full
+type MentionsView struct {
+	mentions []models.Mention
+	loading  bool
+	err      error
+	updated  time.Time
+	status   string
+
+	cursor       int
+	offset       int
+	detailOffset int
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewMentionsView creates a new mentions view
+func NewMentionsView(styles theme.Styles, keys theme.KeyMap) MentionsView {
+	return MentionsView{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the mentions view
+func (v MentionsView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the mentions view
+func (v MentionsView) Update(msg tea.Msg) (MentionsView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+
+	selected := v.SelectedMention()
+	shown := v.cursor
+	v.status = ""
+
+	switch {
+	case key.Matches(keyMsg, v.keys.Back) || keyMsg.String() == "q":
+		return v, func() tea.Msg { return CloseMentionsViewMsg{} }
+	case key.Matches(keyMsg, v.keys.Refresh):
+		return v, func() tea.Msg { return MentionsRefreshMsg{} }
+	case keyMsg.Type == tea.KeyEnter && selected != nil:
+		item := selected.Item
+		return v, func() tea.Msg { return ViewWorkItemMsg{Item: item} }
+	case keyMsg.String() == "c" && selected != nil:
+		item, author := selected.Item, selected.Comment.Author
+		return v, func() tea.Msg {
+			return ComposeCommentMsg{Target: CommentTarget{WorkItem: &item}, Mention: &author}
+		}
+	case keyMsg.String() == "o" && selected != nil:
+		url := selected.Item.WebURL
+		return v, func() tea.Msg { return OpenURLMsg{URL: url} }
+	case key.Matches(keyMsg, v.keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(keyMsg, v.keys.Down):
+		if v.cursor < len(v.mentions)-1 {
+			v.cursor++
+		}
+	case key.Matches(keyMsg, v.keys.Top):
+		v.cursor = 0
+	case key.Matches(keyMsg, v.keys.Bottom):
+		v.cursor = max(len(v.mentions)-1, 0)
+	case keyMsg.String() == "ctrl+d", keyMsg.String() == "pgdown":
+		v.detailOffset += v.visibleLines() / 2
+	case keyMsg.String() == "ctrl+u", keyMsg.String() == "pgup":
+		v.detailOffset = max(v.detailOffset-v.visibleLines()/2, 0)
+	}
+	v.scrollToCursor()
+
+	if v.cursor != shown {
+		v.detailOffset = 0
+	}
+	return v, nil
+}
+
+// visibleLines is how many list rows fit in a panel; each mention takes two
+func (v *MentionsView) visibleLines() int {
+	visible := v.height - 6 // title, borders, status bar
+	if visible < 2 {
+		visible = 2
+	}
+	return visible
+}
+
+func (v *MentionsView) scrollToCursor() {
+	visible := v.visibleLines() / 2
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+visible {
+		v.offset = v.cursor - visible + 1
+	}
+}
+
+// View renders the mentions view
+func (v MentionsView) View() string {
+	title := "Mentions"
+	if len(v.mentions) > 0 {
+		title += fmt.Sprintf(" (%d)", len(v.mentions))
+	}
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(v.width - 2).
+		Render(title)
+
+	panelHeight := v.height - 4
+	listWidth := max(v.width*2/5, 40)
+	detailWidth := max(v.width-listWidth-4, 20)
+
+	// Panels are padded by a column on each side
+	list := v.styles.PanelActive.Width(listWidth - 2).Height(panelHeight).Render(v.renderList(listWidth - 4))
+	details := v.styles.PanelInactive.Width(detailWidth).Height(panelHeight).Render(v.renderDetails(detailWidth-2, panelHeight))
+
+	help := "Esc Back  Enter View item  c Reply  o Open  Ctrl+d/u Scroll  Ctrl+r Refresh"
+	if v.loading && len(v.mentions) > 0 {
+		help += "  Refreshing..."
+	} else if !v.updated.IsZero() {
+		help += "  Updated " + v.updated.Format("15:04:05")
+	}
+	if v.status != "" {
+		help = v.status
+	}
+	statusBar := v.styles.StatusBar.Width(v.width).Render(help)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		lipgloss.JoinHorizontal(lipgloss.Top, list, details),
+		statusBar,
+	)
+}
+
+// renderList renders the mentions, two lines each: the item, and who
+// mentioned me when
+func (v MentionsView) renderList(width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	cursorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED"))
+
+	switch {
+	case v.err != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(wordWrap("Error: "+v.err.Error(), width))
+	case v.loading && len(v.mentions) == 0:
+		return mutedStyle.Render("Loading mentions...")
+	case len(v.mentions) == 0:
+		return mutedStyle.Render("No mentions in the last 30 days")
+	}
+
+	now := time.Now()
+	var lines []string
+	end := v.offset + v.visibleLines()/2
+	for i := v.offset; i < len(v.mentions) && i < end; i++ {
+		m := v.mentions[i]
+		heading := padRight(truncateStr(fmt.Sprintf("#%d %s", m.Item.ID, m.Item.Title), width), width)
+		by := truncateStr(fmt.Sprintf("  %s · %s", m.Comment.Author.DisplayName, models.FormatAge(m.Comment.Created, now)), width)
+
+		if i == v.cursor {
+			lines = append(lines, cursorStyle.Render(heading), mutedStyle.Render(by))
+			continue
+		}
+		lines = append(lines, heading, mutedStyle.Render(by))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDetails renders the selected comment with its work item
+func (v MentionsView) renderDetails(width, height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	m := v.SelectedMention()
+	if m == nil {
+		return ""
+	}
+
+	lines := []string{
+		v.styles.DetailTitle.Render(truncateStr(fmt.Sprintf("#%d %s", m.Item.ID, m.Item.Title), width)),
+		mutedStyle.Render(truncateStr(fmt.Sprintf("%s · %s · %s", m.Item.ShortType(), m.Item.State, m.Item.FieldValue(models.FieldAssignedTo)), width)),
+		"",
+		v.styles.DetailSectionTitle.Render(fmt.Sprintf("%s, %s", m.Comment.Author.DisplayName, m.Comment.Created.Local().Format("2006-01-02 15:04"))),
+	}
+	for _, paragraph := range strings.Split(m.Comment.Text, "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		for _, line := range strings.Split(wordWrap(paragraph, width-2), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	offset := min(v.detailOffset, max(len(lines)-height, 0))
+	end := min(offset+height, len(lines))
+	return strings.Join(lines[offset:end], "\n")
+}
+
+// SelectedMention returns the mention under the cursor, or nil
+func (v MentionsView) SelectedMention() *models.Mention {
+	if v.cursor < len(v.mentions) {
+		return &v.mentions[v.cursor]
+	}
+	return nil
+}
+
+// SetLoading shows that the mentions are being loaded
+func (v *MentionsView) SetLoading() {
+	v.loading = true
+	v.err = nil
+}
+
+// SetMentions sets the mentions to show, newest first. Refreshes keep the
+// cursor on the same comment.
+func (v *MentionsView) SetMentions(mentions []models.Mention) {
+	selectedID := 0
+	if m := v.SelectedMention(); m != nil {
+		selectedID = m.Comment.ID
+	}
+
+	v.loading = false
+	v.mentions = mentions
+	v.updated = time.Now()
+
+	v.cursor = 0
+	for i, m := range mentions {
+		if m.Comment.ID == selectedID {
+			v.cursor = i
+			break
+		}
+	}
+	v.scrollToCursor()
+}
+
+// SetStatus shows a message in the status bar until the next key press
+func (v *MentionsView) SetStatus(status string) {
+	v.status = status
+}
+
+// SetError shows an error instead of the mentions
+func (v *MentionsView) SetError(err error) {
+	v.loading = false
+	v.err = err
+}
+
+// SetSize sets the size of the mentions view
+func (v *MentionsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.scrollToCursor()
+}
+
+// CloseMentionsViewMsg is sent when the mentions view should be closed
+type CloseMentionsViewMsg struct{}
+
+// MentionsRefreshMsg is sent when the mentions should be reloaded
+type MentionsRefreshMsg struct{}
@@ -0,0 +1,229 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// NotificationsModal is the inbox of changes others made to my work items
+type NotificationsModal struct {
+	visible       bool
+	notifications []models.Notification
+	cursor        int
+	enabled       bool // Polling is configured
+	lastPoll      time.Time
+	err           error
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewNotificationsModal creates a new notifications modal
+func NewNotificationsModal(styles theme.Styles, keys theme.KeyMap) NotificationsModal {
+	return NotificationsModal{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m NotificationsModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m NotificationsModal) Update(msg tea.Msg) (NotificationsModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back), keyMsg.String() == "q", key.Matches(keyMsg, m.keys.Notifications):
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.cursor < len(m.notifications)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, m.keys.Top):
+		m.cursor = 0
+	case key.Matches(keyMsg, m.keys.Bottom):
+		m.cursor = max(len(m.notifications)-1, 0)
+	case key.Matches(keyMsg, m.keys.Select):
+		if m.cursor < len(m.notifications) {
+			item := m.notifications[m.cursor].Item
+			return m, func() tea.Msg { return OpenNotificationMsg{Item: item} }
+		}
+	case keyMsg.String() == "r":
+		return m, func() tea.Msg { return MarkNotificationsReadMsg{} }
+	}
+
+	return m, nil
+}
+
+// View renders the modal
+func (m NotificationsModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 70
+	contentWidth := modalWidth - 6
+	visibleItems := max(min(m.height-14, 12), 3)
+
+	var b strings.Builder
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	title := lipgloss.NewStyle().Bold(true).Render("Notifications")
+	unread := 0
+	for _, n := range m.notifications {
+		if !n.Read {
+			unread++
+		}
+	}
+	if unread > 0 {
+		title += mutedStyle.Render(fmt.Sprintf("  %d unread", unread))
+	}
+	b.WriteString(title + "\n\n")
+
+	now := time.Now()
+	switch {
+	case !m.enabled:
+		b.WriteString(mutedStyle.Render(wordWrap("Polling is off. Set notifications.interval in the config to be notified of changes to your items.", contentWidth)) + "\n")
+	case len(m.notifications) == 0:
+		b.WriteString(mutedStyle.Render("  No changes to your items yet") + "\n")
+	default:
+		offset := 0
+		if m.cursor >= visibleItems {
+			offset = m.cursor - visibleItems + 1
+		}
+		end := min(offset+visibleItems, len(m.notifications))
+
+		for i := offset; i < end; i++ {
+			n := m.notifications[i]
+
+			cursor := "  "
+			if i == m.cursor {
+				cursor = "▸ "
+			}
+			marker := "  "
+			if !n.Read {
+				marker = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("● ")
+			}
+
+			style := lipgloss.NewStyle()
+			if i == m.cursor {
+				style = style.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+			} else if n.Read {
+				style = style.Foreground(lipgloss.Color("#9CA3AF"))
+			}
+			heading := fmt.Sprintf("#%d %s: %s", n.Item.ID, n.Item.ShortType(), n.Item.Title)
+			b.WriteString(cursor + marker + style.Render(truncateStr(heading, contentWidth-4)) + "\n")
+
+			by := n.ChangedBy
+			if by == "" {
+				by = "Someone"
+			}
+			detail := fmt.Sprintf("%s changed · %s · %s · %s", by, n.Item.State, n.Reason, models.FormatAge(n.Item.ChangedDate, now))
+			b.WriteString("    " + mutedStyle.Render(truncateStr(detail, contentWidth-4)) + "\n")
+		}
+
+		if len(m.notifications) > visibleItems {
+			b.WriteString(mutedStyle.Render("  ("+itoa(m.cursor+1)+"/"+itoa(len(m.notifications))+")") + "\n")
+		}
+	}
+
+	// Poll status
+	if m.enabled {
+		b.WriteString("\n")
+		switch {
+		case m.err != nil:
+			errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+			b.WriteString(errStyle.Render(truncateStr("Polling failed: "+m.err.Error(), contentWidth)) + "\n")
+		case !m.lastPoll.IsZero():
+			b.WriteString(mutedStyle.Render("Checked "+models.FormatAge(m.lastPoll, now)) + "\n")
+		default:
+			b.WriteString(mutedStyle.Render("Not checked yet") + "\n")
+		}
+	}
+
+	// Help text
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Enter: view item  r: mark all read  Esc: close"))
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetNotifications sets the inbox shown, most recent first
+func (m *NotificationsModal) SetNotifications(notifications []models.Notification) {
+	m.notifications = notifications
+	if m.cursor >= len(notifications) {
+		m.cursor = max(len(notifications)-1, 0)
+	}
+}
+
+// SetPollState sets whether polling is on, when it last succeeded and
+// why the last poll failed, if it did
+func (m *NotificationsModal) SetPollState(enabled bool, lastPoll time.Time, err error) {
+	m.enabled = enabled
+	m.lastPoll = lastPoll
+	m.err = err
+}
+
+// SetVisible sets the visibility
+func (m *NotificationsModal) SetVisible(visible bool) {
+	m.visible = visible
+	if visible {
+		m.cursor = 0
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *NotificationsModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *NotificationsModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// OpenNotificationMsg is sent when the item of a notification should be
+// viewed
+type OpenNotificationMsg struct {
+	Item models.WorkItem
+}
+
+// MarkNotificationsReadMsg is sent when all notifications should be
+// marked read
+type MarkNotificationsReadMsg struct{}
@@ -0,0 +1,522 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// definitionsWidth is the width of the pipelines list, borders included
+const definitionsWidth = 30
+
+// PipelinesView is the fullscreen view of the project's pipelines and their
+// recent runs
+type PipelinesView struct {
+	definitions []models.BuildDefinition
+	runs        []models.Build
+	selectedDef int // Pipeline whose runs are shown, 0 for all
+	loading     bool
+	err         error
+	updated     time.Time
+	status      string
+	confirm     string // Action key pressed once, waiting to be pressed again
+
+	// "Runs for my branches": branches checked out locally, and runs I queued
+	mine       bool
+	myBranches map[string]bool
+	me         string
+
+	focusRuns bool
+	defCursor int // 0 is "All pipelines"
+	defOffset int
+	runCursor int
+	runOffset int
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewPipelinesView creates a new pipelines view
+func NewPipelinesView(styles theme.Styles, keys theme.KeyMap) PipelinesView {
+	return PipelinesView{
+		focusRuns: true,
+		styles:    styles,
+		keys:      keys,
+	}
+}
+
+// Init initializes the pipelines view
+func (p PipelinesView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the pipelines view
+func (p PipelinesView) Update(msg tea.Msg) (PipelinesView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	runs := p.visibleRuns()
+	var selected *models.Build
+	if p.focusRuns && p.runCursor < len(runs) {
+		selected = &runs[p.runCursor]
+	}
+
+	// Cancelling and retrying are confirmed by pressing the key again
+	p.status = ""
+	confirmed := p.confirm == keyMsg.String()
+	p.confirm = ""
+
+	switch {
+	case keyMsg.String() == "n":
+		if id := p.queueDefinitionID(selected); id != 0 {
+			return p, func() tea.Msg { return QueueRunMsg{DefinitionID: id} }
+		}
+		p.status = "Select a pipeline to run"
+	case keyMsg.String() == "x" && selected != nil:
+		run := *selected
+		switch {
+		case !run.IsRunning():
+			p.status = "Only queued and running runs can be cancelled"
+		case !confirmed:
+			p.confirm = "x"
+			p.status = fmt.Sprintf("Press x again to cancel %s", run.Title())
+		default:
+			return p, func() tea.Msg { return CancelRunRequestMsg{Run: run} }
+		}
+	case keyMsg.String() == "R" && selected != nil:
+		run := *selected
+		switch {
+		case !run.CanRetry():
+			p.status = "Only completed runs that didn't succeed can be retried"
+		case !confirmed:
+			p.confirm = "R"
+			p.status = fmt.Sprintf("Press R again to retry the failed stages of %s", run.Title())
+		default:
+			return p, func() tea.Msg { return RetryRunRequestMsg{Run: run} }
+		}
+	case key.Matches(keyMsg, p.keys.Back) || keyMsg.String() == "q":
+		return p, func() tea.Msg { return ClosePipelinesViewMsg{} }
+	case key.Matches(keyMsg, p.keys.NextPanel), key.Matches(keyMsg, p.keys.PrevPanel):
+		p.focusRuns = !p.focusRuns
+	case key.Matches(keyMsg, p.keys.Refresh):
+		return p, func() tea.Msg { return PipelinesRefreshMsg{} }
+	case keyMsg.String() == "m":
+		p.mine = !p.mine
+		p.runCursor, p.runOffset = 0, 0
+	case key.Matches(keyMsg, p.keys.Up):
+		if p.focusRuns && p.runCursor > 0 {
+			p.runCursor--
+		} else if !p.focusRuns && p.defCursor > 0 {
+			p.defCursor--
+		}
+	case key.Matches(keyMsg, p.keys.Down):
+		if p.focusRuns && p.runCursor < len(runs)-1 {
+			p.runCursor++
+		} else if !p.focusRuns && p.defCursor < len(p.definitions) {
+			p.defCursor++
+		}
+	case key.Matches(keyMsg, p.keys.Top):
+		if p.focusRuns {
+			p.runCursor = 0
+		} else {
+			p.defCursor = 0
+		}
+	case key.Matches(keyMsg, p.keys.Bottom):
+		if p.focusRuns {
+			p.runCursor = max(len(runs)-1, 0)
+		} else {
+			p.defCursor = len(p.definitions)
+		}
+	case keyMsg.Type == tea.KeyEnter:
+		if !p.focusRuns {
+			id := 0
+			if p.defCursor > 0 {
+				id = p.definitions[p.defCursor-1].ID
+			}
+			p.focusRuns = true
+			return p, func() tea.Msg { return PipelineSelectedMsg{DefinitionID: id} }
+		}
+		if p.runCursor < len(runs) {
+			run := runs[p.runCursor]
+			return p, func() tea.Msg { return OpenRunMsg{Run: run} }
+		}
+	case keyMsg.String() == "o":
+		if p.focusRuns && p.runCursor < len(runs) {
+			url := runs[p.runCursor].WebURL
+			return p, func() tea.Msg { return OpenURLMsg{URL: url} }
+		}
+	}
+	p.scrollToCursors()
+
+	return p, nil
+}
+
+// visibleLines is how many list rows fit in a panel
+func (p *PipelinesView) visibleLines() int {
+	visible := p.height - 7 // title, header, borders, status bar
+	if visible < 1 {
+		visible = 1
+	}
+	return visible
+}
+
+func (p *PipelinesView) scrollToCursors() {
+	visible := p.visibleLines()
+	if p.runCursor < p.runOffset {
+		p.runOffset = p.runCursor
+	}
+	if p.runCursor >= p.runOffset+visible {
+		p.runOffset = p.runCursor - visible + 1
+	}
+	if p.defCursor < p.defOffset {
+		p.defOffset = p.defCursor
+	}
+	if p.defCursor >= p.defOffset+visible {
+		p.defOffset = p.defCursor - visible + 1
+	}
+}
+
+// visibleRuns returns the runs passing the "my branches" filter
+func (p PipelinesView) visibleRuns() []models.Build {
+	if !p.mine {
+		return p.runs
+	}
+	var runs []models.Build
+	for _, run := range p.runs {
+		if p.myBranches[run.Branch()] || (p.me != "" && strings.EqualFold(run.RequesterID, p.me)) {
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// View renders the pipelines view
+func (p PipelinesView) View() string {
+	title := "Pipelines"
+	if def := p.selectedDefinition(); def != nil {
+		title += ": " + def.Name
+	}
+	if p.mine {
+		title += " (my branches)"
+	}
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(p.width - 2).
+		Render(title)
+
+	panelHeight := p.height - 4
+	runsWidth := p.width - definitionsWidth - 4
+
+	defStyle, runStyle := p.styles.PanelInactive, p.styles.PanelActive
+	if !p.focusRuns {
+		defStyle, runStyle = p.styles.PanelActive, p.styles.PanelInactive
+	}
+	definitions := defStyle.Width(definitionsWidth - 2).Height(panelHeight).Render(p.renderDefinitions())
+	runs := runStyle.Width(runsWidth).Height(panelHeight).Render(p.renderRuns(runsWidth))
+
+	help := "Esc Back  Enter Select/Show run  n Run pipeline  x Cancel  R Retry failed stages  o Open in browser  m My branches  Ctrl+r Refresh"
+	if !p.updated.IsZero() {
+		help += "  Updated " + p.updated.Format("15:04:05")
+	}
+	if p.status != "" {
+		help = p.status
+	}
+	statusBar := p.styles.StatusBar.Width(p.width).Render(help)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		lipgloss.JoinHorizontal(lipgloss.Top, definitions, runs),
+		statusBar,
+	)
+}
+
+// renderDefinitions renders the pipelines list
+func (p PipelinesView) renderDefinitions() string {
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+
+	names := []string{"All pipelines"}
+	for _, def := range p.definitions {
+		names = append(names, def.Name)
+	}
+
+	var lines []string
+	end := p.defOffset + p.visibleLines()
+	for i := p.defOffset; i < len(names) && i < end; i++ {
+		id := 0
+		if i > 0 {
+			id = p.definitions[i-1].ID
+		}
+
+		cursor := "  "
+		style := lipgloss.NewStyle()
+		if id == p.selectedDef {
+			style = selectedStyle
+		}
+		if i == p.defCursor && !p.focusRuns {
+			cursor = "▸ "
+			style = cursorStyle
+		}
+		lines = append(lines, cursor+style.Render(truncateStr(names[i], definitionsWidth-6)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderRuns renders the runs table
+func (p PipelinesView) renderRuns(width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#9CA3AF"))
+	cursorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED"))
+
+	switch {
+	case p.err != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("Error: " + p.err.Error())
+	case p.loading && len(p.runs) == 0:
+		return mutedStyle.Render("Loading runs...")
+	}
+
+	runs := p.visibleRuns()
+	if len(runs) == 0 {
+		if p.mine {
+			return mutedStyle.Render("No runs for your branches")
+		}
+		return mutedStyle.Render("No runs")
+	}
+
+	// Pipeline and branch share what the fixed columns leave
+	const fixed = 2 + 14 + 9 + 16 + 10 + 5
+	flex := max(width-fixed, 20)
+	pipelineWidth := flex * 2 / 5
+	branchWidth := flex - pipelineWidth
+
+	row := func(status, pipeline, number, branch, duration, requestedFor, queued string) string {
+		return padRight(status, 2) +
+			padRight(truncateStr(pipeline, pipelineWidth-1), pipelineWidth) +
+			padRight(truncateStr(number, 13), 14) +
+			padRight(truncateStr(branch, branchWidth-1), branchWidth) +
+			padRight(duration, 9) +
+			padRight(truncateStr(requestedFor, 15), 16) +
+			queued
+	}
+
+	lines := []string{headerStyle.Render(row("", "Pipeline", "Run", "Branch", "Duration", "Requested for", "Queued"))}
+	now := time.Now()
+	end := p.runOffset + p.visibleLines()
+	for i := p.runOffset; i < len(runs) && i < end; i++ {
+		run := runs[i]
+
+		duration := ""
+		if d := run.Duration(now); d > 0 {
+			duration = models.FormatDuration(d)
+		}
+		queued := ""
+		if !run.QueueTime.IsZero() {
+			queued = models.FormatAge(run.QueueTime, now)
+		}
+		text := row(" ", run.Definition, run.Number, run.Branch(), duration, run.RequestedFor, queued)
+
+		if i == p.runCursor && p.focusRuns {
+			lines = append(lines, cursorStyle.Render(runSymbol(run)+text[1:]))
+			continue
+		}
+		badge := p.styles.StatusBadge(runStatus(run)).Render(runSymbol(run))
+		lines = append(lines, badge+text[1:])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runStatus returns the result of a completed run, or its status
+func runStatus(run models.Build) string {
+	if run.Status == "completed" && run.Result != "" {
+		return run.Result
+	}
+	return run.Status
+}
+
+// runSymbol returns the status symbol of a run
+func runSymbol(run models.Build) string {
+	switch runStatus(run) {
+	case "succeeded":
+		return "✓"
+	case "partiallySucceeded":
+		return "!"
+	case "failed":
+		return "✗"
+	case "canceled", "cancelling":
+		return "⊘"
+	case "inProgress":
+		return "●"
+	default:
+		return "○"
+	}
+}
+
+// queueDefinitionID returns the pipeline to queue a run of: the one under
+// the cursor, the one whose runs are shown, or the selected run's
+func (p PipelinesView) queueDefinitionID(selected *models.Build) int {
+	switch {
+	case !p.focusRuns && p.defCursor > 0:
+		return p.definitions[p.defCursor-1].ID
+	case !p.focusRuns:
+		return 0
+	case p.selectedDef != 0:
+		return p.selectedDef
+	case selected != nil:
+		return selected.DefinitionID
+	}
+	return 0
+}
+
+// selectedDefinition returns the pipeline whose runs are shown, nil for all
+func (p PipelinesView) selectedDefinition() *models.BuildDefinition {
+	for i := range p.definitions {
+		if p.definitions[i].ID == p.selectedDef {
+			return &p.definitions[i]
+		}
+	}
+	return nil
+}
+
+// HasRunning reports whether any shown run is queued or in progress
+func (p PipelinesView) HasRunning() bool {
+	for _, run := range p.runs {
+		if run.IsRunning() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLoading shows that runs are being loaded for a pipeline, 0 for all
+func (p *PipelinesView) SetLoading(definitionID int) {
+	if definitionID != p.selectedDef {
+		p.runs = nil
+		p.runCursor, p.runOffset = 0, 0
+	}
+	p.selectedDef = definitionID
+	p.loading = true
+	p.err = nil
+}
+
+// SetData sets the pipelines and runs to show. Refreshes keep the cursor
+// on the same run.
+func (p *PipelinesView) SetData(definitions []models.BuildDefinition, runs []models.Build) {
+	selectedID := 0
+	if visible := p.visibleRuns(); p.runCursor < len(visible) {
+		selectedID = visible[p.runCursor].ID
+	}
+
+	p.loading = false
+	p.definitions = definitions
+	p.runs = runs
+	p.updated = time.Now()
+
+	p.runCursor = 0
+	for i, run := range p.visibleRuns() {
+		if run.ID == selectedID {
+			p.runCursor = i
+			break
+		}
+	}
+	if p.defCursor > len(p.definitions) {
+		p.defCursor = len(p.definitions)
+	}
+	p.scrollToCursors()
+}
+
+// AddRun shows a run that was just queued at the top, selected, until the
+// next refresh lists it
+func (p *PipelinesView) AddRun(run models.Build) {
+	for _, r := range p.runs {
+		if r.ID == run.ID {
+			return
+		}
+	}
+	if p.selectedDef != 0 && p.selectedDef != run.DefinitionID {
+		return
+	}
+	p.runs = append([]models.Build{run}, p.runs...)
+	p.focusRuns = true
+	p.runCursor, p.runOffset = 0, 0
+	for i, r := range p.visibleRuns() {
+		if r.ID == run.ID {
+			p.runCursor = i
+			break
+		}
+	}
+	p.scrollToCursors()
+}
+
+// SetStatus shows a message in the status bar until the next key press
+func (p *PipelinesView) SetStatus(status string) {
+	p.status = status
+}
+
+// SetError shows an error instead of the runs
+func (p *PipelinesView) SetError(err error) {
+	p.loading = false
+	p.err = err
+}
+
+// SetMine sets what counts as my runs: the locally checked out branches and
+// the current user's account
+func (p *PipelinesView) SetMine(branches []string, me string) {
+	p.myBranches = make(map[string]bool, len(branches))
+	for _, b := range branches {
+		p.myBranches[b] = true
+	}
+	p.me = me
+}
+
+// SetSize sets the size of the pipelines view
+func (p *PipelinesView) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.scrollToCursors()
+}
+
+// ClosePipelinesViewMsg is sent when the pipelines view should be closed
+type ClosePipelinesViewMsg struct{}
+
+// PipelineSelectedMsg is sent when the runs of another pipeline should be
+// shown, 0 for all pipelines
+type PipelineSelectedMsg struct {
+	DefinitionID int
+}
+
+// PipelinesRefreshMsg is sent when the pipelines should be reloaded
+type PipelinesRefreshMsg struct{}
+
+// QueueRunMsg is sent when a run of a pipeline should be queued
+type QueueRunMsg struct {
+	DefinitionID int
+}
+
+// CancelRunRequestMsg is sent when a queued or running run should be
+// cancelled
+type CancelRunRequestMsg struct {
+	Run models.Build
+}
+
+// RetryRunRequestMsg is sent when stages of a run should be retried, all
+// failed and canceled stages when none are given
+type RetryRunRequestMsg struct {
+	Run    models.Build
+	Stages []string
+}
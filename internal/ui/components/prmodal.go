@@ -0,0 +1,428 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// PullRequestSource is the local branch a pull request is created from
+type PullRequestSource struct {
+	Branch     string
+	Remote     string // Remote the branch is pushed to, e.g. "origin"
+	Repository models.Repository
+	Pushed     bool // Whether the branch has an upstream branch
+	Ahead      int  // Commits not pushed yet
+}
+
+// NeedsPush reports whether the branch must be pushed before the pull
+// request can be created
+func (s *PullRequestSource) NeedsPush() bool {
+	return !s.Pushed || s.Ahead > 0
+}
+
+// Pull request modal fields, in focus order
+const (
+	prFieldTitle = iota
+	prFieldTarget
+	prFieldDescription
+	prFieldReviewers
+	prFieldCount
+)
+
+// maxReviewerSuggestions is how many matching team members are listed
+// below the reviewers field
+const maxReviewerSuggestions = 4
+
+// PullRequestModal is a modal for creating a pull request from the current
+// branch, prefilled from a work item
+type PullRequestModal struct {
+	visible bool
+	item    *models.WorkItem
+
+	// Source branch, detected after the modal opens
+	source    *PullRequestSource
+	sourceErr error
+
+	title       textinput.Model
+	target      textinput.Model
+	description textarea.Model
+	reviewer    textinput.Model
+	focus       int
+
+	members      []models.TeamMember
+	reviewers    []models.TeamMember
+	suggestions  []models.TeamMember
+	suggestion   int
+	autoComplete bool
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewPullRequestModal creates a new pull request modal
+func NewPullRequestModal(styles theme.Styles, keys theme.KeyMap) PullRequestModal {
+	title := textinput.New()
+	title.Placeholder = "Title"
+	title.CharLimit = 400
+	title.Width = 56
+
+	target := textinput.New()
+	target.Placeholder = "main"
+	target.CharLimit = 200
+	target.Width = 56
+
+	description := textarea.New()
+	description.Placeholder = "Description"
+	description.ShowLineNumbers = false
+	description.SetWidth(60)
+	description.SetHeight(6)
+	description.CharLimit = 0
+
+	reviewer := textinput.New()
+	reviewer.Placeholder = "Type to add reviewers..."
+	reviewer.CharLimit = 50
+	reviewer.Width = 56
+
+	return PullRequestModal{
+		styles:      styles,
+		keys:        keys,
+		title:       title,
+		target:      target,
+		description: description,
+		reviewer:    reviewer,
+	}
+}
+
+// Init initializes the modal
+func (m PullRequestModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m PullRequestModal) Update(msg tea.Msg) (PullRequestModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case "tab":
+			m.setFocus((m.focus + 1) % prFieldCount)
+			return m, nil
+		case "shift+tab":
+			m.setFocus((m.focus + prFieldCount - 1) % prFieldCount)
+			return m, nil
+		case "ctrl+a":
+			m.autoComplete = !m.autoComplete
+			return m, nil
+		case "ctrl+s":
+			return m, m.submit()
+		}
+
+		if m.focus == prFieldReviewers {
+			switch keyMsg.String() {
+			case "up":
+				if m.suggestion > 0 {
+					m.suggestion--
+				}
+				return m, nil
+			case "down":
+				if m.suggestion < len(m.suggestions)-1 {
+					m.suggestion++
+				}
+				return m, nil
+			case "enter":
+				if m.suggestion < len(m.suggestions) {
+					m.reviewers = append(m.reviewers, m.suggestions[m.suggestion])
+					m.reviewer.SetValue("")
+					m.updateSuggestions()
+				}
+				return m, nil
+			case "backspace":
+				if m.reviewer.Value() == "" && len(m.reviewers) > 0 {
+					m.reviewers = m.reviewers[:len(m.reviewers)-1]
+					return m, nil
+				}
+			}
+		} else if keyMsg.String() == "enter" && m.focus != prFieldDescription {
+			m.setFocus(m.focus + 1)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.focus {
+	case prFieldTitle:
+		m.title, cmd = m.title.Update(msg)
+	case prFieldTarget:
+		m.target, cmd = m.target.Update(msg)
+	case prFieldDescription:
+		m.description, cmd = m.description.Update(msg)
+	case prFieldReviewers:
+		m.reviewer, cmd = m.reviewer.Update(msg)
+		m.updateSuggestions()
+	}
+	return m, cmd
+}
+
+// submit returns the command requesting the pull request, or nil while
+// the source branch is unknown or required fields are empty
+func (m *PullRequestModal) submit() tea.Cmd {
+	if m.item == nil || m.source == nil {
+		return nil
+	}
+	title := strings.TrimSpace(m.title.Value())
+	target := strings.TrimSpace(m.target.Value())
+	if title == "" || target == "" {
+		return nil
+	}
+
+	req := CreatePullRequestMsg{
+		Item:         *m.item,
+		Source:       *m.source,
+		Title:        title,
+		TargetBranch: target,
+		Description:  strings.TrimSpace(m.description.Value()),
+		Reviewers:    append([]models.TeamMember{}, m.reviewers...),
+		AutoComplete: m.autoComplete,
+	}
+	return func() tea.Msg { return req }
+}
+
+func (m *PullRequestModal) setFocus(focus int) {
+	m.focus = focus
+	m.title.Blur()
+	m.target.Blur()
+	m.description.Blur()
+	m.reviewer.Blur()
+
+	switch focus {
+	case prFieldTitle:
+		m.title.Focus()
+	case prFieldTarget:
+		m.target.Focus()
+	case prFieldDescription:
+		m.description.Focus()
+	case prFieldReviewers:
+		m.reviewer.Focus()
+	}
+}
+
+// updateSuggestions lists the team members matching the reviewer input
+// that aren't reviewers yet
+func (m *PullRequestModal) updateSuggestions() {
+	m.suggestions = nil
+	m.suggestion = 0
+
+	filter := strings.ToLower(strings.TrimSpace(m.reviewer.Value()))
+	if filter == "" {
+		return
+	}
+
+	for _, member := range m.members {
+		if m.isReviewer(member) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(member.DisplayName), filter) ||
+			strings.Contains(strings.ToLower(member.UniqueName), filter) {
+			m.suggestions = append(m.suggestions, member)
+			if len(m.suggestions) == maxReviewerSuggestions {
+				return
+			}
+		}
+	}
+}
+
+func (m *PullRequestModal) isReviewer(member models.TeamMember) bool {
+	for _, r := range m.reviewers {
+		if r.ID == member.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// View renders the modal
+func (m PullRequestModal) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 70
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Create Pull Request for #%d", m.item.ID))
+	b.WriteString(title + "\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	focusStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+
+	// Source branch
+	switch {
+	case m.sourceErr != nil:
+		b.WriteString(errStyle.Render(wordWrap(m.sourceErr.Error(), modalWidth-6)) + "\n\n")
+	case m.source == nil:
+		b.WriteString(mutedStyle.Render("Detecting branch...") + "\n\n")
+	default:
+		repo := m.source.Repository
+		b.WriteString(mutedStyle.Render(truncateStr(fmt.Sprintf("%s/%s: %s", repo.Project, repo.Name, m.source.Branch), modalWidth-6)) + "\n")
+		switch {
+		case !m.source.Pushed:
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("Branch will be pushed to %s", m.source.Remote)) + "\n\n")
+		case m.source.Ahead > 0:
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("%d unpushed commit(s) will be pushed", m.source.Ahead)) + "\n\n")
+		default:
+			b.WriteString(mutedStyle.Render("Branch is up to date with its remote") + "\n\n")
+		}
+	}
+
+	label := func(field int, text string) string {
+		if m.focus == field {
+			return focusStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	b.WriteString(label(prFieldTitle, "Title:") + "\n")
+	b.WriteString(m.title.View() + "\n")
+	b.WriteString(label(prFieldTarget, "Target branch:") + "\n")
+	b.WriteString(m.target.View() + "\n")
+	b.WriteString(label(prFieldDescription, "Description:") + "\n")
+	b.WriteString(m.description.View() + "\n")
+
+	b.WriteString(label(prFieldReviewers, "Reviewers:"))
+	for _, r := range m.reviewers {
+		b.WriteString(" " + m.styles.DetailTag.Render(r.DisplayName))
+	}
+	b.WriteString("\n" + m.reviewer.View() + "\n")
+	if m.focus == prFieldReviewers {
+		for i, member := range m.suggestions {
+			line := "  " + member.DisplayName
+			if i == m.suggestion {
+				line = focusStyle.Render("▸ " + member.DisplayName)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	check := "[ ]"
+	if m.autoComplete {
+		check = "[x]"
+	}
+	b.WriteString("\n" + check + " Auto-complete when policies pass (deletes the source branch)\n")
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("Links #%d", m.item.ID)) + "\n\n")
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Ctrl+s: create  Tab: next field  Ctrl+a: auto-complete  Esc: cancel"))
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetVisible sets the visibility. Opening prefills the title and
+// description from the work item and forgets the previous source branch.
+func (m *PullRequestModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.setFocus(-1)
+		return
+	}
+
+	m.source = nil
+	m.sourceErr = nil
+	m.reviewers = nil
+	m.suggestions = nil
+	m.autoComplete = false
+	m.title.SetValue("")
+	m.target.SetValue("")
+	m.description.Reset()
+	m.reviewer.SetValue("")
+	if m.item != nil {
+		m.title.SetValue(m.item.Title)
+		m.description.SetValue(m.item.Description)
+	}
+	m.setFocus(prFieldTitle)
+	m.title.CursorEnd()
+}
+
+// IsVisible returns whether the modal is visible
+func (m *PullRequestModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetItem sets the work item the pull request is for
+func (m *PullRequestModal) SetItem(item *models.WorkItem) {
+	m.item = item
+}
+
+// ItemID returns the ID of the work item, or 0
+func (m *PullRequestModal) ItemID() int {
+	if m.item == nil {
+		return 0
+	}
+	return m.item.ID
+}
+
+// SetMembers sets the team members that can be added as reviewers
+func (m *PullRequestModal) SetMembers(members []models.TeamMember) {
+	m.members = members
+}
+
+// SetSource sets the detected source branch, targeting the repository's
+// default branch unless a target was typed already
+func (m *PullRequestModal) SetSource(source PullRequestSource) {
+	m.source = &source
+	m.sourceErr = nil
+	if m.target.Value() == "" {
+		m.target.SetValue(source.Repository.DefaultBranch)
+	}
+}
+
+// SetSourceError shows why no pull request can be created from here
+func (m *PullRequestModal) SetSourceError(err error) {
+	m.source = nil
+	m.sourceErr = err
+}
+
+// SetSize sets the modal size
+func (m *PullRequestModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// CreatePullRequestMsg is sent when a pull request should be created
+type CreatePullRequestMsg struct {
+	Item         models.WorkItem
+	Source       PullRequestSource
+	Title        string
+	TargetBranch string
+	Description  string
+	Reviewers    []models.TeamMember
+	AutoComplete bool
+}
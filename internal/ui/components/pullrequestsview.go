@@ -0,0 +1,524 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// Pull request filters, cycled with "f"
+const (
+	prFilterAll = iota
+	prFilterCreatedByMe
+	prFilterReviewing
+	prFilterCount
+)
+
+// prFilterLabels are the titles of the pull request filters
+var prFilterLabels = []string{"All", "Created by me", "Needs my review"}
+
+// PullRequestsView is the fullscreen view of the project's active pull
+// requests, with the details of the selected one
+type PullRequestsView struct {
+	prs     []models.PullRequest
+	loading bool
+	err     error
+	updated time.Time
+	status  string
+	confirm string // Action key pressed once, waiting to be pressed again
+
+	// Details of the pull requests looked at, keyed by ID
+	reviews    map[int]models.PullRequestReview
+	reviewErrs map[int]error
+
+	// Filters: who I am (account and identity IDs, mine and my teams'), and
+	// the repository shown, "" for all
+	filter   int
+	repo     string
+	me       string
+	reviewer map[string]bool
+
+	cursor       int
+	offset       int
+	detailOffset int
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewPullRequestsView creates a new pull requests view
+func NewPullRequestsView(styles theme.Styles, keys theme.KeyMap) PullRequestsView {
+	return PullRequestsView{
+		reviews:    make(map[int]models.PullRequestReview),
+		reviewErrs: make(map[int]error),
+		styles:     styles,
+		keys:       keys,
+	}
+}
+
+// Init initializes the pull requests view
+func (p PullRequestsView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the pull requests view
+func (p PullRequestsView) Update(msg tea.Msg) (PullRequestsView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	prs := p.visiblePullRequests()
+	var selected *models.PullRequest
+	if p.cursor < len(prs) {
+		selected = &prs[p.cursor]
+	}
+	shownID := p.SelectedID()
+
+	// Rejecting is confirmed by pressing the key again
+	p.status = ""
+	confirmed := p.confirm == keyMsg.String()
+	p.confirm = ""
+
+	vote := func(vote int) tea.Cmd {
+		pr := *selected
+		return func() tea.Msg { return VoteRequestMsg{PullRequest: pr, Vote: vote} }
+	}
+
+	switch {
+	case key.Matches(keyMsg, p.keys.Back) || keyMsg.String() == "q":
+		return p, func() tea.Msg { return ClosePullRequestsViewMsg{} }
+	case key.Matches(keyMsg, p.keys.Refresh):
+		return p, func() tea.Msg { return PullRequestsRefreshMsg{} }
+	case keyMsg.String() == "f":
+		p.filter = (p.filter + 1) % prFilterCount
+		p.cursor, p.offset = 0, 0
+	case keyMsg.String() == "r":
+		p.repo = p.nextRepository()
+		p.cursor, p.offset = 0, 0
+	case keyMsg.String() == "a" && selected != nil:
+		return p, vote(models.VoteApproved)
+	case keyMsg.String() == "A" && selected != nil:
+		return p, vote(models.VoteApprovedWithSuggestions)
+	case keyMsg.String() == "w" && selected != nil:
+		return p, vote(models.VoteWaitingForAuthor)
+	case keyMsg.String() == "x" && selected != nil:
+		if !confirmed {
+			p.confirm = "x"
+			p.status = fmt.Sprintf("Press x again to reject !%d", selected.ID)
+			return p, nil
+		}
+		return p, vote(models.VoteRejected)
+	case keyMsg.String() == "c" && selected != nil:
+		pr := *selected
+		return p, func() tea.Msg { return ComposeCommentMsg{Target: CommentTarget{PullRequest: &pr}} }
+	case keyMsg.Type == tea.KeyEnter && selected != nil:
+		pr := *selected
+		return p, func() tea.Msg { return OpenDiffMsg{PullRequest: pr} }
+	case keyMsg.String() == "o" && selected != nil:
+		url := selected.WebURL
+		return p, func() tea.Msg { return OpenURLMsg{URL: url} }
+	case key.Matches(keyMsg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(keyMsg, p.keys.Down):
+		if p.cursor < len(prs)-1 {
+			p.cursor++
+		}
+	case key.Matches(keyMsg, p.keys.Top):
+		p.cursor = 0
+	case key.Matches(keyMsg, p.keys.Bottom):
+		p.cursor = max(len(prs)-1, 0)
+	case keyMsg.String() == "ctrl+d", keyMsg.String() == "pgdown":
+		p.detailOffset += p.visibleLines() / 2
+	case keyMsg.String() == "ctrl+u", keyMsg.String() == "pgup":
+		p.detailOffset = max(p.detailOffset-p.visibleLines()/2, 0)
+	}
+	p.scrollToCursor()
+
+	if id := p.SelectedID(); id != shownID {
+		p.detailOffset = 0
+		return p, p.selectedCmd()
+	}
+	return p, nil
+}
+
+// selectedCmd asks for the details of the selected pull request unless they
+// are known
+func (p PullRequestsView) selectedCmd() tea.Cmd {
+	pr := p.SelectedPullRequest()
+	if pr == nil {
+		return nil
+	}
+	if _, ok := p.reviews[pr.ID]; ok {
+		return nil
+	}
+	selected := *pr
+	return func() tea.Msg { return PullRequestSelectedMsg{PullRequest: selected} }
+}
+
+// visibleLines is how many list rows fit in a panel
+func (p *PullRequestsView) visibleLines() int {
+	visible := p.height - 6 // title, borders, status bar
+	if visible < 1 {
+		visible = 1
+	}
+	return visible
+}
+
+func (p *PullRequestsView) scrollToCursor() {
+	visible := p.visibleLines()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+visible {
+		p.offset = p.cursor - visible + 1
+	}
+}
+
+// visiblePullRequests returns the pull requests passing the filters
+func (p PullRequestsView) visiblePullRequests() []models.PullRequest {
+	var prs []models.PullRequest
+	for _, pr := range p.prs {
+		if p.repo != "" && pr.Repository != p.repo {
+			continue
+		}
+		switch p.filter {
+		case prFilterCreatedByMe:
+			if p.me == "" || !strings.EqualFold(pr.CreatorID, p.me) {
+				continue
+			}
+		case prFilterReviewing:
+			if !p.isReviewer(pr) {
+				continue
+			}
+		}
+		prs = append(prs, pr)
+	}
+	return prs
+}
+
+// isReviewer reports whether I or one of my teams reviews a pull request
+func (p PullRequestsView) isReviewer(pr models.PullRequest) bool {
+	for _, r := range pr.Reviewers {
+		if p.reviewer[strings.ToLower(r.ID)] {
+			return true
+		}
+	}
+	return false
+}
+
+// nextRepository returns the repository after the shown one, "" after the
+// last one
+func (p PullRequestsView) nextRepository() string {
+	seen := make(map[string]bool)
+	var repos []string
+	for _, pr := range p.prs {
+		if !seen[pr.Repository] {
+			seen[pr.Repository] = true
+			repos = append(repos, pr.Repository)
+		}
+	}
+	sort.Strings(repos)
+
+	if p.repo == "" {
+		if len(repos) == 0 {
+			return ""
+		}
+		return repos[0]
+	}
+	for i, repo := range repos {
+		if repo == p.repo && i+1 < len(repos) {
+			return repos[i+1]
+		}
+	}
+	return ""
+}
+
+// View renders the pull requests view
+func (p PullRequestsView) View() string {
+	title := "Pull Requests: " + prFilterLabels[p.filter]
+	if p.repo != "" {
+		title += " in " + p.repo
+	}
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(p.width - 2).
+		Render(title)
+
+	panelHeight := p.height - 4
+	listWidth := max(p.width*2/5, 40)
+	detailWidth := max(p.width-listWidth-4, 20)
+
+	// Panels are padded by a column on each side
+	list := p.styles.PanelActive.Width(listWidth - 2).Height(panelHeight).Render(p.renderList(listWidth - 4))
+	details := p.styles.PanelInactive.Width(detailWidth).Height(panelHeight).Render(p.renderDetails(detailWidth-2, panelHeight))
+
+	help := "Esc Back  Enter Changes  a/A Approve  w Wait for author  x Reject  c Comment  o Open  f Filter  r Repo  Ctrl+d/u Scroll"
+	if !p.updated.IsZero() {
+		help += "  Updated " + p.updated.Format("15:04:05")
+	}
+	if p.status != "" {
+		help = p.status
+	}
+	statusBar := p.styles.StatusBar.Width(p.width).Render(help)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		lipgloss.JoinHorizontal(lipgloss.Top, list, details),
+		statusBar,
+	)
+}
+
+// renderList renders the pull requests list
+func (p PullRequestsView) renderList(width int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	cursorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED"))
+
+	switch {
+	case p.err != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(wordWrap("Error: "+p.err.Error(), width))
+	case p.loading && len(p.prs) == 0:
+		return mutedStyle.Render("Loading pull requests...")
+	}
+
+	prs := p.visiblePullRequests()
+	if len(prs) == 0 {
+		return mutedStyle.Render("No active pull requests")
+	}
+
+	var lines []string
+	end := p.offset + p.visibleLines()
+	for i := p.offset; i < len(prs) && i < end; i++ {
+		pr := prs[i]
+		id := padRight(fmt.Sprintf("!%d", pr.ID), 7)
+		votes := pr.VoteSummary()
+		titleWidth := max(width-len(id)-lipgloss.Width(votes)-2, 10)
+		text := truncateStr(pr.Title, titleWidth)
+		if pr.IsDraft {
+			text = truncateStr("[Draft] "+pr.Title, titleWidth)
+		}
+		text = padRight(text, titleWidth)
+
+		if i == p.cursor {
+			lines = append(lines, cursorStyle.Render(id+text+" "+votes))
+			continue
+		}
+		lines = append(lines, id+text+" "+mutedStyle.Render(votes))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDetails renders the selected pull request: branches, reviewers and
+// votes, checks, description and changed files
+func (p PullRequestsView) renderDetails(width, height int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+
+	pr := p.SelectedPullRequest()
+	if pr == nil {
+		return ""
+	}
+
+	lines := []string{
+		p.styles.DetailTitle.Render(truncateStr(fmt.Sprintf("!%d %s", pr.ID, pr.Title), width)),
+		mutedStyle.Render(truncateStr(fmt.Sprintf("%s: %s → %s", pr.Repository, pr.SourceBranch, pr.TargetBranch), width)),
+	}
+	created := pr.StatusLabel() + " · by " + pr.CreatedBy
+	if !pr.Created.IsZero() {
+		created += ", " + models.FormatAge(pr.Created, time.Now())
+	}
+	lines = append(lines, mutedStyle.Render(truncateStr(created, width)), "")
+
+	section := func(title string) {
+		lines = append(lines, p.styles.DetailSectionTitle.Render(title))
+	}
+
+	section("Reviewers")
+	if len(pr.Reviewers) == 0 {
+		lines = append(lines, mutedStyle.Render("  None"))
+	}
+	for _, r := range pr.Reviewers {
+		line := "  " + p.styles.StatusBadge(voteStatus(r.Vote)).Render(r.VoteSymbol()) + " " + r.Name
+		if r.Required {
+			line += mutedStyle.Render(" (required)")
+		}
+		line += mutedStyle.Render(" · " + r.VoteLabel())
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+
+	review, loaded := p.reviews[pr.ID]
+	switch err := p.reviewErrs[pr.ID]; {
+	case err != nil:
+		lines = append(lines, errStyle.Render(truncateStr("Error: "+err.Error(), width)), "")
+	case !loaded:
+		lines = append(lines, mutedStyle.Render("Loading checks and files..."), "")
+	default:
+		section("Checks")
+		if len(review.Checks) == 0 {
+			lines = append(lines, mutedStyle.Render("  None"))
+		}
+		for _, check := range review.Checks {
+			line := "  " + p.styles.StatusBadge(check.Status).Render(check.Symbol()) + " " + truncateStr(check.Name, width-16)
+			if !check.Blocking {
+				line += mutedStyle.Render(" (optional)")
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, "")
+	}
+
+	section("Description")
+	if strings.TrimSpace(pr.Description) == "" {
+		lines = append(lines, mutedStyle.Render("  No description"))
+	}
+	for _, paragraph := range strings.Split(pr.Description, "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		for _, line := range strings.Split(wordWrap(paragraph, width-2), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	if loaded {
+		lines = append(lines, "")
+		section(fmt.Sprintf("Files (%d)", len(review.Changes)))
+		for _, change := range review.Changes {
+			lines = append(lines, "  "+truncateStr(change.Label(), width-2))
+		}
+	}
+
+	offset := min(p.detailOffset, max(len(lines)-height, 0))
+	end := min(offset+height, len(lines))
+	return strings.Join(lines[offset:end], "\n")
+}
+
+// SelectedPullRequest returns the pull request under the cursor, or nil
+func (p PullRequestsView) SelectedPullRequest() *models.PullRequest {
+	prs := p.visiblePullRequests()
+	if p.cursor < len(prs) {
+		return &prs[p.cursor]
+	}
+	return nil
+}
+
+// SelectedID returns the ID of the pull request under the cursor, or 0
+func (p PullRequestsView) SelectedID() int {
+	if pr := p.SelectedPullRequest(); pr != nil {
+		return pr.ID
+	}
+	return 0
+}
+
+// SetLoading shows that the pull requests are being loaded
+func (p *PullRequestsView) SetLoading() {
+	p.loading = true
+	p.err = nil
+}
+
+// SetPullRequests sets the pull requests to show. Refreshes keep the cursor
+// on the same pull request and forget the details of the ones that changed.
+// The returned command asks for the selected pull request's details.
+func (p *PullRequestsView) SetPullRequests(prs []models.PullRequest) tea.Cmd {
+	selectedID := p.SelectedID()
+
+	p.loading = false
+	p.prs = prs
+	p.updated = time.Now()
+	p.reviews = make(map[int]models.PullRequestReview)
+	p.reviewErrs = make(map[int]error)
+
+	p.cursor = 0
+	for i, pr := range p.visiblePullRequests() {
+		if pr.ID == selectedID {
+			p.cursor = i
+			break
+		}
+	}
+	p.scrollToCursor()
+	return p.selectedCmd()
+}
+
+// SetReview sets the checks and changed files of a pull request
+func (p *PullRequestsView) SetReview(id int, review models.PullRequestReview, err error) {
+	if err != nil {
+		p.reviewErrs[id] = err
+		return
+	}
+	delete(p.reviewErrs, id)
+	p.reviews[id] = review
+}
+
+// SetMe sets who I am: my account for "created by me", and my identity ID
+// and my teams' for "needs my review"
+func (p *PullRequestsView) SetMe(account string, reviewerIDs []string) {
+	p.me = account
+	p.reviewer = make(map[string]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		if id != "" {
+			p.reviewer[strings.ToLower(id)] = true
+		}
+	}
+}
+
+// SetStatus shows a message in the status bar until the next key press
+func (p *PullRequestsView) SetStatus(status string) {
+	p.status = status
+}
+
+// SetError shows an error instead of the pull requests
+func (p *PullRequestsView) SetError(err error) {
+	p.loading = false
+	p.err = err
+}
+
+// SetSize sets the size of the pull requests view
+func (p *PullRequestsView) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.scrollToCursor()
+}
+
+// ClosePullRequestsViewMsg is sent when the pull requests view should be
+// closed
+type ClosePullRequestsViewMsg struct{}
+
+// PullRequestsRefreshMsg is sent when the pull requests should be reloaded
+type PullRequestsRefreshMsg struct{}
+
+// PullRequestSelectedMsg is sent when the details of a pull request should
+// be loaded
+type PullRequestSelectedMsg struct {
+	PullRequest models.PullRequest
+}
+
+// VoteRequestMsg is sent when I vote on a pull request
+type VoteRequestMsg struct {
+	PullRequest models.PullRequest
+	Vote        int
+}
+
+// ComposeCommentMsg is sent when a comment should be written, optionally
+// starting with a mention of someone, e.g. when replying to them
+type ComposeCommentMsg struct {
+	Target  CommentTarget
+	Mention *models.TeamMember
+}
@@ -0,0 +1,457 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// maxQueueFields is how many parameter and variable fields are shown at once
+const maxQueueFields = 8
+
+// queueParam is a runtime parameter field of the queue modal
+type queueParam struct {
+	param  models.PipelineParameter
+	input  textinput.Model // Free text values
+	choice int             // Index into the allowed values, or 1 for true
+}
+
+// choices returns the values the parameter can be cycled through, nil when
+// it's typed in
+func (p *queueParam) choices() []string {
+	switch {
+	case len(p.param.Values) > 0:
+		return p.param.Values
+	case p.param.Type == "boolean":
+		return []string{"false", "true"}
+	}
+	return nil
+}
+
+// value returns the value to queue the run with
+func (p *queueParam) value() string {
+	if choices := p.choices(); choices != nil {
+		return choices[p.choice]
+	}
+	return strings.TrimSpace(p.input.Value())
+}
+
+// QueueModal is a form for queuing a pipeline run for a branch, with the
+// pipeline's runtime parameters and variables
+type QueueModal struct {
+	visible      bool
+	definitionID int
+	definition   *models.BuildDefinition // nil while loading
+	loadErr      error
+	queueErr     error
+	queuing      bool
+
+	branch        textinput.Model
+	paramsBranch  string // Branch the parameters were read from
+	paramsLoading bool
+	paramsErr     error
+	params        []queueParam
+	variables     []textinput.Model
+	focus         int // 0 is the branch, then parameters, then variables
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewQueueModal creates a new queue modal
+func NewQueueModal(styles theme.Styles, keys theme.KeyMap) QueueModal {
+	branch := textinput.New()
+	branch.Placeholder = "main"
+	branch.CharLimit = 200
+	branch.Width = 50
+
+	return QueueModal{
+		styles: styles,
+		keys:   keys,
+		branch: branch,
+	}
+}
+
+// Init initializes the modal
+func (m QueueModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m QueueModal) Update(msg tea.Msg) (QueueModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	fields := 1 + len(m.params) + len(m.variables)
+	switch keyMsg.String() {
+	case "esc":
+		m.visible = false
+		return m, func() tea.Msg { return ModalClosedMsg{} }
+	case "tab", "down":
+		return m, m.setFocus((m.focus + 1) % fields)
+	case "shift+tab", "up":
+		return m, m.setFocus((m.focus + fields - 1) % fields)
+	case "enter":
+		if m.focus < fields-1 {
+			return m, m.setFocus(m.focus + 1)
+		}
+		return m, m.submit()
+	case "ctrl+s":
+		return m, m.submit()
+	}
+
+	if m.focus == 0 {
+		var cmd tea.Cmd
+		m.branch, cmd = m.branch.Update(msg)
+		return m, cmd
+	}
+
+	if i := m.focus - 1; i < len(m.params) {
+		p := &m.params[i]
+		if choices := p.choices(); choices != nil {
+			switch keyMsg.String() {
+			case "left", "h":
+				p.choice = (p.choice + len(choices) - 1) % len(choices)
+			case "right", "l", " ":
+				p.choice = (p.choice + 1) % len(choices)
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		return m, cmd
+	}
+
+	i := m.focus - 1 - len(m.params)
+	var cmd tea.Cmd
+	m.variables[i], cmd = m.variables[i].Update(msg)
+	return m, cmd
+}
+
+// setFocus moves to a field. Leaving the branch field for another branch
+// reads the parameters again, from that branch.
+func (m *QueueModal) setFocus(focus int) tea.Cmd {
+	var cmd tea.Cmd
+	branch := strings.TrimSpace(m.branch.Value())
+	if m.focus == 0 && focus != 0 && m.definition != nil && branch != "" && branch != m.paramsBranch {
+		m.paramsLoading = true
+		m.paramsBranch = branch
+		req := QueueBranchChangedMsg{Definition: *m.definition, Branch: branch}
+		cmd = func() tea.Msg { return req }
+	}
+
+	m.focus = focus
+	m.branch.Blur()
+	for i := range m.params {
+		m.params[i].input.Blur()
+	}
+	for i := range m.variables {
+		m.variables[i].Blur()
+	}
+
+	switch {
+	case focus == 0:
+		m.branch.Focus()
+	case focus-1 < len(m.params):
+		if m.params[focus-1].choices() == nil {
+			m.params[focus-1].input.Focus()
+		}
+	case focus-1-len(m.params) < len(m.variables):
+		m.variables[focus-1-len(m.params)].Focus()
+	}
+	return cmd
+}
+
+// submit returns the command queuing the run, or nil while the form isn't
+// complete
+func (m *QueueModal) submit() tea.Cmd {
+	branch := strings.TrimSpace(m.branch.Value())
+	if m.definition == nil || m.paramsLoading || m.queuing || branch == "" {
+		return nil
+	}
+
+	run := models.NewPipelineRun{
+		DefinitionID: m.definition.ID,
+		Branch:       models.BranchName(branch),
+		Parameters:   make(map[string]string),
+		Variables:    make(map[string]string),
+	}
+	for i := range m.params {
+		run.Parameters[m.params[i].param.Name] = m.params[i].value()
+	}
+	// Only changed variables are sent, so secrets keep their values
+	for i, v := range m.definition.Variables {
+		if value := m.variables[i].Value(); value != v.Value && !(v.Secret && value == "") {
+			run.Variables[v.Name] = value
+		}
+	}
+
+	m.queuing = true
+	m.queueErr = nil
+	return func() tea.Msg { return QueueRunRequestMsg{Run: run} }
+}
+
+// View renders the modal
+func (m QueueModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 70
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	focusStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+
+	label := func(field int, text string) string {
+		if m.focus == field {
+			return focusStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+
+	var b strings.Builder
+	name := "pipeline"
+	if m.definition != nil {
+		name = m.definition.Name
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(truncateStr("Run "+name, modalWidth-6)) + "\n\n")
+
+	switch {
+	case m.loadErr != nil:
+		b.WriteString(errStyle.Render(wordWrap(m.loadErr.Error(), modalWidth-6)) + "\n\n")
+	case m.definition == nil:
+		b.WriteString(mutedStyle.Render("Loading pipeline...") + "\n\n")
+	default:
+		b.WriteString(label(0, "Branch:") + "\n")
+		b.WriteString(m.branch.View() + "\n\n")
+		b.WriteString(m.renderFields(modalWidth-6, label))
+	}
+
+	switch {
+	case m.queueErr != nil:
+		b.WriteString(errStyle.Render(wordWrap(m.queueErr.Error(), modalWidth-6)) + "\n\n")
+	case m.queuing:
+		b.WriteString(mutedStyle.Render("Queuing...") + "\n\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Ctrl+s: run  Tab: next field  ←→/Space: change choice  Esc: cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// renderFields renders the parameters and variables around the focused one
+func (m QueueModal) renderFields(width int, label func(int, string) string) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	choiceStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#10B981"))
+
+	var b strings.Builder
+	switch {
+	case m.paramsLoading:
+		b.WriteString(mutedStyle.Render("Reading parameters from "+m.paramsBranch+"...") + "\n\n")
+	case m.paramsErr != nil:
+		b.WriteString(errStyle.Render(wordWrap("Parameters: "+m.paramsErr.Error(), width)) + "\n\n")
+	}
+
+	total := len(m.params) + len(m.variables)
+	if total == 0 {
+		if !m.paramsLoading {
+			b.WriteString(mutedStyle.Render("No parameters or variables to set") + "\n\n")
+		}
+		return b.String()
+	}
+
+	// Keep the focused field in the window
+	start := 0
+	if focused := m.focus - 1; focused >= maxQueueFields {
+		start = focused - maxQueueFields + 1
+	}
+	end := min(start+maxQueueFields, total)
+	if start > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("↑ %d more", start)) + "\n")
+	}
+
+	for i := start; i < end; i++ {
+		field := i + 1
+		if i < len(m.params) {
+			if i == 0 {
+				b.WriteString(mutedStyle.Render("Parameters") + "\n")
+			}
+			p := m.params[i]
+			if choices := p.choices(); choices != nil {
+				b.WriteString(label(field, truncateStr(p.param.Label()+":", width/2)) + " ‹ " + choiceStyle.Render(choices[p.choice]) + " ›\n")
+				continue
+			}
+			b.WriteString(label(field, truncateStr(p.param.Label()+":", width)) + "\n")
+			b.WriteString(p.input.View() + "\n")
+			continue
+		}
+
+		j := i - len(m.params)
+		if j == 0 {
+			b.WriteString(mutedStyle.Render("Variables") + "\n")
+		}
+		b.WriteString(label(field, truncateStr(m.definition.Variables[j].Name+":", width)) + "\n")
+		b.WriteString(m.variables[j].View() + "\n")
+	}
+	if end < total {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("↓ %d more", total-end)) + "\n")
+	}
+	return b.String() + "\n"
+}
+
+// Open shows the modal for a pipeline, loading until its definition is set
+func (m *QueueModal) Open(definitionID int) {
+	m.visible = true
+	m.definitionID = definitionID
+	m.definition = nil
+	m.loadErr = nil
+	m.queueErr = nil
+	m.queuing = false
+	m.params = nil
+	m.variables = nil
+	m.paramsErr = nil
+	m.paramsLoading = false
+	m.branch.SetValue("")
+	m.setFocus(0)
+}
+
+// SetDefinition sets the pipeline, prefilling the branch with its default
+// branch (or the given one) and the variables with their values. Its
+// parameters are read next, from that branch.
+func (m *QueueModal) SetDefinition(def models.BuildDefinition, branch string) {
+	if def.ID != m.definitionID {
+		return
+	}
+	m.definition = &def
+	if branch == "" {
+		branch = def.DefaultBranch
+	}
+	m.branch.SetValue(branch)
+	m.branch.CursorEnd()
+	m.paramsBranch = branch
+	m.paramsLoading = true
+
+	m.variables = nil
+	for _, v := range def.Variables {
+		ti := textinput.New()
+		ti.CharLimit = 500
+		ti.Width = 50
+		ti.SetValue(v.Value)
+		if v.Secret {
+			ti.EchoMode = textinput.EchoPassword
+			ti.Placeholder = "(secret, unchanged)"
+		}
+		m.variables = append(m.variables, ti)
+	}
+}
+
+// SetParameters sets the runtime parameters read from a branch. Values
+// already typed for parameters of the same name are kept.
+func (m *QueueModal) SetParameters(definitionID int, branch string, params []models.PipelineParameter, err error) {
+	if definitionID != m.definitionID || branch != m.paramsBranch {
+		return
+	}
+	m.paramsLoading = false
+	m.paramsErr = err
+
+	previous := make(map[string]string, len(m.params))
+	for i := range m.params {
+		previous[m.params[i].param.Name] = m.params[i].value()
+	}
+
+	m.params = nil
+	for _, param := range params {
+		p := queueParam{param: param, input: textinput.New()}
+		p.input.CharLimit = 500
+		p.input.Width = 50
+
+		value, ok := previous[param.Name]
+		if !ok {
+			value = param.Default
+		}
+		if choices := p.choices(); choices != nil {
+			for i, c := range choices {
+				if strings.EqualFold(c, value) {
+					p.choice = i
+				}
+			}
+		} else {
+			p.input.SetValue(value)
+		}
+		m.params = append(m.params, p)
+	}
+
+	// Keep the focus inside the form
+	fields := 1 + len(m.params) + len(m.variables)
+	m.setFocus(min(m.focus, fields-1))
+}
+
+// SetLoadError shows why the pipeline couldn't be loaded
+func (m *QueueModal) SetLoadError(definitionID int, err error) {
+	if definitionID == m.definitionID {
+		m.loadErr = err
+	}
+}
+
+// SetQueueError shows why the run couldn't be queued, keeping the form
+func (m *QueueModal) SetQueueError(err error) {
+	m.queuing = false
+	m.queueErr = err
+}
+
+// SetVisible sets the visibility
+func (m *QueueModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.branch.Blur()
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *QueueModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal size
+func (m *QueueModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// QueueBranchChangedMsg is sent when the parameters should be read from
+// another branch
+type QueueBranchChangedMsg struct {
+	Definition models.BuildDefinition
+	Branch     string
+}
+
+// QueueRunRequestMsg is sent when a pipeline run should be queued
+type QueueRunRequestMsg struct {
+	Run models.NewPipelineRun
+}
@@ -0,0 +1,493 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// timelineWidth is the width of the stages, jobs and steps list, borders
+// included
+const timelineWidth = 44
+
+// RunView is the fullscreen view of a pipeline run: its stages, jobs and
+// steps, and the log of one of them
+type RunView struct {
+	run     models.Build
+	nodes   []models.TimelineNode
+	loading bool
+	err     error
+	status  string
+	confirm string // Action key pressed once, waiting to be pressed again
+
+	cursor int
+	offset int
+
+	// Record whose log is shown, "" for none
+	logRecord string
+	focusLog  bool
+	log       LogView
+
+	styles theme.Styles
+	keys   theme.KeyMap
+	width  int
+	height int
+}
+
+// NewRunView creates a new run view
+func NewRunView(styles theme.Styles, keys theme.KeyMap) RunView {
+	return RunView{
+		log:    NewLogView(styles, keys),
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the run view
+func (r RunView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the run view
+func (r RunView) Update(msg tea.Msg) (RunView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return r, nil
+	}
+
+	r.status = ""
+	confirmed := r.confirm == keyMsg.String()
+	r.confirm = ""
+
+	if r.focusLog {
+		switch {
+		case r.log.Searching():
+		case key.Matches(keyMsg, r.keys.Back) || keyMsg.String() == "q":
+			if r.log.HasSearch() {
+				r.log.ClearSearch()
+			} else {
+				r.focusLog = false
+			}
+			return r, nil
+		case key.Matches(keyMsg, r.keys.NextPanel), key.Matches(keyMsg, r.keys.PrevPanel):
+			r.focusLog = false
+			return r, nil
+		}
+
+		var cmd tea.Cmd
+		r.log, cmd = r.log.Update(msg)
+		return r, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, r.keys.Back) || keyMsg.String() == "q":
+		return r, func() tea.Msg { return CloseRunViewMsg{} }
+	case key.Matches(keyMsg, r.keys.NextPanel), key.Matches(keyMsg, r.keys.PrevPanel):
+		if r.logRecord != "" {
+			r.focusLog = true
+		}
+	case key.Matches(keyMsg, r.keys.Refresh):
+		return r, func() tea.Msg { return RunRefreshMsg{} }
+	case keyMsg.String() == "x":
+		run := r.run
+		switch {
+		case !run.IsRunning():
+			r.status = "The run isn't queued or running"
+		case !confirmed:
+			r.confirm = "x"
+			r.status = "Press x again to cancel the run"
+		default:
+			return r, func() tea.Msg { return CancelRunRequestMsg{Run: run} }
+		}
+	case keyMsg.String() == "R":
+		run := r.run
+		// The selected stage, or all failed ones
+		var stages []string
+		what := "the failed stages"
+		if stage := r.selectedStage(); stage != nil && stage.CanRetry() {
+			stages = []string{stage.Identifier}
+			what = "stage " + stage.Name
+		}
+		switch {
+		case stages == nil && !run.CanRetry():
+			r.status = "Only completed runs that didn't succeed can be retried"
+		case !confirmed:
+			r.confirm = "R"
+			r.status = "Press R again to retry " + what
+		default:
+			return r, func() tea.Msg { return RetryRunRequestMsg{Run: run, Stages: stages} }
+		}
+	case keyMsg.String() == "o":
+		if r.run.WebURL != "" {
+			url := r.run.WebURL
+			return r, func() tea.Msg { return OpenURLMsg{URL: url} }
+		}
+	case key.Matches(keyMsg, r.keys.Up):
+		if r.cursor > 0 {
+			r.cursor--
+		}
+	case key.Matches(keyMsg, r.keys.Down):
+		if r.cursor < len(r.nodes)-1 {
+			r.cursor++
+		}
+	case key.Matches(keyMsg, r.keys.Top):
+		r.cursor = 0
+	case key.Matches(keyMsg, r.keys.Bottom):
+		r.cursor = max(len(r.nodes)-1, 0)
+	case keyMsg.Type == tea.KeyEnter || key.Matches(keyMsg, r.keys.Right):
+		if record := r.SelectedRecord(); record != nil {
+			return r, r.showLog(*record)
+		}
+	}
+	r.scrollToCursor()
+
+	return r, nil
+}
+
+// showLog shows the log of a record, requesting it when the record has one
+func (r *RunView) showLog(record models.TimelineRecord) tea.Cmd {
+	r.focusLog = true
+	if record.ID == r.logRecord {
+		return nil
+	}
+
+	r.logRecord = record.ID
+	switch {
+	case record.LogID == 0 && record.IsRunning():
+		r.log.Reset("Waiting for the log...", true)
+		return nil
+	case record.LogID == 0:
+		r.log.Reset("No log", false)
+		return nil
+	}
+	r.log.Reset("Loading log...", record.IsRunning())
+	return func() tea.Msg { return RunLogRequestMsg{Record: record, StartLine: 1} }
+}
+
+// visibleLines is how many timeline rows fit in the panel
+func (r *RunView) visibleLines() int {
+	return max(r.height-6, 1) // title, borders, status bar
+}
+
+func (r *RunView) scrollToCursor() {
+	visible := r.visibleLines()
+	if r.cursor < r.offset {
+		r.offset = r.cursor
+	}
+	if r.cursor >= r.offset+visible {
+		r.offset = r.cursor - visible + 1
+	}
+}
+
+// View renders the run view
+func (r RunView) View() string {
+	title := "Run " + r.run.Title()
+	if branch := r.run.Branch(); branch != "" {
+		title += " · " + branch
+	}
+	if status := r.run.StatusLabel(); status != "" {
+		title += " · " + status
+	}
+	titleBar := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1).
+		Width(r.width - 2).
+		Render(truncateStr(title, r.width-4))
+
+	panelHeight := r.height - 4
+	logWidth := r.width - timelineWidth - 4
+
+	timelineStyle, logStyle := r.styles.PanelActive, r.styles.PanelInactive
+	if r.focusLog {
+		timelineStyle, logStyle = r.styles.PanelInactive, r.styles.PanelActive
+	}
+	timeline := timelineStyle.Width(timelineWidth - 2).Height(panelHeight).Render(r.renderTimeline())
+
+	logContent := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).
+		Render("Select a stage, job or step and press Enter to show its log")
+	if r.logRecord != "" {
+		logContent = r.log.View()
+	}
+	logPanel := logStyle.Width(logWidth).Height(panelHeight).Render(logContent)
+
+	help := "Esc Back  Enter Show log  Tab Switch panel  x Cancel run  R Retry stage  o Open in browser  Ctrl+r Refresh"
+	if r.focusLog {
+		help = "Esc Back  / Search  n/N Next/prev match  e/E Next/prev error  h/l Scroll sideways  t Timestamps  s Save  G Follow"
+	}
+	if r.status != "" {
+		help = r.status
+	}
+	statusBar := r.styles.StatusBar.Width(r.width).Render(help)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleBar,
+		lipgloss.JoinHorizontal(lipgloss.Top, timeline, logPanel),
+		statusBar,
+	)
+}
+
+// renderTimeline renders the stages, jobs and steps of the run
+func (r RunView) renderTimeline() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	cursorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#F9FAFB")).
+		Background(lipgloss.Color("#7C3AED"))
+	shownStyle := lipgloss.NewStyle().Bold(true)
+
+	switch {
+	case r.err != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("Error: " + r.err.Error())
+	case r.loading && len(r.nodes) == 0:
+		return mutedStyle.Render("Loading timeline...")
+	case len(r.nodes) == 0:
+		return mutedStyle.Render("No stages yet")
+	}
+
+	width := timelineWidth - 4
+	now := time.Now()
+	var lines []string
+	end := min(r.offset+r.visibleLines(), len(r.nodes))
+	for i := r.offset; i < end; i++ {
+		node := r.nodes[i]
+		record := node.Record
+
+		duration := ""
+		if d := record.Duration(now); d > 0 {
+			duration = models.FormatDuration(d)
+		}
+		indent := strings.Repeat("  ", node.Depth)
+		name := truncateStr(record.Name, max(width-len(indent)-2-len(duration)-1, 1))
+		text := indent + "  " + padRight(name, width-len(indent)-2-len(duration)) + duration
+
+		symbol := recordSymbol(record)
+		switch {
+		case i == r.cursor && !r.focusLog:
+			lines = append(lines, cursorStyle.Render(indent+symbol+text[len(indent)+1:]))
+		case record.ID == r.logRecord:
+			badge := r.styles.StatusBadge(recordStatus(record)).Render(symbol)
+			lines = append(lines, indent+badge+shownStyle.Render(text[len(indent)+1:]))
+		default:
+			badge := r.styles.StatusBadge(recordStatus(record)).Render(symbol)
+			lines = append(lines, indent+badge+text[len(indent)+1:])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recordStatus maps the state of a timeline record onto the build statuses
+// the theme colors
+func recordStatus(record models.TimelineRecord) string {
+	switch {
+	case record.State == "inProgress":
+		return "inProgress"
+	case record.State != "completed":
+		return "notStarted"
+	case record.Result == "succeededWithIssues":
+		return "partiallySucceeded"
+	case record.Result == "skipped", record.Result == "abandoned":
+		return "canceled"
+	}
+	return record.Result
+}
+
+// recordSymbol returns the status symbol of a timeline record
+func recordSymbol(record models.TimelineRecord) string {
+	if record.Result == "skipped" {
+		return "–"
+	}
+	return runSymbol(models.Build{Status: "completed", Result: recordStatus(record)})
+}
+
+// LogFileName names a saved log after the run and the shown record
+func (r RunView) LogFileName() string {
+	name := models.Slugify(r.run.Definition) + "-" + models.Slugify(r.run.Number)
+	for _, node := range r.nodes {
+		if node.Record.ID == r.logRecord {
+			name += "-" + models.Slugify(node.Record.Name)
+			break
+		}
+	}
+	return strings.Trim(name, "-") + ".log"
+}
+
+// SelectedRecord returns the record under the cursor
+func (r RunView) SelectedRecord() *models.TimelineRecord {
+	if r.cursor < len(r.nodes) {
+		return &r.nodes[r.cursor].Record
+	}
+	return nil
+}
+
+// selectedStage returns the stage of the record under the cursor
+func (r RunView) selectedStage() *models.TimelineRecord {
+	for i := min(r.cursor, len(r.nodes)-1); i >= 0; i-- {
+		if strings.EqualFold(r.nodes[i].Record.Type, "Stage") {
+			return &r.nodes[i].Record
+		}
+		if r.nodes[i].Depth == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// LogRecord returns the record whose log is shown, nil for none
+func (r RunView) LogRecord() *models.TimelineRecord {
+	for i := range r.nodes {
+		if r.nodes[i].Record.ID == r.logRecord {
+			return &r.nodes[i].Record
+		}
+	}
+	return nil
+}
+
+// Run returns the shown run
+func (r RunView) Run() models.Build {
+	return r.run
+}
+
+// SetRun shows another run, until its timeline is loaded
+func (r *RunView) SetRun(run models.Build) {
+	r.run = run
+	r.nodes = nil
+	r.cursor, r.offset = 0, 0
+	r.logRecord = ""
+	r.focusLog = false
+	r.loading = true
+	r.err = nil
+	r.status = ""
+}
+
+// SetTimeline updates the run and its timeline. The first time, the cursor
+// lands on the first failed (or running) step. It returns a command to load
+// the shown log again when its record may have written more lines.
+func (r *RunView) SetTimeline(run models.Build, records []models.TimelineRecord) tea.Cmd {
+	first := r.loading && len(r.nodes) == 0
+	selected := ""
+	if record := r.SelectedRecord(); record != nil {
+		selected = record.ID
+	}
+	var before *models.TimelineRecord
+	if record := r.LogRecord(); record != nil {
+		copied := *record
+		before = &copied
+	}
+
+	r.run = run
+	r.nodes = models.BuildTimelineTree(records)
+	r.loading = false
+	r.err = nil
+
+	r.cursor = min(r.cursor, max(len(r.nodes)-1, 0))
+	for i, node := range r.nodes {
+		if node.Record.ID == selected {
+			r.cursor = i
+			break
+		}
+	}
+	if first {
+		r.cursor = r.landingIndex()
+	}
+	r.scrollToCursor()
+
+	after := r.LogRecord()
+	if after == nil || after.LogID == 0 {
+		return nil
+	}
+	if before != nil && before.LogID == after.LogID && !before.IsRunning() && !r.log.Empty() {
+		return nil
+	}
+	// Only the lines written since are loaded for the same log
+	start := 1
+	switch {
+	case before != nil && before.LogID == after.LogID:
+		start = r.log.LineCount() + 1
+	case before != nil && before.LogID == 0:
+		r.log.Reset("Loading log...", true)
+	}
+	record := *after
+	return func() tea.Msg { return RunLogRequestMsg{Record: record, StartLine: start} }
+}
+
+// landingIndex returns the first failed step, else the first running one
+func (r RunView) landingIndex() int {
+	running := -1
+	for i, node := range r.nodes {
+		if node.Record.Result == "failed" && !strings.EqualFold(node.Record.Type, "Stage") && !strings.EqualFold(node.Record.Type, "Job") {
+			return i
+		}
+		if running < 0 && node.Record.State == "inProgress" && strings.EqualFold(node.Record.Type, "Task") {
+			running = i
+		}
+	}
+	return max(running, 0)
+}
+
+// Searching reports whether the log's search input has focus
+func (r RunView) Searching() bool {
+	return r.focusLog && r.log.Searching()
+}
+
+// SetLog sets the lines of the shown record's log from startLine on.
+// Lines that don't continue the shown log are dropped.
+func (r *RunView) SetLog(recordID string, startLine int, lines []string) {
+	switch {
+	case recordID != r.logRecord:
+	case startLine <= 1:
+		r.log.SetLines(lines)
+	case startLine == r.log.LineCount()+1:
+		r.log.AppendLines(lines)
+	}
+}
+
+// SetLogError shows why the log couldn't be loaded
+func (r *RunView) SetLogError(recordID string, err error) {
+	if recordID == r.logRecord {
+		r.log.SetMessage("Error: " + err.Error())
+	}
+}
+
+// SetError shows an error instead of the timeline
+func (r *RunView) SetError(err error) {
+	r.loading = false
+	r.err = err
+}
+
+// SetStatus shows a message in the status bar until the next key press,
+// e.g. where a log was saved
+func (r *RunView) SetStatus(status string) {
+	r.status = status
+}
+
+// SetSize sets the size of the run view
+func (r *RunView) SetSize(width, height int) {
+	r.width = width
+	r.height = height
+	r.log.SetSize(max(width-timelineWidth-4, 1), max(height-4, 1))
+	r.scrollToCursor()
+}
+
+// CloseRunViewMsg is sent when the run view should be closed
+type CloseRunViewMsg struct{}
+
+// RunRefreshMsg is sent when the run should be reloaded
+type RunRefreshMsg struct{}
+
+// RunLogRequestMsg is sent when the log of a stage, job or step is needed
+type RunLogRequestMsg struct {
+	Record    models.TimelineRecord
+	StartLine int // 1-based; later lines when tailing
+}
+
+// OpenRunMsg is sent when a pipeline run should be shown
+type OpenRunMsg struct {
+	Run models.Build
+}
@@ -0,0 +1,208 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// SortModal is a modal for choosing one or more sort keys among the visible columns
+type SortModal struct {
+	visible  bool
+	columns  []models.Column
+	sortKeys []models.SortKey
+	cursor   int
+	styles   theme.Styles
+	keys     theme.KeyMap
+	width    int
+	height   int
+}
+
+// NewSortModal creates a new sort modal
+func NewSortModal(styles theme.Styles, keys theme.KeyMap) SortModal {
+	return SortModal{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m SortModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m SortModal) Update(msg tea.Msg) (SortModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Back):
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case msg.Type == tea.KeyEnter:
+			keys := append([]models.SortKey{}, m.sortKeys...)
+			return m, func() tea.Msg { return SortChangedMsg{Keys: keys} }
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.columns)-1 {
+				m.cursor++
+			}
+		case msg.String() == " ":
+			// Add as the next sort key, or flip the direction if already used
+			if field, ok := m.cursorField(); ok {
+				if i := m.keyIndex(field); i >= 0 {
+					m.sortKeys[i].Desc = !m.sortKeys[i].Desc
+				} else {
+					m.sortKeys = append(m.sortKeys, models.SortKey{Field: field})
+				}
+			}
+		case msg.String() == "p":
+			// Make the column the primary sort key
+			if field, ok := m.cursorField(); ok {
+				sk := models.SortKey{Field: field}
+				if i := m.keyIndex(field); i >= 0 {
+					sk = m.sortKeys[i]
+					m.sortKeys = append(m.sortKeys[:i], m.sortKeys[i+1:]...)
+				}
+				m.sortKeys = append([]models.SortKey{sk}, m.sortKeys...)
+			}
+		case msg.String() == "d" || msg.String() == "x":
+			if field, ok := m.cursorField(); ok {
+				if i := m.keyIndex(field); i >= 0 {
+					m.sortKeys = append(m.sortKeys[:i], m.sortKeys[i+1:]...)
+				}
+			}
+		case msg.String() == "c":
+			m.sortKeys = nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m *SortModal) cursorField() (string, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.columns) {
+		return "", false
+	}
+	return m.columns[m.cursor].Field, true
+}
+
+// keyIndex returns the position of field among the sort keys, or -1
+func (m *SortModal) keyIndex(field string) int {
+	for i, sk := range m.sortKeys {
+		if sk.Field == field {
+			return i
+		}
+	}
+	return -1
+}
+
+// View renders the modal
+func (m SortModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	// Modal dimensions
+	modalWidth := 50
+	modalHeight := len(m.columns) + 9
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render("Sort By")
+	b.WriteString(title + "\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	sortedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+
+	for i, col := range m.columns {
+		cursor := "  "
+		style := lipgloss.NewStyle()
+		if i == m.cursor {
+			cursor = "▸ "
+			style = style.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		}
+
+		// Position and direction of the key, if the column is sorted
+		order := mutedStyle.Render(" -  ")
+		if k := m.keyIndex(col.Field); k >= 0 {
+			arrow := "▲"
+			if m.sortKeys[k].Desc {
+				arrow = "▼"
+			}
+			order = sortedStyle.Render(padRight(itoa(k+1)+arrow, 4))
+		}
+
+		b.WriteString(cursor + order + " " + style.Render(col.HeaderTitle()) + "\n")
+	}
+
+	if len(m.sortKeys) == 0 {
+		b.WriteString("\n" + mutedStyle.Render("No sort keys: sorted by ID") + "\n")
+	} else {
+		b.WriteString("\n\n")
+	}
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Space: add/flip  p: primary  d: remove  c: clear") + "\n")
+	b.WriteString(helpStyle.Render("Enter: apply  Esc: cancel"))
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Height(modalHeight).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetVisible sets the visibility
+func (m *SortModal) SetVisible(visible bool) {
+	m.visible = visible
+	if visible {
+		m.cursor = 0
+	}
+}
+
+// IsVisible returns whether the modal is visible
+func (m *SortModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetColumns sets the columns that can be sorted by
+func (m *SortModal) SetColumns(columns []models.Column) {
+	m.columns = columns
+}
+
+// SetSortKeys sets the current sort keys (the slice is copied)
+func (m *SortModal) SetSortKeys(keys []models.SortKey) {
+	m.sortKeys = append([]models.SortKey{}, keys...)
+}
+
+// SetSize sets the modal container size
+func (m *SortModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SortChangedMsg is sent when the sort keys change
+type SortChangedMsg struct {
+	Keys []models.SortKey
+}
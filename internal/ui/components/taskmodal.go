@@ -0,0 +1,170 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// TaskModal is a modal for breaking a work item down into child tasks,
+// one task per line
+type TaskModal struct {
+	visible    bool
+	item       *models.WorkItem
+	input      textarea.Model
+	assignToMe bool
+	styles     theme.Styles
+	keys       theme.KeyMap
+	width      int
+	height     int
+}
+
+// NewTaskModal creates a new task modal
+func NewTaskModal(styles theme.Styles, keys theme.KeyMap) TaskModal {
+	ta := textarea.New()
+	ta.Placeholder = "One task per line..."
+	ta.ShowLineNumbers = true
+	ta.SetWidth(60)
+	ta.SetHeight(10)
+	ta.CharLimit = 0
+
+	return TaskModal{
+		styles: styles,
+		keys:   keys,
+		input:  ta,
+	}
+}
+
+// Init initializes the modal
+func (m TaskModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m TaskModal) Update(msg tea.Msg) (TaskModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case "ctrl+a":
+			m.assignToMe = !m.assignToMe
+			return m, nil
+		case "ctrl+s":
+			titles := m.titles()
+			if len(titles) == 0 || m.item == nil {
+				return m, nil
+			}
+			req := CreateTasksRequestMsg{
+				Parent:     *m.item,
+				Titles:     titles,
+				AssignToMe: m.assignToMe,
+			}
+			return m, func() tea.Msg { return req }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// titles returns the non-empty lines of the input
+func (m *TaskModal) titles() []string {
+	var titles []string
+	for _, line := range strings.Split(m.input.Value(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			titles = append(titles, line)
+		}
+	}
+	return titles
+}
+
+// View renders the modal
+func (m TaskModal) View() string {
+	if !m.visible || m.item == nil {
+		return ""
+	}
+
+	modalWidth := 70
+
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Add Tasks to #%d", m.item.ID))
+	b.WriteString(title + "\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(mutedStyle.Render(truncateStr(m.item.Title, modalWidth-6)) + "\n\n")
+
+	b.WriteString(m.input.View() + "\n\n")
+
+	check := "[ ]"
+	if m.assignToMe {
+		check = "[x]"
+	}
+	b.WriteString(check + " Assign to me\n")
+
+	count := len(m.titles())
+	summary := fmt.Sprintf("%d task(s), area and iteration inherited from #%d", count, m.item.ID)
+	b.WriteString(mutedStyle.Render(summary) + "\n\n")
+
+	// Help text
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Ctrl+s: create  Ctrl+a: toggle assign to me  Esc: cancel"))
+
+	// Modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	modal := modalStyle.Render(b.String())
+
+	// Center the modal
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// SetVisible sets the visibility, starting with an empty prompt
+func (m *TaskModal) SetVisible(visible bool) {
+	m.visible = visible
+	if !visible {
+		m.input.Blur()
+		return
+	}
+	m.input.Reset()
+	m.input.Focus()
+}
+
+// IsVisible returns whether the modal is visible
+func (m *TaskModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetItem sets the parent work item
+func (m *TaskModal) SetItem(item *models.WorkItem) {
+	m.item = item
+}
+
+// SetSize sets the modal size
+func (m *TaskModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// CreateTasksRequestMsg is sent when child tasks should be created
+type CreateTasksRequestMsg struct {
+	Parent     models.WorkItem
+	Titles     []string
+	AssignToMe bool
+}
@@ -1,9 +1,10 @@
 package components
 
 import (
-	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,62 +13,60 @@ import (
 	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
 )
 
-// SortField represents the field to sort by
-type SortField int
-
-const (
-	SortByID SortField = iota
-	SortByState
-	SortByType
-)
-
-// SortDirection represents the sort direction
-type SortDirection int
-
-const (
-	SortAsc SortDirection = iota
-	SortDesc
-)
-
-// Column definitions
-type column struct {
-	title    string
-	width    int
-	minWidth int
-	flex     bool // If true, this column takes remaining space
-}
-
 // WorkItemsPanel is the work items list component
 type WorkItemsPanel struct {
-	items     []models.WorkItem
-	cursor    int
-	styles    theme.Styles
-	keys      theme.KeyMap
-	width     int
-	height    int
-	focused   bool
-	offset    int // For scrolling
-	columns   []column
-	sortField SortField
-	sortDir   SortDirection
+	items    []models.WorkItem
+	cursor   int
+	styles   theme.Styles
+	keys     theme.KeyMap
+	width    int
+	height   int
+	focused  bool
+	offset   int // For scrolling
+	columns  []models.Column
+	sortKeys []models.SortKey
+
+	// Grouping
+	groupBy   string // Field ref, "" when not grouped
+	groups    []models.WorkItemGroup
+	collapsed map[string]bool // Collapsed groups by key
+	rows      []listRow       // What the cursor moves over
+
+	// Stale highlighting
+	staleDays    int
+	statesByType map[string][]models.WorkItemStateInfo
+
+	// Work item of the current git branch, marked in the list
+	currentID int
 }
 
 // NewWorkItemsPanel creates a new work items panel
 func NewWorkItemsPanel(styles theme.Styles, keys theme.KeyMap) WorkItemsPanel {
 	return WorkItemsPanel{
-		items:  []models.WorkItem{},
-		styles: styles,
-		keys:   keys,
-		columns: []column{
-			{title: "ID", width: 7, minWidth: 6},
-			{title: "TYPE", width: 8, minWidth: 6},
-			{title: "STATE", width: 12, minWidth: 8},
-			{title: "ASSIGNED", width: 14, minWidth: 10},
-			{title: "TITLE", flex: true, minWidth: 20},
-		},
+		items:     []models.WorkItem{},
+		styles:    styles,
+		keys:      keys,
+		columns:   models.DefaultColumns(),
+		sortKeys:  defaultSortKeys(),
+		collapsed: make(map[string]bool),
 	}
 }
 
+// listRow is a line in the list: either a group header or a work item
+type listRow struct {
+	header bool
+	group  int // Index into groups, -1 when not grouped
+	item   int // Index into items, -1 for headers
+}
+
+// rowTarget identifies a row independently of its position,
+// so the cursor can follow it across re-sorting and regrouping
+type rowTarget struct {
+	itemID   int
+	groupKey string
+	header   bool
+}
+
 // Init initializes the work items panel
 func (w WorkItemsPanel) Init() tea.Cmd {
 	return nil
@@ -82,6 +81,16 @@ func (w WorkItemsPanel) Update(msg tea.Msg) (WorkItemsPanel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case w.onHeader() && key.Matches(msg, w.keys.Select):
+			w.setCollapsed(w.currentGroupKey(), !w.collapsed[w.currentGroupKey()])
+		case w.groupBy != "" && key.Matches(msg, w.keys.Left):
+			w.setCollapsed(w.currentGroupKey(), true)
+		case w.groupBy != "" && key.Matches(msg, w.keys.Right):
+			w.setCollapsed(w.currentGroupKey(), false)
+		case key.Matches(msg, w.keys.GroupBy):
+			w.SetGroupBy(nextGroupBy(w.groupBy))
+			field := w.groupBy
+			return w, func() tea.Msg { return GroupByChangedMsg{Field: field} }
 		case key.Matches(msg, w.keys.Up):
 			w.moveUp()
 		case key.Matches(msg, w.keys.Down):
@@ -99,11 +108,14 @@ func (w WorkItemsPanel) Update(msg tea.Msg) (WorkItemsPanel, tea.Cmd) {
 				return w, func() tea.Msg { return ViewWorkItemMsg{Item: *w.SelectedItem()} }
 			}
 		case key.Matches(msg, w.keys.SortByID):
-			w.toggleSort(SortByID)
+			w.toggleSort(models.FieldID)
+			return w, w.sortChangedCmd()
 		case key.Matches(msg, w.keys.SortByState):
-			w.toggleSort(SortByState)
+			w.toggleSort(models.FieldState)
+			return w, w.sortChangedCmd()
 		case key.Matches(msg, w.keys.SortByType):
-			w.toggleSort(SortByType)
+			w.toggleSort(models.FieldType)
+			return w, w.sortChangedCmd()
 		}
 	}
 
@@ -134,13 +146,18 @@ func (w WorkItemsPanel) View() string {
 	} else {
 		visibleItems := w.visibleItemCount()
 
-		// Render visible items
-		for i := w.offset; i < len(w.items) && i < w.offset+visibleItems; i++ {
-			item := w.items[i]
+		// Render visible rows
+		for i := w.offset; i < len(w.rows) && i < w.offset+visibleItems; i++ {
+			row := w.rows[i]
 			isCursor := i == w.cursor
-			line := w.renderItem(item, isCursor, colWidths)
+			var line string
+			if row.header {
+				line = w.renderGroupHeader(w.groups[row.group], isCursor)
+			} else {
+				line = w.renderItem(w.items[row.item], isCursor, colWidths)
+			}
 			b.WriteString(line)
-			if i < len(w.items)-1 && i < w.offset+visibleItems-1 {
+			if i < len(w.rows)-1 && i < w.offset+visibleItems-1 {
 				b.WriteString("\n")
 			}
 		}
@@ -167,10 +184,10 @@ func (w *WorkItemsPanel) calculateColumnWidths() []int {
 	fixedWidth := 0
 	flexCount := 0
 	for _, col := range w.columns {
-		if col.flex {
+		if col.IsFlex() {
 			flexCount++
 		} else {
-			fixedWidth += col.width + 1 // +1 for separator
+			fixedWidth += col.ColumnWidth() + 2 // +2 for separator
 		}
 	}
 
@@ -183,16 +200,35 @@ func (w *WorkItemsPanel) calculateColumnWidths() []int {
 	// Build widths array
 	widths := make([]int, len(w.columns))
 	for i, col := range w.columns {
-		if col.flex {
+		if col.IsFlex() {
 			widths[i] = flexWidth / flexCount
 		} else {
-			widths[i] = col.width
+			widths[i] = col.ColumnWidth()
 		}
 	}
 
 	return widths
 }
 
+// sortIndicator returns the header suffix for a sorted column, e.g. "▲" or "2▼"
+// when several sort keys are active, or "" if the column isn't sorted
+func (w *WorkItemsPanel) sortIndicator(field string) string {
+	for i, sk := range w.sortKeys {
+		if sk.Field != field {
+			continue
+		}
+		arrow := "▲"
+		if sk.Desc {
+			arrow = "▼"
+		}
+		if len(w.sortKeys) > 1 {
+			return itoa(i+1) + arrow
+		}
+		return arrow
+	}
+	return ""
+}
+
 func (w *WorkItemsPanel) renderHeader(colWidths []int) string {
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -205,19 +241,13 @@ func (w *WorkItemsPanel) renderHeader(colWidths []int) string {
 	var parts []string
 	for i, col := range w.columns {
 		width := colWidths[i]
-		title := col.title
+		title := col.HeaderTitle()
 
 		// Add sort indicator
-		isSorted := (col.title == "ID" && w.sortField == SortByID) ||
-			(col.title == "STATE" && w.sortField == SortByState) ||
-			(col.title == "TYPE" && w.sortField == SortByType)
-
+		indicator := w.sortIndicator(col.Field)
+		isSorted := indicator != ""
 		if isSorted {
-			arrow := "▲"
-			if w.sortDir == SortDesc {
-				arrow = "▼"
-			}
-			title = title + arrow
+			title = title + indicator
 		}
 
 		if len(title) > width {
@@ -243,9 +273,77 @@ func (w *WorkItemsPanel) renderSeparator(colWidths []int) string {
 		contentWidth = 10
 	}
 
+	if w.groupBy != "" {
+		label := "── Grouped by " + models.GroupByLabel(w.groupBy) + " "
+		if len([]rune(label)) < contentWidth {
+			return sepStyle.Render(label + strings.Repeat("─", contentWidth-len([]rune(label))))
+		}
+	}
+
 	return sepStyle.Render(strings.Repeat("─", contentWidth))
 }
 
+// renderGroupHeader renders a group header row with its item count and
+// the sums of story points and remaining work
+func (w *WorkItemsPanel) renderGroupHeader(g models.WorkItemGroup, isCursor bool) string {
+	arrow := "▼"
+	if w.collapsed[g.Key] {
+		arrow = "▶"
+	}
+
+	title := arrow + " " + g.Label
+	stats := "(" + itoa(len(g.Items)) + ")"
+	if g.StoryPoints > 0 {
+		stats += "  " + formatSum(g.StoryPoints) + " pts"
+	}
+	if g.RemainingWork > 0 {
+		stats += "  " + formatSum(g.RemainingWork) + "h remaining"
+	}
+
+	// Keep the stats visible on narrow panels
+	maxTitle := w.width - 8 - len(stats)
+	if maxTitle < 10 {
+		maxTitle = 10
+	}
+	title = truncateStr(title, maxTitle)
+
+	if isCursor {
+		rowStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#F9FAFB")).
+			Background(lipgloss.Color("#7C3AED")).
+			Width(w.width - 4)
+		return rowStyle.Render("▸ " + title + "  " + stats)
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	return "  " + titleStyle.Render(title) + "  " + statsStyle.Render(stats)
+}
+
+// formatSum formats a sum of story points or hours without trailing zeros
+func formatSum(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// cellStyle returns the style of a cell in a non-cursor row
+func (w *WorkItemsPanel) cellStyle(item models.WorkItem, field string) lipgloss.Style {
+	switch field {
+	case models.FieldID:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA"))
+	case models.FieldType:
+		return w.styles.TypeBadge(string(item.Type))
+	case models.FieldState:
+		return w.styles.StateBadge(string(item.State))
+	case models.FieldTitle:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+	case models.FieldAssignedTo:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	}
+}
+
 func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidths []int) string {
 	// Cursor indicator
 	cursor := "  "
@@ -253,16 +351,6 @@ func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidt
 		cursor = "▸ "
 	}
 
-	// Format values
-	id := fmt.Sprintf("#%d", item.ID)
-	typeStr := item.ShortType()
-	stateStr := string(item.State)
-	assigned := truncateStr(item.AssignedTo, colWidths[3])
-	if assigned == "" {
-		assigned = "-"
-	}
-	title := truncateStr(item.Title, colWidths[4])
-
 	// For cursor row, use plain text with unified background
 	if isCursor {
 		rowStyle := lipgloss.NewStyle().
@@ -272,31 +360,29 @@ func (w *WorkItemsPanel) renderItem(item models.WorkItem, isCursor bool, colWidt
 			Width(w.width - 4)
 
 		// Build plain text cells (no individual colors)
-		cells := []string{
-			padRight(truncateStr(id, colWidths[0]), colWidths[0]),
-			padRight(truncateStr(typeStr, colWidths[1]), colWidths[1]),
-			padRight(truncateStr(stateStr, colWidths[2]), colWidths[2]),
-			padRight(assigned, colWidths[3]),
-			padRight(title, colWidths[4]),
+		cells := make([]string, len(w.columns))
+		for i, col := range w.columns {
+			cells[i] = padRight(truncateStr(item.FieldValue(col.Field), colWidths[i]), colWidths[i])
 		}
 		row := cursor + strings.Join(cells, "  ")
 		return rowStyle.Render(row)
 	}
 
-	// Non-cursor rows with individual cell colors
-	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA"))
-	typeStyle := w.styles.TypeBadge(string(item.Type))
-	stateStyle := w.styles.StateBadge(string(item.State))
-	assignedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
-	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+	if item.ID == w.currentID {
+		cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("● ")
+	}
+
+	// Highlight items nobody has touched for a while
+	stale := item.IsStale(w.staleDays, w.statesByType, time.Now())
 
-	// Build cells with proper width and colors
-	cells := []string{
-		idStyle.Width(colWidths[0]).Render(truncateStr(id, colWidths[0])),
-		typeStyle.Width(colWidths[1]).Render(truncateStr(typeStr, colWidths[1])),
-		stateStyle.Width(colWidths[2]).Render(truncateStr(stateStr, colWidths[2])),
-		assignedStyle.Width(colWidths[3]).Render(assigned),
-		titleStyle.Width(colWidths[4]).Render(title),
+	// Non-cursor rows with individual cell colors
+	cells := make([]string, len(w.columns))
+	for i, col := range w.columns {
+		style := w.cellStyle(item, col.Field)
+		if stale && (col.Field == models.FieldID || col.Field == models.FieldTitle) {
+			style = style.Foreground(lipgloss.Color("#F59E0B"))
+		}
+		cells[i] = style.Width(colWidths[i]).Render(truncateStr(item.FieldValue(col.Field), colWidths[i]))
 	}
 
 	row := cursor + strings.Join(cells, "  ")
@@ -338,7 +424,7 @@ func (w *WorkItemsPanel) moveUp() {
 }
 
 func (w *WorkItemsPanel) moveDown() {
-	if w.cursor < len(w.items)-1 {
+	if w.cursor < len(w.rows)-1 {
 		w.cursor++
 	}
 }
@@ -349,24 +435,192 @@ func (w *WorkItemsPanel) moveToTop() {
 }
 
 func (w *WorkItemsPanel) moveToBottom() {
-	if len(w.items) > 0 {
-		w.cursor = len(w.items) - 1
+	if len(w.rows) > 0 {
+		w.cursor = len(w.rows) - 1
 	}
 }
 
-func (w *WorkItemsPanel) toggleSort(field SortField) {
-	if w.sortField == field {
-		// Toggle direction if same field
-		if w.sortDir == SortAsc {
-			w.sortDir = SortDesc
-		} else {
-			w.sortDir = SortAsc
+// scrollToCursor adjusts the offset to keep the cursor visible
+func (w *WorkItemsPanel) scrollToCursor() {
+	visible := w.visibleItemCount()
+	if w.cursor < w.offset {
+		w.offset = w.cursor
+	}
+	if w.cursor >= w.offset+visible {
+		w.offset = w.cursor - visible + 1
+	}
+	if w.offset < 0 {
+		w.offset = 0
+	}
+}
+
+// rebuildRows rebuilds the rows from the items, grouping them if a
+// group-by field is set. Callers restore the cursor afterwards.
+func (w *WorkItemsPanel) rebuildRows() {
+	rows := make([]listRow, 0, len(w.items))
+	if w.groupBy == "" {
+		w.groups = nil
+		for i := range w.items {
+			rows = append(rows, listRow{group: -1, item: i})
 		}
-	} else {
-		w.sortField = field
-		w.sortDir = SortAsc
+		w.rows = rows
+		return
+	}
+
+	w.groups = models.GroupWorkItems(w.items, w.groupBy, w.statesByType)
+	for gi, g := range w.groups {
+		rows = append(rows, listRow{header: true, group: gi, item: -1})
+		if w.collapsed[g.Key] {
+			continue
+		}
+		for _, i := range g.Items {
+			rows = append(rows, listRow{group: gi, item: i})
+		}
+	}
+	w.rows = rows
+}
+
+// cursorTarget returns what the cursor is currently on
+func (w *WorkItemsPanel) cursorTarget() rowTarget {
+	if w.cursor < 0 || w.cursor >= len(w.rows) {
+		return rowTarget{}
+	}
+	return w.rowTarget(w.rows[w.cursor])
+}
+
+func (w *WorkItemsPanel) rowTarget(row listRow) rowTarget {
+	t := rowTarget{header: row.header}
+	if row.item >= 0 {
+		t.itemID = w.items[row.item].ID
+	}
+	if row.group >= 0 {
+		t.groupKey = w.groups[row.group].Key
+	}
+	return t
+}
+
+// restoreCursor moves the cursor to the target row. Items in several groups
+// (tags) prefer the same group. Returns false if the target is gone.
+func (w *WorkItemsPanel) restoreCursor(t rowTarget) bool {
+	if t.itemID == 0 && !t.header {
+		return false
+	}
+	for i, row := range w.rows {
+		if w.rowTarget(row) == t {
+			w.cursor = i
+			return true
+		}
+	}
+	if t.header {
+		return false
+	}
+	for i, row := range w.rows {
+		if row.item >= 0 && w.items[row.item].ID == t.itemID {
+			w.cursor = i
+			return true
+		}
+	}
+	return false
+}
+
+// clampCursor keeps the cursor within the rows and visible
+func (w *WorkItemsPanel) clampCursor() {
+	if w.cursor >= len(w.rows) {
+		w.cursor = len(w.rows) - 1
 	}
+	if w.cursor < 0 {
+		w.cursor = 0
+	}
+	w.scrollToCursor()
+}
+
+// refresh re-sorts and regroups the items, keeping the cursor on the same row
+func (w *WorkItemsPanel) refresh() {
+	target := w.cursorTarget()
 	w.sortItems()
+	w.rebuildRows()
+	w.restoreCursor(target)
+	w.clampCursor()
+}
+
+// onHeader reports whether the cursor is on a group header
+func (w *WorkItemsPanel) onHeader() bool {
+	return w.cursor >= 0 && w.cursor < len(w.rows) && w.rows[w.cursor].header
+}
+
+// currentGroupKey returns the key of the group the cursor is in
+func (w *WorkItemsPanel) currentGroupKey() string {
+	if w.cursor < 0 || w.cursor >= len(w.rows) || w.rows[w.cursor].group < 0 {
+		return ""
+	}
+	return w.groups[w.rows[w.cursor].group].Key
+}
+
+// setCollapsed collapses or expands a group. The cursor moves to the
+// group header when its item is hidden.
+func (w *WorkItemsPanel) setCollapsed(groupKey string, collapsed bool) {
+	if w.groupBy == "" || w.collapsed[groupKey] == collapsed {
+		return
+	}
+	target := w.cursorTarget()
+	w.collapsed[groupKey] = collapsed
+	w.rebuildRows()
+	if !w.restoreCursor(target) {
+		w.restoreCursor(rowTarget{groupKey: groupKey, header: true})
+	}
+	w.clampCursor()
+}
+
+// nextGroupBy returns the group-by field following current, cycling
+// through the groupable fields and back to no grouping
+func nextGroupBy(current string) string {
+	if current == "" {
+		return models.GroupByFields[0]
+	}
+	for i, field := range models.GroupByFields {
+		if field == current && i+1 < len(models.GroupByFields) {
+			return models.GroupByFields[i+1]
+		}
+	}
+	return ""
+}
+
+// SetGroupBy groups the items by a field, or ungroups them when field is ""
+func (w *WorkItemsPanel) SetGroupBy(field string) {
+	if field == w.groupBy {
+		return
+	}
+	target := w.cursorTarget()
+	w.groupBy = field
+	w.collapsed = make(map[string]bool)
+	w.rebuildRows()
+
+	// Headers of the previous grouping no longer exist
+	target.groupKey = ""
+	target.header = false
+	w.restoreCursor(target)
+	w.clampCursor()
+}
+
+// GroupBy returns the group-by field, "" when not grouped
+func (w *WorkItemsPanel) GroupBy() string {
+	return w.groupBy
+}
+
+// defaultSortKeys returns the sort used when nothing else is chosen
+func defaultSortKeys() []models.SortKey {
+	return []models.SortKey{{Field: models.FieldID}}
+}
+
+// toggleSort makes field the only sort key, flipping the direction if it
+// already was the primary key
+func (w *WorkItemsPanel) toggleSort(field string) {
+	desc := false
+	if len(w.sortKeys) > 0 && w.sortKeys[0].Field == field {
+		desc = !w.sortKeys[0].Desc
+	}
+	w.sortKeys = []models.SortKey{{Field: field, Desc: desc}}
+	w.refresh()
 }
 
 func (w *WorkItemsPanel) sortItems() {
@@ -374,42 +628,106 @@ func (w *WorkItemsPanel) sortItems() {
 		return
 	}
 
+	// Fall back to ID so the order is stable across reloads
+	keys := append(append([]models.SortKey{}, w.sortKeys...), models.SortKey{Field: models.FieldID})
 	sort.SliceStable(w.items, func(i, j int) bool {
-		var less bool
-		switch w.sortField {
-		case SortByID:
-			less = w.items[i].ID < w.items[j].ID
-		case SortByState:
-			less = string(w.items[i].State) < string(w.items[j].State)
-		case SortByType:
-			less = string(w.items[i].Type) < string(w.items[j].Type)
-		default:
-			less = w.items[i].ID < w.items[j].ID
-		}
-
-		if w.sortDir == SortDesc {
-			return !less
-		}
-		return less
+		return models.SortWorkItemsLess(&w.items[i], &w.items[j], keys, w.statesByType)
 	})
 }
 
+// sortChangedCmd reports the current sort keys so they can be persisted
+func (w *WorkItemsPanel) sortChangedCmd() tea.Cmd {
+	keys := append([]models.SortKey{}, w.sortKeys...)
+	return func() tea.Msg { return SortChangedMsg{Keys: keys} }
+}
+
+// SetSortKeys sets the sort keys (primary first) and re-sorts the items
+func (w *WorkItemsPanel) SetSortKeys(keys []models.SortKey) {
+	if len(keys) == 0 {
+		keys = defaultSortKeys()
+	}
+	w.sortKeys = keys
+	w.refresh()
+}
+
+// SortKeys returns the current sort keys
+func (w *WorkItemsPanel) SortKeys() []models.SortKey {
+	return w.sortKeys
+}
+
 // SetSize sets the size of the work items panel
 func (w *WorkItemsPanel) SetSize(width, height int) {
 	w.width = width
 	w.height = height
 
 	// Adjust offset to keep cursor visible (now that we have correct height)
-	visible := w.visibleItemCount()
-	if w.cursor < w.offset {
-		w.offset = w.cursor
+	w.scrollToCursor()
+}
+
+// SetColumns sets the visible columns
+func (w *WorkItemsPanel) SetColumns(columns []models.Column) {
+	if len(columns) == 0 {
+		columns = models.DefaultColumns()
 	}
-	if w.cursor >= w.offset+visible {
-		w.offset = w.cursor - visible + 1
+	w.columns = columns
+}
+
+// Columns returns the visible columns
+func (w *WorkItemsPanel) Columns() []models.Column {
+	return w.columns
+}
+
+// UpdateItem replaces a loaded item (matched by ID) with a newer version
+func (w *WorkItemsPanel) UpdateItem(item models.WorkItem) {
+	for i := range w.items {
+		if w.items[i].ID == item.ID {
+			w.items[i] = item
+			// The new version may belong to another group
+			if w.groupBy != "" {
+				w.refresh()
+			}
+			return
+		}
 	}
-	if w.offset < 0 {
-		w.offset = 0
+}
+
+// SetStaleDays sets after how many days without changes an open item is highlighted
+func (w *WorkItemsPanel) SetStaleDays(days int) {
+	w.staleDays = days
+}
+
+// SetStatesByType sets the state metadata used to tell open items from closed ones
+func (w *WorkItemsPanel) SetStatesByType(statesByType map[string][]models.WorkItemStateInfo) {
+	w.statesByType = statesByType
+}
+
+// SetCurrentItemID sets the work item of the current git branch, 0 for none
+func (w *WorkItemsPanel) SetCurrentItemID(id int) {
+	w.currentID = id
+}
+
+// SelectItem moves the cursor to an item, expanding its group if it is
+// collapsed. Returns false if the item isn't in the list.
+func (w *WorkItemsPanel) SelectItem(id int) bool {
+	for _, g := range w.groups {
+		if !w.collapsed[g.Key] {
+			continue
+		}
+		for _, i := range g.Items {
+			if w.items[i].ID == id {
+				w.collapsed[g.Key] = false
+				break
+			}
+		}
+	}
+	w.rebuildRows()
+
+	if !w.restoreCursor(rowTarget{itemID: id}) {
+		w.clampCursor()
+		return false
 	}
+	w.scrollToCursor()
+	return true
 }
 
 // SetFocused sets whether the panel is focused
@@ -419,54 +737,33 @@ func (w *WorkItemsPanel) SetFocused(focused bool) {
 
 // SetItems sets the work items
 func (w *WorkItemsPanel) SetItems(items []models.WorkItem) {
-	// Remember currently selected item ID
-	var selectedID int
-	if w.cursor >= 0 && w.cursor < len(w.items) {
-		selectedID = w.items[w.cursor].ID
-	}
+	// Remember the currently selected row
+	target := w.cursorTarget()
 
 	oldLen := len(w.items)
 	w.items = items
 
-	// Re-apply current sort
+	// Re-apply current sort and grouping
 	w.sortItems()
+	w.rebuildRows()
 
 	// Only reset position if this is new data (not just a refresh)
 	if oldLen == 0 && len(items) > 0 {
 		w.cursor = 0
 		w.offset = 0
-	} else if selectedID > 0 {
-		// Try to restore cursor to previously selected item
-		for i, item := range w.items {
-			if item.ID == selectedID {
-				w.cursor = i
-				break
-			}
-		}
-	}
-
-	// Clamp cursor to valid range
-	if w.cursor >= len(items) {
-		w.cursor = len(items) - 1
-	}
-	if w.cursor < 0 {
-		w.cursor = 0
+	} else {
+		// Try to restore cursor to previously selected row
+		w.restoreCursor(target)
 	}
 
-	// Adjust offset to keep cursor visible
-	visible := w.visibleItemCount()
-	if w.cursor < w.offset {
-		w.offset = w.cursor
-	}
-	if w.cursor >= w.offset+visible {
-		w.offset = w.cursor - visible + 1
-	}
+	w.clampCursor()
 }
 
-// SelectedItem returns the currently selected work item
+// SelectedItem returns the currently selected work item, or nil when the
+// cursor is on a group header
 func (w *WorkItemsPanel) SelectedItem() *models.WorkItem {
-	if w.cursor >= 0 && w.cursor < len(w.items) {
-		return &w.items[w.cursor]
+	if w.cursor >= 0 && w.cursor < len(w.rows) && !w.rows[w.cursor].header {
+		return &w.items[w.rows[w.cursor].item]
 	}
 	return nil
 }
@@ -480,3 +777,8 @@ type OpenWorkItemMsg struct {
 type ViewWorkItemMsg struct {
 	Item models.WorkItem
 }
+
+// GroupByChangedMsg is sent when the list is grouped by another field
+type GroupByChangedMsg struct {
+	Field string // "" when grouping was turned off
+}
@@ -0,0 +1,165 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samuelenocsson/devops-tui/internal/models"
+	"github.com/samuelenocsson/devops-tui/internal/ui/theme"
+)
+
+// WorkModal previews the steps of starting or finishing work on an item,
+// letting each be switched off before they run
+type WorkModal struct {
+	visible bool
+	plan    models.WorkPlan
+	note    string // Why nothing needs to change, or a warning about the plan
+	cursor  int
+	styles  theme.Styles
+	keys    theme.KeyMap
+	width   int
+	height  int
+}
+
+// NewWorkModal creates a new start/finish work modal
+func NewWorkModal(styles theme.Styles, keys theme.KeyMap) WorkModal {
+	return WorkModal{
+		styles: styles,
+		keys:   keys,
+	}
+}
+
+// Init initializes the modal
+func (m WorkModal) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m WorkModal) Update(msg tea.Msg) (WorkModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Back):
+			m.visible = false
+			return m, func() tea.Msg { return ModalClosedMsg{} }
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.plan.Steps)-1 {
+				m.cursor++
+			}
+		case msg.String() == " ":
+			if m.cursor < len(m.plan.Steps) {
+				m.plan.Steps[m.cursor].Enabled = !m.plan.Steps[m.cursor].Enabled
+			}
+		case msg.Type == tea.KeyEnter:
+			if _, ok := m.firstEnabled(); !ok {
+				return m, nil
+			}
+			plan := m.plan
+			return m, func() tea.Msg { return WorkRequestMsg{Plan: plan} }
+		}
+	}
+
+	return m, nil
+}
+
+// firstEnabled returns the first step that will run
+func (m WorkModal) firstEnabled() (models.WorkStep, bool) {
+	for _, s := range m.plan.Steps {
+		if s.Enabled {
+			return s, true
+		}
+	}
+	return models.WorkStep{}, false
+}
+
+// View renders the modal
+func (m WorkModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 60
+
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.plan.Title()) + "\n")
+	b.WriteString(lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render("#"+itoa(m.plan.Item.ID)+" "+truncateStr(m.plan.Item.Title, 45)) + "\n\n")
+
+	if len(m.plan.Steps) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("Nothing to change") + "\n")
+	}
+
+	for i, step := range m.plan.Steps {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+		check := "[ ] "
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		if step.Enabled {
+			check = "[x] "
+			style = lipgloss.NewStyle()
+		}
+		if i == m.cursor {
+			style = style.Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+		}
+		b.WriteString(cursor + style.Render(check+truncateStr(step.Description(), modalWidth-12)) + "\n")
+	}
+
+	if m.note != "" {
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render(m.note) + "\n")
+	}
+
+	b.WriteString("\n")
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	b.WriteString(helpStyle.Render("Space: toggle step  Enter: run  Esc: cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Background(lipgloss.Color("#1F2937"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalStyle.Render(b.String()))
+}
+
+// SetPlan sets the steps to preview, with a note shown below them
+func (m *WorkModal) SetPlan(plan models.WorkPlan, note string) {
+	m.plan = plan
+	m.note = note
+	m.cursor = 0
+}
+
+// SetVisible sets the visibility
+func (m *WorkModal) SetVisible(visible bool) {
+	m.visible = visible
+}
+
+// IsVisible returns whether the modal is visible
+func (m *WorkModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal container size
+func (m *WorkModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// WorkRequestMsg is sent when the previewed steps should run
+type WorkRequestMsg struct {
+	Plan models.WorkPlan
+}
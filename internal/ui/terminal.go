@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"os"
+	"sync"
+)
+
+// Terminal is the program's output. The renderer's frames and the alerts
+// sent from commands, like the bell, are written one at a time, so an
+// alert can't end up inside a frame.
+type Terminal struct {
+	*os.File
+	mu sync.Mutex
+}
+
+// NewTerminal wraps the file the program renders to
+func NewTerminal(f *os.File) *Terminal {
+	return &Terminal{File: f}
+}
+
+// Write writes output of the renderer
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.File.Write(p)
+}
+
+// WriteString writes output of the renderer, or an alert between frames
+func (t *Terminal) WriteString(s string) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.File.WriteString(s)
+}
@@ -15,22 +15,39 @@ type KeyMap struct {
 	PrevPanel key.Binding
 
 	// Actions
-	Select       key.Binding
-	Open         key.Binding
-	View         key.Binding
-	Search       key.Binding
-	Refresh      key.Binding
-	Help         key.Binding
-	Back         key.Binding
-	Quit         key.Binding
-	ChangeState  key.Binding
-	CreateBranch key.Binding
-	Assign       key.Binding
+	Select        key.Binding
+	Open          key.Binding
+	View          key.Binding
+	Search        key.Binding
+	Refresh       key.Binding
+	Help          key.Binding
+	Back          key.Binding
+	Quit          key.Binding
+	ChangeState   key.Binding
+	CreateBranch  key.Binding
+	Assign        key.Binding
+	Columns       key.Binding
+	Links         key.Binding
+	AddTasks      key.Binding
+	Graph         key.Binding
+	PullRequest   key.Binding
+	CurrentItem   key.Binding
+	StartWork     key.Binding
+	FinishWork    key.Binding
+	Pipelines     key.Binding
+	PullRequests  key.Binding
+	Notifications key.Binding
+	Mentions      key.Binding
+	Comment       key.Binding
 
 	// Sorting
 	SortByID    key.Binding
 	SortByState key.Binding
 	SortByType  key.Binding
+	Sort        key.Binding
+
+	// Grouping
+	GroupBy key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -112,6 +129,58 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "assign"),
 		),
+		Columns: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "choose columns"),
+		),
+		Links: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "edit links"),
+		),
+		AddTasks: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "add child tasks"),
+		),
+		Graph: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "dependency graph"),
+		),
+		PullRequest: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "create pull request"),
+		),
+		CurrentItem: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "go to current branch item"),
+		),
+		StartWork: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "start work"),
+		),
+		FinishWork: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "finish work"),
+		),
+		Pipelines: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pipelines"),
+		),
+		PullRequests: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "pull requests"),
+		),
+		Notifications: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "notifications"),
+		),
+		Mentions: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "mentions"),
+		),
+		Comment: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "comment (detail view)"),
+		),
 		SortByID: key.NewBinding(
 			key.WithKeys("1"),
 			key.WithHelp("1", "sort by ID"),
@@ -124,6 +193,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("3"),
 			key.WithHelp("3", "sort by state"),
 		),
+		Sort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "sort by columns"),
+		),
+		GroupBy: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "cycle group by"),
+		),
 	}
 }
 
@@ -144,8 +221,10 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.Top, k.Bottom},
 		{k.NextPanel, k.PrevPanel},
 		{k.Select, k.Open, k.View},
-		{k.ChangeState, k.CreateBranch, k.Assign},
-		{k.SortByID, k.SortByType, k.SortByState},
+		{k.ChangeState, k.CreateBranch, k.Assign, k.Columns, k.Links, k.AddTasks, k.Graph, k.PullRequest, k.CurrentItem},
+		{k.StartWork, k.FinishWork, k.Pipelines, k.PullRequests, k.Notifications, k.Mentions, k.Comment},
+		{k.SortByID, k.SortByType, k.SortByState, k.Sort},
+		{k.GroupBy, k.Left, k.Right},
 		{k.Search, k.Refresh},
 		{k.Help, k.Back, k.Quit},
 	}
@@ -1,6 +1,10 @@
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Colors
 var (
@@ -43,6 +47,22 @@ var stateColors = map[string]lipgloss.Color{
 	"Approved":    lipgloss.Color("#10B981"), // Green
 }
 
+// Pull request and build status colors, keyed by lowercase status without spaces
+var statusColors = map[string]lipgloss.Color{
+	"active":             lipgloss.Color("#3B82F6"), // Blue
+	"draft":              colorMuted,
+	"completed":          colorSuccess,
+	"abandoned":          colorMuted,
+	"notstarted":         colorMuted,
+	"inprogress":         lipgloss.Color("#3B82F6"), // Blue
+	"pending":            lipgloss.Color("#3B82F6"), // Blue
+	"cancelling":         colorMuted,
+	"succeeded":          colorSuccess,
+	"partiallysucceeded": colorWarning,
+	"failed":             colorError,
+	"canceled":           colorMuted,
+}
+
 // Styles defines all UI styles
 type Styles struct {
 	// Base styles
@@ -86,6 +106,9 @@ type Styles struct {
 	// Type and state badges
 	TypeBadge  func(string) lipgloss.Style
 	StateBadge func(string) lipgloss.Style
+
+	// Pull request and build status badge, e.g. "Active" or "Partially succeeded"
+	StatusBadge func(string) lipgloss.Style
 }
 
 // DefaultStyles returns the default styles
@@ -230,5 +253,15 @@ func DefaultStyles() Styles {
 			return lipgloss.NewStyle().
 				Foreground(color)
 		},
+
+		StatusBadge: func(s string) lipgloss.Style {
+			color := statusColors[strings.ToLower(strings.ReplaceAll(s, " ", ""))]
+			if color == "" {
+				color = colorMuted
+			}
+			return lipgloss.NewStyle().
+				Foreground(color).
+				Bold(true)
+		},
 	}
 }
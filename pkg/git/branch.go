@@ -2,20 +2,19 @@ package git
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 )
 
-// IsGitRepo checks if the current directory is a git repository
-func IsGitRepo() bool {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
+// IsGitRepo checks if the working tree is in a git repository
+func (r Repo) IsGitRepo() bool {
+	cmd := r.command("rev-parse", "--git-dir")
 	err := cmd.Run()
 	return err == nil
 }
 
 // GetCurrentBranch returns the current branch name
-func GetCurrentBranch() (string, error) {
-	cmd := exec.Command("git", "branch", "--show-current")
+func (r Repo) GetCurrentBranch() (string, error) {
+	cmd := r.command("branch", "--show-current")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("getting current branch: %w", err)
@@ -24,28 +23,28 @@ func GetCurrentBranch() (string, error) {
 }
 
 // BranchExists checks if a branch exists
-func BranchExists(name string) bool {
-	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+name)
+func (r Repo) BranchExists(name string) bool {
+	cmd := r.command("show-ref", "--verify", "--quiet", "refs/heads/"+name)
 	err := cmd.Run()
 	return err == nil
 }
 
 // CreateBranch creates a new branch and optionally checks it out
-func CreateBranch(name string, checkout bool) error {
-	if BranchExists(name) {
+func (r Repo) CreateBranch(name string, checkout bool) error {
+	if r.BranchExists(name) {
 		return fmt.Errorf("branch '%s' already exists", name)
 	}
 
 	if checkout {
 		// Create and checkout
-		cmd := exec.Command("git", "checkout", "-b", name)
+		cmd := r.command("checkout", "-b", name)
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			return fmt.Errorf("creating branch: %s", strings.TrimSpace(string(output)))
 		}
 	} else {
 		// Just create
-		cmd := exec.Command("git", "branch", name)
+		cmd := r.command("branch", name)
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			return fmt.Errorf("creating branch: %s", strings.TrimSpace(string(output)))
@@ -55,9 +54,82 @@ func CreateBranch(name string, checkout bool) error {
 	return nil
 }
 
+// CreateBranchFrom creates a branch from base and checks it out. The new
+// branch doesn't track base, so pushing it sets its own upstream.
+func (r Repo) CreateBranchFrom(name, base string) error {
+	if base == "" {
+		return r.CreateBranch(name, true)
+	}
+	if r.BranchExists(name) {
+		return fmt.Errorf("branch '%s' already exists", name)
+	}
+
+	cmd := r.command("checkout", "--no-track", "-b", name, base)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("creating branch: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RemoteBranchExists checks if a remote-tracking branch exists, e.g.
+// "feature/x" on "origin"
+func (r Repo) RemoteBranchExists(remote, name string) bool {
+	cmd := r.command("show-ref", "--verify", "--quiet", "refs/remotes/"+remote+"/"+name)
+	return cmd.Run() == nil
+}
+
+// Fetch updates the remote-tracking branches of a remote
+func (r Repo) Fetch(remote string) error {
+	cmd := r.command("fetch", "--prune", remote)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		return fmt.Errorf("fetching %s: %s", remote, lines[len(lines)-1])
+	}
+	return nil
+}
+
+// ListBranches returns the local branches and the remote-tracking branches,
+// the latter prefixed with their remote (e.g. "origin/main")
+func (r Repo) ListBranches() (local, remote []string, err error) {
+	cmd := r.command("for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing branches: %w", err)
+	}
+
+	for _, ref := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			local = append(local, strings.TrimPrefix(ref, "refs/heads/"))
+		case strings.HasPrefix(ref, "refs/remotes/") && !strings.HasSuffix(ref, "/HEAD"):
+			remote = append(remote, strings.TrimPrefix(ref, "refs/remotes/"))
+		}
+	}
+	return local, remote, nil
+}
+
+// DefaultBranch returns the default branch of a remote as a remote-tracking
+// branch (e.g. "origin/main"), or "" when it isn't known
+func (r Repo) DefaultBranch(remote string) string {
+	cmd := r.command("symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if output, err := cmd.Output(); err == nil {
+		return strings.TrimSpace(string(output))
+	}
+
+	// Clones made without a remote HEAD, e.g. with git init and remote add
+	for _, name := range []string{"main", "master"} {
+		if r.RemoteBranchExists(remote, name) {
+			return remote + "/" + name
+		}
+	}
+	return ""
+}
+
 // CheckoutBranch checks out an existing branch
-func CheckoutBranch(name string) error {
-	cmd := exec.Command("git", "checkout", name)
+func (r Repo) CheckoutBranch(name string) error {
+	cmd := r.command("checkout", name)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("checking out branch: %s", strings.TrimSpace(string(output)))
@@ -66,11 +138,17 @@ func CheckoutBranch(name string) error {
 }
 
 // HasUncommittedChanges checks if there are uncommitted changes
-func HasUncommittedChanges() bool {
-	cmd := exec.Command("git", "status", "--porcelain")
+func (r Repo) HasUncommittedChanges() bool {
+	cmd := r.command("status", "--porcelain")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
 	}
 	return len(strings.TrimSpace(string(output))) > 0
 }
+
+// Merging checks if a merge is being concluded
+func (r Repo) Merging() bool {
+	cmd := r.command("rev-parse", "-q", "--verify", "MERGE_HEAD")
+	return cmd.Run() == nil
+}
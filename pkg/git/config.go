@@ -0,0 +1,75 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// startedWorkItemKey returns the repository config key holding the work
+// item explicitly started on a branch
+func startedWorkItemKey(branch string) string {
+	return "branch." + branch + ".devops-tui-workitem"
+}
+
+// StartedWorkItem returns the work item started on a branch, or 0 when
+// none is
+func (r Repo) StartedWorkItem(branch string) int {
+	if branch == "" {
+		return 0
+	}
+	cmd := r.command("config", "--local", "--get", startedWorkItemKey(branch))
+	output, err := cmd.Output()
+	if err != nil {
+		return 0
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// SetStartedWorkItem records the work item being worked on in a branch,
+// taking precedence over the ID in the branch name
+func (r Repo) SetStartedWorkItem(branch string, id int) error {
+	if branch == "" {
+		return fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	cmd := r.command("config", "--local", startedWorkItemKey(branch), strconv.Itoa(id))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("setting started work item: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ClearStartedWorkItem forgets the work item started on a branch
+func (r Repo) ClearStartedWorkItem(branch string) error {
+	if r.StartedWorkItem(branch) == 0 {
+		return nil
+	}
+	cmd := r.command("config", "--local", "--unset", startedWorkItemKey(branch))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("clearing started work item: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// CommentChar returns core.commentChar (or core.commentString), the prefix
+// of the lines git strips from commit messages: "#" unless configured, and
+// "auto" when git picks one per message
+func (r Repo) CommentChar() string {
+	for _, key := range []string{"core.commentString", "core.commentChar"} {
+		cmd := r.command("config", "--get", key)
+		output, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		if value := strings.TrimRight(string(output), "\n"); value != "" {
+			return value
+		}
+	}
+	return "#"
+}
@@ -0,0 +1,35 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// HasCommit checks if a commit is in the local repository
+func (r Repo) HasCommit(sha string) bool {
+	if sha == "" {
+		return false
+	}
+	cmd := r.command("cat-file", "-e", sha+"^{commit}")
+	return cmd.Run() == nil
+}
+
+// DiffFile returns the unified diff of a file between two commits. Paths
+// are relative to the repository root; a renamed file is compared to its
+// old path.
+func (r Repo) DiffFile(base, head, oldPath, path string) (string, error) {
+	args := []string{"diff", "--no-color", "--no-ext-diff", "-M", base, head, "--", ":(top)" + path}
+	if oldPath != "" && oldPath != path {
+		args = append(args, ":(top)"+oldPath)
+	}
+	cmd := r.command(args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("diffing %s: %s", path, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("diffing %s: %w", path, err)
+	}
+	return string(output), nil
+}
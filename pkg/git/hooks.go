@@ -0,0 +1,18 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// HookPath returns the path of a hook script in the repository,
+// honoring core.hooksPath
+func (r Repo) HookPath(name string) (string, error) {
+	cmd := r.command("rev-parse", "--git-path", "hooks/"+name)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("finding hooks directory: %w", err)
+	}
+	return filepath.Abs(strings.TrimSpace(string(output)))
+}
@@ -0,0 +1,121 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AzureRemote is a remote repository hosted in Azure Repos
+type AzureRemote struct {
+	Organization string
+	Project      string
+	Repository   string
+}
+
+// GetRemoteURL returns the URL of a remote, e.g. "origin"
+func (r Repo) GetRemoteURL(remote string) (string, error) {
+	cmd := r.command("remote", "get-url", remote)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("getting URL of remote '%s': %w", remote, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ParseAzureRemote parses an Azure Repos remote URL in one of the forms
+//
+//	https://dev.azure.com/{org}/{project}/_git/{repo}
+//	https://{user}@dev.azure.com/{org}/{project}/_git/{repo}
+//	git@ssh.dev.azure.com:v3/{org}/{project}/{repo}
+//	https://{org}.visualstudio.com/[DefaultCollection/]{project}/_git/{repo}
+func ParseAzureRemote(remoteURL string) (AzureRemote, bool) {
+	var parts []string
+
+	switch {
+	case strings.HasPrefix(remoteURL, "git@ssh.dev.azure.com:v3/"):
+		parts = strings.Split(strings.TrimPrefix(remoteURL, "git@ssh.dev.azure.com:v3/"), "/")
+
+	case strings.HasPrefix(remoteURL, "ssh://"):
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.Hostname() != "ssh.dev.azure.com" {
+			return AzureRemote{}, false
+		}
+		parts = strings.Split(strings.TrimPrefix(u.Path, "/v3/"), "/")
+
+	default:
+		u, err := url.Parse(remoteURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return AzureRemote{}, false
+		}
+		path := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+		host := u.Hostname()
+		switch {
+		case host == "dev.azure.com":
+		case strings.HasSuffix(host, ".visualstudio.com"):
+			org := strings.TrimSuffix(host, ".visualstudio.com")
+			if len(path) > 0 && strings.EqualFold(path[0], "DefaultCollection") {
+				path = path[1:]
+			}
+			path = append([]string{org}, path...)
+		default:
+			return AzureRemote{}, false
+		}
+
+		// {org}/{project}/_git/{repo}
+		if len(path) != 4 || path[2] != "_git" {
+			return AzureRemote{}, false
+		}
+		parts = []string{path[0], path[1], path[3]}
+	}
+
+	if len(parts) != 3 {
+		return AzureRemote{}, false
+	}
+	for i, p := range parts {
+		if unescaped, err := url.PathUnescape(p); err == nil {
+			parts[i] = unescaped
+		}
+		if parts[i] == "" {
+			return AzureRemote{}, false
+		}
+	}
+
+	return AzureRemote{
+		Organization: parts[0],
+		Project:      parts[1],
+		Repository:   strings.TrimSuffix(parts[2], ".git"),
+	}, true
+}
+
+// UpstreamStatus reports whether a branch has an upstream branch, and how
+// many of its commits haven't been pushed there
+func (r Repo) UpstreamStatus(branch string) (hasUpstream bool, ahead int, err error) {
+	cmd := r.command("rev-parse", "--abbrev-ref", branch+"@{upstream}")
+	if err := cmd.Run(); err != nil {
+		return false, 0, nil
+	}
+
+	cmd = r.command("rev-list", "--count", branch+"@{upstream}.."+branch)
+	output, err := cmd.Output()
+	if err != nil {
+		return true, 0, fmt.Errorf("counting unpushed commits: %w", err)
+	}
+	ahead, err = strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return true, 0, fmt.Errorf("counting unpushed commits: %w", err)
+	}
+	return true, ahead, nil
+}
+
+// Push pushes a branch to a remote and sets it as the branch's upstream
+func (r Repo) Push(remote, branch string) error {
+	cmd := r.command("push", "--set-upstream", remote, branch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("pushing branch: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package git
+
+import "os/exec"
+
+// Repo runs git in a working tree. The zero value uses the current
+// directory.
+type Repo struct {
+	Dir string
+}
+
+// command returns a git command running in the repository's working tree
+func (r Repo) command(args ...string) *exec.Cmd {
+	if r.Dir != "" {
+		args = append([]string{"-C", r.Dir}, args...)
+	}
+	return exec.Command("git", args...)
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Worktree is a working tree of the repository
+type Worktree struct {
+	Path   string
+	Branch string // Checked out branch, "" when detached
+	Main   bool   // The repository's main working tree
+}
+
+// ListWorktrees lists the working trees of the repository, the
+// main one first
+func (r Repo) ListWorktrees() ([]Worktree, error) {
+	cmd := r.command("worktree", "list", "--porcelain")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(string(output)), "\n\n") {
+		var wt Worktree
+		for _, line := range strings.Split(block, "\n") {
+			switch {
+			case strings.HasPrefix(line, "worktree "):
+				wt.Path = strings.TrimPrefix(line, "worktree ")
+			case strings.HasPrefix(line, "branch "):
+				wt.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+			}
+		}
+		if wt.Path == "" {
+			continue
+		}
+		wt.Main = len(worktrees) == 0
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees, nil
+}
+
+// AddWorktree checks out a branch in a new working tree at path. A branch
+// that doesn't exist is created from base, or HEAD when base is empty; it
+// tracks base only when base is the branch's own remote-tracking branch.
+func (r Repo) AddWorktree(path, branch, base string) error {
+	var args []string
+	switch {
+	case r.BranchExists(branch):
+		args = []string{"worktree", "add", path, branch}
+	case base == "":
+		args = []string{"worktree", "add", "-b", branch, path}
+	case strings.HasSuffix(base, "/"+branch):
+		args = []string{"worktree", "add", "--track", "-b", branch, path, base}
+	default:
+		args = []string{"worktree", "add", "--no-track", "-b", branch, path, base}
+	}
+	cmd := r.command(args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// The error follows git's progress output
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		return fmt.Errorf("creating worktree: %s", lines[len(lines)-1])
+	}
+	return nil
+}